@@ -1,5 +1,7 @@
 package ast
 
+import "strings"
+
 //Action is a action like used in the SecAction directive
 // https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#Actions
 type Action interface {
@@ -250,6 +252,26 @@ func (frbv *ActionCTLForceRequestBodyVariable) Children() []Node {
 	return []Node{}
 }
 
+//ActionCTLHashEnforcement is a special purpose "Directive" which should only be used as Option value for ActionCTL
+// The hashEnforcement option enables or disables the verification of the signatures created by the hash engine for the current transaction.
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#ctl
+type ActionCTLHashEnforcement struct {
+	AbstractNode
+
+	Enabled bool
+}
+
+func (he *ActionCTLHashEnforcement) Name() string {
+	return "hashEnforcement"
+}
+
+//Directive is a marker to associate the struct with the Directive interface
+func (he *ActionCTLHashEnforcement) Directive() {}
+
+func (he *ActionCTLHashEnforcement) Children() []Node {
+	return []Node{}
+}
+
 type RequestBodyProcessorType string
 
 const (
@@ -416,6 +438,36 @@ func (action *ActionDrop) Children() []Node {
 	return []Node{}
 }
 
+//ActionExec Executes an external script/binary supplied as parameter.
+// As of v2.5.0, if the parameter supplied to exec is a Lua script (detected by the .lua extension) the script will be processed internally.
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#exec
+type ActionExec struct {
+	AbstractNode
+
+	//The path to the script or binary
+	Path string
+
+	//The contents of the script, only set for Lua scripts if the loader was asked to resolve scripts
+	Script []byte
+}
+
+func (action *ActionExec) Name() string {
+	return "exec"
+}
+
+func (action *ActionExec) ActionType() ActionType {
+	return ACTION_TYPE_NON_DISRUPTIVE
+}
+
+func (action *ActionExec) Children() []Node {
+	return []Node{}
+}
+
+//IsLua returns true if the executed file is a Lua script which is processed by ModSecurity itself
+func (action *ActionExec) IsLua() bool {
+	return strings.HasSuffix(strings.ToLower(action.Path), ".lua")
+}
+
 //ActionExpireVar Configures a collection variable to expire after the given time period (in seconds).
 // https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#expirevar
 type ActionExpireVar struct {
@@ -440,7 +492,7 @@ func (action *ActionExpireVar) ActionType() ActionType {
 }
 
 func (action *ActionExpireVar) Children() []Node {
-	return []Node{action.Collection, action.Variable}
+	return []Node{action.Collection, action.Variable, action.TTL}
 }
 
 //ActionID  Assigns a unique ID to the rule or chain in which it appears.
@@ -615,6 +667,27 @@ func (action *ActionPass) Children() []Node {
 	return []Node{}
 }
 
+//ActionPause Pauses transaction processing for the specified number of milliseconds.
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#pause
+type ActionPause struct {
+	AbstractNode
+
+	//The duration of the pause in milliseconds
+	Value int
+}
+
+func (action *ActionPause) Name() string {
+	return "pause"
+}
+
+func (action *ActionPause) ActionType() ActionType {
+	return ACTION_TYPE_DISRUPTIVE
+}
+
+func (action *ActionPause) Children() []Node {
+	return []Node{}
+}
+
 //ActionPhase Places the rule or chain into one of five available processing phases.
 // It can also be used in SecDefaultAction to establish the rule defaults.
 // https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#phase
@@ -635,6 +708,48 @@ func (action *ActionPhase) Children() []Node {
 	return []Node{}
 }
 
+//ActionProxy Intercepts the current transaction by forwarding the request to another web server using the proxy backend.
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#proxy
+type ActionProxy struct {
+	AbstractNode
+
+	//The URL of the backend
+	Value *ExpandableString
+}
+
+func (action *ActionProxy) Name() string {
+	return "proxy"
+}
+
+func (action *ActionProxy) ActionType() ActionType {
+	return ACTION_TYPE_DISRUPTIVE
+}
+
+func (action *ActionProxy) Children() []Node {
+	return []Node{action.Value}
+}
+
+//ActionRedirect Intercepts transaction by issuing an external (client-visible) redirection to the given location.
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#redirect
+type ActionRedirect struct {
+	AbstractNode
+
+	//The location to redirect to
+	Value *ExpandableString
+}
+
+func (action *ActionRedirect) Name() string {
+	return "redirect"
+}
+
+func (action *ActionRedirect) ActionType() ActionType {
+	return ACTION_TYPE_DISRUPTIVE
+}
+
+func (action *ActionRedirect) Children() []Node {
+	return []Node{action.Value}
+}
+
 //ActionSeverity Assigns severity to the rule in which it is used.
 // https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#severity
 type ActionSeverity struct {
@@ -1,5 +1,11 @@
 package ast
 
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
 type Directive interface {
 	Node
 	Directive()
@@ -38,6 +44,28 @@ func (dir *DirectiveSecAction) AddAction(action Action) {
 	dir.ActionNodes = append(dir.ActionNodes, action)
 }
 
+//DirectiveSecArgumentSeparator Specifies which character to use as the separator for application/x-www-form-urlencoded content.
+// The default is the ampersand (&), some applications use a semicolon (;) instead.
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecArgumentSeparator
+type DirectiveSecArgumentSeparator struct {
+	AbstractNode
+
+	//The separator, this is always a single character
+	Value string
+}
+
+func (dir *DirectiveSecArgumentSeparator) Name() string {
+	return "SecArgumentSeparator"
+}
+
+//Directive is a marker to associate the struct with the Directive interface
+func (dir *DirectiveSecArgumentSeparator) Directive() {}
+
+//Children returns all child nodes, this satisfies the Node interface
+func (dir *DirectiveSecArgumentSeparator) Children() []Node {
+	return []Node{}
+}
+
 //SecAuditEngineValue is the value of the DirectiveSecAuditEngine directive
 // https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecAuditEngine
 type SecAuditEngineValue string
@@ -67,6 +95,142 @@ func (dir *DirectiveSecAuditEngine) Children() []Node {
 	return []Node{}
 }
 
+//DirectiveSecAuditLog Defines the path to the main audit log file (serial logging format) or the concurrent logging index file (concurrent logging format).
+// When used in combination with mlogc (only possible with concurrent logging), this directive defines the mlogc location and command line.
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecAuditLog
+type DirectiveSecAuditLog struct {
+	AbstractNode
+
+	//The path to the log file or, if it starts with a pipe (|), the command to which the log is piped
+	Value string
+}
+
+func (dir *DirectiveSecAuditLog) Name() string {
+	return "SecAuditLog"
+}
+
+//Directive is a marker to associate the struct with the Directive interface
+func (dir *DirectiveSecAuditLog) Directive() {}
+
+//Children returns all child nodes, this satisfies the Node interface
+func (dir *DirectiveSecAuditLog) Children() []Node {
+	return []Node{}
+}
+
+//DirectiveSecAuditLog2 Defines the path to the secondary audit log index file when concurrent logging is enabled. See SecAuditLog for more details.
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecAuditLog2
+type DirectiveSecAuditLog2 struct {
+	AbstractNode
+
+	//The path to the log file or, if it starts with a pipe (|), the command to which the log is piped
+	Value string
+}
+
+func (dir *DirectiveSecAuditLog2) Name() string {
+	return "SecAuditLog2"
+}
+
+//Directive is a marker to associate the struct with the Directive interface
+func (dir *DirectiveSecAuditLog2) Directive() {}
+
+//Children returns all child nodes, this satisfies the Node interface
+func (dir *DirectiveSecAuditLog2) Children() []Node {
+	return []Node{}
+}
+
+//FileMode is the value of directives which configure the permissions of files or directories created by ModSecurity.
+// The value is either a octal unix permission mask or the keyword "default", in which case Default is true and the permissions of the web server are used.
+type FileMode struct {
+	Default bool
+	Mode    os.FileMode
+}
+
+func (fm FileMode) Valid() bool {
+	return fm.Default || fm.Mode <= 07777
+}
+
+func (fm FileMode) String() string {
+	if fm.Default {
+		return "default"
+	}
+
+	return fmt.Sprintf("%04o", uint32(fm.Mode))
+}
+
+//DirectiveSecAuditLogDirMode Configures the mode (permissions) of any directories created for the concurrent audit logs, using an octal mode value as parameter (as used in chmod).
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecAuditLogDirMode
+type DirectiveSecAuditLogDirMode struct {
+	AbstractNode
+	Value FileMode
+}
+
+func (dir *DirectiveSecAuditLogDirMode) Name() string {
+	return "SecAuditLogDirMode"
+}
+
+//Directive is a marker to associate the struct with the Directive interface
+func (dir *DirectiveSecAuditLogDirMode) Directive() {}
+
+//Children returns all child nodes, this satisfies the Node interface
+func (dir *DirectiveSecAuditLogDirMode) Children() []Node {
+	return []Node{}
+}
+
+//DirectiveSecAuditLogFileMode Configures the mode (permissions) of any files created for concurrent audit logs using an octal mode (as used in chmod).
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecAuditLogFileMode
+type DirectiveSecAuditLogFileMode struct {
+	AbstractNode
+	Value FileMode
+}
+
+func (dir *DirectiveSecAuditLogFileMode) Name() string {
+	return "SecAuditLogFileMode"
+}
+
+//Directive is a marker to associate the struct with the Directive interface
+func (dir *DirectiveSecAuditLogFileMode) Directive() {}
+
+//Children returns all child nodes, this satisfies the Node interface
+func (dir *DirectiveSecAuditLogFileMode) Children() []Node {
+	return []Node{}
+}
+
+//SecAuditLogFormatValue is the value of the DirectiveSecAuditLogFormat directive
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecAuditLogFormat
+type SecAuditLogFormatValue string
+
+const (
+	//SecAuditLogFormatJSON logs every audit log entry as a JSON object
+	SecAuditLogFormatJSON SecAuditLogFormatValue = "JSON"
+
+	//SecAuditLogFormatNative logs every audit log entry in the native multi part format
+	SecAuditLogFormatNative SecAuditLogFormatValue = "Native"
+)
+
+func (alf SecAuditLogFormatValue) Valid() bool {
+	return alf == SecAuditLogFormatJSON ||
+		alf == SecAuditLogFormatNative
+}
+
+//DirectiveSecAuditLogFormat Select the output format of the AuditLogs. The format can be either the native AuditLogs format or JSON.
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecAuditLogFormat
+type DirectiveSecAuditLogFormat struct {
+	AbstractNode
+	Value SecAuditLogFormatValue
+}
+
+func (dir *DirectiveSecAuditLogFormat) Name() string {
+	return "SecAuditLogFormat"
+}
+
+//Directive is a marker to associate the struct with the Directive interface
+func (dir *DirectiveSecAuditLogFormat) Directive() {}
+
+//Children returns all child nodes, this satisfies the Node interface
+func (dir *DirectiveSecAuditLogFormat) Children() []Node {
+	return []Node{}
+}
+
 //SecAuditLogPart describes a single part of the audit log format
 // https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#SecAuditLogParts
 type SecAuditLogPart rune
@@ -146,137 +310,1570 @@ func (dir *DirectiveSecAuditLogParts) Children() []Node {
 	return []Node{}
 }
 
-//DirectiveSecComponentSignature Appends component signature to the ModSecurity signature.
-// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#seccomponentsignature
-type DirectiveSecComponentSignature struct {
+//DirectiveSecAuditLogRelevantStatus Configures which response status code is to be considered relevant for the purpose of audit logging.
+// The value is a regular expression which is matched against the response status code, it is only used when SecAuditEngine is set to RelevantOnly.
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecAuditLogRelevantStatus
+type DirectiveSecAuditLogRelevantStatus struct {
 	AbstractNode
-	Signature string
+
+	//A regular expression (PCRE) which matches the relevant status codes
+	Value string
 }
 
-func (dir *DirectiveSecComponentSignature) Name() string {
-	return "SecComponentSignature"
+func (dir *DirectiveSecAuditLogRelevantStatus) Name() string {
+	return "SecAuditLogRelevantStatus"
 }
 
 //Directive is a marker to associate the struct with the Directive interface
-func (dir *DirectiveSecComponentSignature) Directive() {}
+func (dir *DirectiveSecAuditLogRelevantStatus) Directive() {}
 
 //Children returns all child nodes, this satisfies the Node interface
-func (dir *DirectiveSecComponentSignature) Children() []Node {
+func (dir *DirectiveSecAuditLogRelevantStatus) Children() []Node {
 	return []Node{}
 }
 
-//DirectiveSecMarker Adds a fixed rule marker that can be used as a target in a skipAfter action. A SecMarker directive essentially creates a rule that does nothing and whose only purpose is to carry the given ID.
-// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#secmarker
-type DirectiveSecMarker struct {
+//DirectiveSecAuditLogStorageDir Configures the directory where concurrent audit log entries are to be stored.
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecAuditLogStorageDir
+type DirectiveSecAuditLogStorageDir struct {
 	AbstractNode
 	Value string
 }
 
-func (dir *DirectiveSecMarker) Name() string {
-	return "SecMarker"
+func (dir *DirectiveSecAuditLogStorageDir) Name() string {
+	return "SecAuditLogStorageDir"
 }
 
 //Directive is a marker to associate the struct with the Directive interface
-func (dir *DirectiveSecMarker) Directive() {}
+func (dir *DirectiveSecAuditLogStorageDir) Directive() {}
 
 //Children returns all child nodes, this satisfies the Node interface
-func (dir *DirectiveSecMarker) Children() []Node {
+func (dir *DirectiveSecAuditLogStorageDir) Children() []Node {
 	return []Node{}
 }
 
-//SecRequestBodyAccessValue is the value of the SecRequestBodyAccess directive
-// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecRequestBodyAccess
-type SecRequestBodyAccessValue string
+//SecAuditLogTypeValue is the value of the DirectiveSecAuditLogType directive
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecAuditLogType
+type SecAuditLogTypeValue string
 
 const (
-	SecRequestBodyAccessOn  SecRequestBodyAccessValue = "On"
-	SecRequestBodyAccessOff SecRequestBodyAccessValue = "Off"
+	//SecAuditLogTypeSerial Audit log entries will be stored in a single file, specified by SecAuditLog.
+	SecAuditLogTypeSerial SecAuditLogTypeValue = "Serial"
+
+	//SecAuditLogTypeConcurrent One file per transaction is used for audit logging. This approach is more scalable when heavy logging is required.
+	SecAuditLogTypeConcurrent SecAuditLogTypeValue = "Concurrent"
+
+	//SecAuditLogTypeHTTPS Audit log entries are send to a HTTP(S) server, specified by SecAuditLog. (only libmodsecurity / v3)
+	SecAuditLogTypeHTTPS SecAuditLogTypeValue = "HTTPS"
 )
 
-func (sev SecRequestBodyAccessValue) Valid() bool {
-	return sev == SecRequestBodyAccessOn ||
-		sev == SecRequestBodyAccessOff
+func (alt SecAuditLogTypeValue) Valid() bool {
+	return alt == SecAuditLogTypeSerial ||
+		alt == SecAuditLogTypeConcurrent ||
+		alt == SecAuditLogTypeHTTPS
 }
 
-//DirectiveSecRequestBodyAccessConfigures whether request bodies will be buffered and processed by ModSecurity.
-// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecRequestBodyAccess
-type DirectiveSecRequestBodyAccess struct {
+//DirectiveSecAuditLogType Configures the type of audit logging mechanism to be used.
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecAuditLogType
+type DirectiveSecAuditLogType struct {
 	AbstractNode
-	Value SecRequestBodyAccessValue
+	Value SecAuditLogTypeValue
 }
 
-func (dir *DirectiveSecRequestBodyAccess) Name() string {
-	return "SecRequestBodyAccess"
+func (dir *DirectiveSecAuditLogType) Name() string {
+	return "SecAuditLogType"
 }
 
 //Directive is a marker to associate the struct with the Directive interface
-func (dir *DirectiveSecRequestBodyAccess) Directive() {}
+func (dir *DirectiveSecAuditLogType) Directive() {}
 
 //Children returns all child nodes, this satisfies the Node interface
-func (dir *DirectiveSecRequestBodyAccess) Children() []Node {
+func (dir *DirectiveSecAuditLogType) Children() []Node {
 	return []Node{}
 }
 
-//DirectiveSecRule Creates a rule that will analyze the selected variables using the selected operator.
-//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#secrule
-type DirectiveSecRule struct {
+//DirectiveSecChrootDir Configures the directory path that will be used to jail the web server process.
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecChrootDir
+type DirectiveSecChrootDir struct {
 	AbstractNode
-	Variable    *VariableList
-	Operator    Operator
-	ActionNodes []Action
+	Value string
 }
 
-func (dir *DirectiveSecRule) Name() string {
-	return "SecRule"
+func (dir *DirectiveSecChrootDir) Name() string {
+	return "SecChrootDir"
 }
 
 //Directive is a marker to associate the struct with the Directive interface
-func (dir *DirectiveSecRule) Directive() {}
+func (dir *DirectiveSecChrootDir) Directive() {}
 
 //Children returns all child nodes, this satisfies the Node interface
-func (dir *DirectiveSecRule) Children() []Node {
-	nodes := make([]Node, len(dir.ActionNodes))
-	for i, action := range dir.ActionNodes {
-		nodes[i] = Node(action)
-	}
-	return nodes
+func (dir *DirectiveSecChrootDir) Children() []Node {
+	return []Node{}
 }
 
-//Actions returns all actions of the directive
-func (dir *DirectiveSecRule) Actions() []Action {
-	return dir.ActionNodes
+//DirectiveSecCollectionTimeout Specifies the collections timeout in seconds. Default is 3600 seconds.
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecCollectionTimeout
+type DirectiveSecCollectionTimeout struct {
+	AbstractNode
+
+	//The timeout in seconds
+	Value int
 }
 
-func (dir *DirectiveSecRule) AddAction(action Action) {
-	action.SetParent(dir)
-	dir.ActionNodes = append(dir.ActionNodes, action)
+func (dir *DirectiveSecCollectionTimeout) Name() string {
+	return "SecCollectionTimeout"
 }
 
-//SecRuleEngineValue is the value of the SecRuleEngine directive
-// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#secruleengine
-type SecRuleEngineValue string
+//Directive is a marker to associate the struct with the Directive interface
+func (dir *DirectiveSecCollectionTimeout) Directive() {}
 
-func (sev SecRuleEngineValue) Valid() bool {
+//Children returns all child nodes, this satisfies the Node interface
+func (dir *DirectiveSecCollectionTimeout) Children() []Node {
+	return []Node{}
+}
+
+//DirectiveSecComponentSignature Appends component signature to the ModSecurity signature.
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#seccomponentsignature
+type DirectiveSecComponentSignature struct {
+	AbstractNode
+	Signature string
+}
+
+func (dir *DirectiveSecComponentSignature) Name() string {
+	return "SecComponentSignature"
+}
+
+//Directive is a marker to associate the struct with the Directive interface
+func (dir *DirectiveSecComponentSignature) Directive() {}
+
+//Children returns all child nodes, this satisfies the Node interface
+func (dir *DirectiveSecComponentSignature) Children() []Node {
+	return []Node{}
+}
+
+//SecConnEngineValue is the value of the SecConnEngine directive
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecConnEngine
+type SecConnEngineValue string
+
+func (sev SecConnEngineValue) Valid() bool {
 	return sev == ModsecOn ||
 		sev == ModsecOff ||
 		sev == ModsecDetectionOnly
 }
 
-//DirectiveSecRuleEngine Configures the rules engine.
-// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#secruleengine
-type DirectiveSecRuleEngine struct {
+//DirectiveSecConnEngine Configures the connection engine, which processes the connection level rules (SecConnReadStateLimit and SecConnWriteStateLimit).
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecConnEngine
+type DirectiveSecConnEngine struct {
 	AbstractNode
-	Value SecRuleEngineValue
+	Value SecConnEngineValue
 }
 
-func (dir *DirectiveSecRuleEngine) Name() string {
-	return "SecRuleEngine"
+func (dir *DirectiveSecConnEngine) Name() string {
+	return "SecConnEngine"
 }
 
 //Directive is a marker to associate the struct with the Directive interface
-func (dir *DirectiveSecRuleEngine) Directive() {}
+func (dir *DirectiveSecConnEngine) Directive() {}
 
 //Children returns all child nodes, this satisfies the Node interface
-func (dir *DirectiveSecRuleEngine) Children() []Node {
+func (dir *DirectiveSecConnEngine) Children() []Node {
+	return []Node{}
+}
+
+//DirectiveSecConnReadStateLimit Establishes a per-IP address limit of how many connections are allowed to be in SERVER_BUSY_READ state.
+// This measure is effective against Slowloris-style attacks from a single IP address, but it may not be as good against modified attacks that work by slowly sending request headers.
+// The deprecated SecReadStateLimit directive is parsed as this directive.
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecConnReadStateLimit
+type DirectiveSecConnReadStateLimit struct {
+	AbstractNode
+
+	//The maximum amount of connections per IP address
+	Limit int
+
+	//Optional @ipMatch operator which limits the IP addresses to which the limit applies
+	Operator Operator
+}
+
+func (dir *DirectiveSecConnReadStateLimit) Name() string {
+	return "SecConnReadStateLimit"
+}
+
+//Directive is a marker to associate the struct with the Directive interface
+func (dir *DirectiveSecConnReadStateLimit) Directive() {}
+
+//Children returns all child nodes, this satisfies the Node interface
+func (dir *DirectiveSecConnReadStateLimit) Children() []Node {
+	if dir.Operator == nil {
+		return []Node{}
+	}
+
+	return []Node{dir.Operator}
+}
+
+//DirectiveSecConnWriteStateLimit Establishes a per-IP address limit of how many connections are allowed to be in SERVER_BUSY_WRITE state.
+// This measure is effective against Slow DoS request body attacks.
+// The deprecated SecWriteStateLimit directive is parsed as this directive.
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecConnWriteStateLimit
+type DirectiveSecConnWriteStateLimit struct {
+	AbstractNode
+
+	//The maximum amount of connections per IP address
+	Limit int
+
+	//Optional @ipMatch operator which limits the IP addresses to which the limit applies
+	Operator Operator
+}
+
+func (dir *DirectiveSecConnWriteStateLimit) Name() string {
+	return "SecConnWriteStateLimit"
+}
+
+//Directive is a marker to associate the struct with the Directive interface
+func (dir *DirectiveSecConnWriteStateLimit) Directive() {}
+
+//Children returns all child nodes, this satisfies the Node interface
+func (dir *DirectiveSecConnWriteStateLimit) Children() []Node {
+	if dir.Operator == nil {
+		return []Node{}
+	}
+
+	return []Node{dir.Operator}
+}
+
+//SecContentInjectionValue is the value of the SecContentInjection directive
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecContentInjection
+type SecContentInjectionValue string
+
+func (sev SecContentInjectionValue) Valid() bool {
+	return sev == ModsecOn ||
+		sev == ModsecOff
+}
+
+//DirectiveSecContentInjection Enables content injection using actions append and prepend.
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecContentInjection
+type DirectiveSecContentInjection struct {
+	AbstractNode
+	Value SecContentInjectionValue
+}
+
+func (dir *DirectiveSecContentInjection) Name() string {
+	return "SecContentInjection"
+}
+
+//Directive is a marker to associate the struct with the Directive interface
+func (dir *DirectiveSecContentInjection) Directive() {}
+
+//Children returns all child nodes, this satisfies the Node interface
+func (dir *DirectiveSecContentInjection) Children() []Node {
+	return []Node{}
+}
+
+//SecCookieFormatValue is the value of the SecCookieFormat directive
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecCookieFormat
+type SecCookieFormatValue int
+
+const (
+	//SecCookieFormatVersion0 Use version 0 (Netscape) cookies. This is what most applications use. It is the default value.
+	SecCookieFormatVersion0 SecCookieFormatValue = 0
+
+	//SecCookieFormatVersion1 Use version 1 cookies.
+	SecCookieFormatVersion1 SecCookieFormatValue = 1
+)
+
+func (cf SecCookieFormatValue) Valid() bool {
+	return cf == SecCookieFormatVersion0 ||
+		cf == SecCookieFormatVersion1
+}
+
+//DirectiveSecCookieFormat Selects the cookie format that will be used in the current configuration context.
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecCookieFormat
+type DirectiveSecCookieFormat struct {
+	AbstractNode
+	Value SecCookieFormatValue
+}
+
+func (dir *DirectiveSecCookieFormat) Name() string {
+	return "SecCookieFormat"
+}
+
+//Directive is a marker to associate the struct with the Directive interface
+func (dir *DirectiveSecCookieFormat) Directive() {}
+
+//Children returns all child nodes, this satisfies the Node interface
+func (dir *DirectiveSecCookieFormat) Children() []Node {
+	return []Node{}
+}
+
+//DirectiveSecCookieV0Separator Specifies which character to use as the separator for cookie v0 content.
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecCookieV0Separator
+type DirectiveSecCookieV0Separator struct {
+	AbstractNode
+
+	//The separator, this is always a single character
+	Value string
+}
+
+func (dir *DirectiveSecCookieV0Separator) Name() string {
+	return "SecCookieV0Separator"
+}
+
+//Directive is a marker to associate the struct with the Directive interface
+func (dir *DirectiveSecCookieV0Separator) Directive() {}
+
+//Children returns all child nodes, this satisfies the Node interface
+func (dir *DirectiveSecCookieV0Separator) Children() []Node {
+	return []Node{}
+}
+
+//DirectiveSecDataDir Path where persistent data (e.g., IP address data, session data, and so on) is to be stored.
+// This directive must be provided before initcol, setsid, and setuid can be used.
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecDataDir
+type DirectiveSecDataDir struct {
+	AbstractNode
+	Value string
+}
+
+func (dir *DirectiveSecDataDir) Name() string {
+	return "SecDataDir"
+}
+
+//Directive is a marker to associate the struct with the Directive interface
+func (dir *DirectiveSecDataDir) Directive() {}
+
+//Children returns all child nodes, this satisfies the Node interface
+func (dir *DirectiveSecDataDir) Children() []Node {
+	return []Node{}
+}
+
+//DirectiveSecDefaultAction Defines the default list of actions, which will be inherited by the rules in the same configuration context.
+// Every SecDefaultAction must specify a disruptive action and a processing phase, it applies to the rules of that phase which follow it.
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#SecDefaultAction
+type DirectiveSecDefaultAction struct {
+	AbstractNode
+	ActionNodes []Action
+}
+
+func (dir *DirectiveSecDefaultAction) Name() string {
+	return "SecDefaultAction"
+}
+
+//Directive is a marker to associate the struct with the Directive interface
+func (dir *DirectiveSecDefaultAction) Directive() {}
+
+//Children returns all child nodes, this satisfies the Node interface
+func (dir *DirectiveSecDefaultAction) Children() []Node {
+	nodes := make([]Node, len(dir.ActionNodes))
+	for i, action := range dir.ActionNodes {
+		nodes[i] = Node(action)
+	}
+	return nodes
+}
+
+//Actions returns all actions of the directive
+func (dir *DirectiveSecDefaultAction) Actions() []Action {
+	return dir.ActionNodes
+}
+
+func (dir *DirectiveSecDefaultAction) AddAction(action Action) {
+	dir.ActionNodes = append(dir.ActionNodes, action)
+}
+
+//DirectiveSecDebugLog Path to the ModSecurity debug log file.
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecDebugLog
+type DirectiveSecDebugLog struct {
+	AbstractNode
+	Value string
+}
+
+func (dir *DirectiveSecDebugLog) Name() string {
+	return "SecDebugLog"
+}
+
+//Directive is a marker to associate the struct with the Directive interface
+func (dir *DirectiveSecDebugLog) Directive() {}
+
+//Children returns all child nodes, this satisfies the Node interface
+func (dir *DirectiveSecDebugLog) Children() []Node {
+	return []Node{}
+}
+
+//DirectiveSecDebugLogLevel Configures the verboseness of the debug log data. Levels 1 - 3 are always sent to the Apache error log. Level 0 disables logging, level 9 is the most verbose
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecDebugLogLevel
+type DirectiveSecDebugLogLevel struct {
+	AbstractNode
+	Value int
+}
+
+func (dir *DirectiveSecDebugLogLevel) Name() string {
+	return "SecDebugLogLevel"
+}
+
+//Directive is a marker to associate the struct with the Directive interface
+func (dir *DirectiveSecDebugLogLevel) Directive() {}
+
+//Children returns all child nodes, this satisfies the Node interface
+func (dir *DirectiveSecDebugLogLevel) Children() []Node {
+	return []Node{}
+}
+
+//SecDisableBackendCompressionValue is the value of the SecDisableBackendCompression directive
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecDisableBackendCompression
+type SecDisableBackendCompressionValue string
+
+func (sev SecDisableBackendCompressionValue) Valid() bool {
+	return sev == ModsecOn ||
+		sev == ModsecOff
+}
+
+//DirectiveSecDisableBackendCompression Disables backend compression while leaving the frontend compression enabled.
+// This directive is necessary in reverse proxy mode when the backend servers support response compression, but you wish to inspect response bodies.
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecDisableBackendCompression
+type DirectiveSecDisableBackendCompression struct {
+	AbstractNode
+	Value SecDisableBackendCompressionValue
+}
+
+func (dir *DirectiveSecDisableBackendCompression) Name() string {
+	return "SecDisableBackendCompression"
+}
+
+//Directive is a marker to associate the struct with the Directive interface
+func (dir *DirectiveSecDisableBackendCompression) Directive() {}
+
+//Children returns all child nodes, this satisfies the Node interface
+func (dir *DirectiveSecDisableBackendCompression) Children() []Node {
+	return []Node{}
+}
+
+//DirectiveSecGeoLookupDb Defines the path to the database that will be used for geolocation lookups.
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecGeoLookupDb
+type DirectiveSecGeoLookupDb struct {
+	AbstractNode
+
+	//The path to the database file
+	Value string
+}
+
+func (dir *DirectiveSecGeoLookupDb) Name() string {
+	return "SecGeoLookupDb"
+}
+
+//Directive is a marker to associate the struct with the Directive interface
+func (dir *DirectiveSecGeoLookupDb) Directive() {}
+
+//Children returns all child nodes, this satisfies the Node interface
+func (dir *DirectiveSecGeoLookupDb) Children() []Node {
+	return []Node{}
+}
+
+//DirectiveSecGsbLookupDb Defines the path to the database that will be used for Google Safe Browsing (GSB) lookups.
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecGsbLookupDb
+type DirectiveSecGsbLookupDb struct {
+	AbstractNode
+
+	//The path to the database file
+	Value string
+}
+
+func (dir *DirectiveSecGsbLookupDb) Name() string {
+	return "SecGsbLookupDb"
+}
+
+//Directive is a marker to associate the struct with the Directive interface
+func (dir *DirectiveSecGsbLookupDb) Directive() {}
+
+//Children returns all child nodes, this satisfies the Node interface
+func (dir *DirectiveSecGsbLookupDb) Children() []Node {
+	return []Node{}
+}
+
+//DirectiveSecGuardianLog Configures an external program that will receive the information about every request via piped logging.
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecGuardianLog
+type DirectiveSecGuardianLog struct {
+	AbstractNode
+
+	//The path to the log file or, if it starts with a pipe (|), the command to which the log is piped
+	Value string
+}
+
+func (dir *DirectiveSecGuardianLog) Name() string {
+	return "SecGuardianLog"
+}
+
+//Directive is a marker to associate the struct with the Directive interface
+func (dir *DirectiveSecGuardianLog) Directive() {}
+
+//Children returns all child nodes, this satisfies the Node interface
+func (dir *DirectiveSecGuardianLog) Children() []Node {
+	return []Node{}
+}
+
+//SecHashEngineValue is the value of the SecHashEngine directive
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecHashEngine
+type SecHashEngineValue string
+
+func (sev SecHashEngineValue) Valid() bool {
+	return sev == ModsecOn ||
+		sev == ModsecOff
+}
+
+//DirectiveSecHashEngine Configures the hash engine.
+// The hash engine will sign (HMAC) elements in the response body (like links and form actions) and verify the signatures of incoming requests.
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecHashEngine
+type DirectiveSecHashEngine struct {
+	AbstractNode
+	Value SecHashEngineValue
+}
+
+func (dir *DirectiveSecHashEngine) Name() string {
+	return "SecHashEngine"
+}
+
+//Directive is a marker to associate the struct with the Directive interface
+func (dir *DirectiveSecHashEngine) Directive() {}
+
+//Children returns all child nodes, this satisfies the Node interface
+func (dir *DirectiveSecHashEngine) Children() []Node {
+	return []Node{}
+}
+
+//SecHashKeyRand is the special key value which tells ModSecurity to generate a random key at startup
+const SecHashKeyRand = "Rand"
+
+//SecHashKeyMask specifies which information is used in addition to the key to create the hash
+type SecHashKeyMask string
+
+const (
+	//SecHashKeyMaskKeyOnly only uses the key
+	SecHashKeyMaskKeyOnly SecHashKeyMask = "KeyOnly"
+
+	//SecHashKeyMaskSessionID uses the key and the session id of the client
+	SecHashKeyMaskSessionID SecHashKeyMask = "SessionID"
+
+	//SecHashKeyMaskRemoteIP uses the key and the IP address of the client
+	SecHashKeyMaskRemoteIP SecHashKeyMask = "RemoteIP"
+)
+
+func (mask SecHashKeyMask) Valid() bool {
+	return mask == SecHashKeyMaskKeyOnly ||
+		mask == SecHashKeyMaskSessionID ||
+		mask == SecHashKeyMaskRemoteIP
+}
+
+//DirectiveSecHashKey Defines the key that will be used by HMAC.
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecHashKey
+type DirectiveSecHashKey struct {
+	AbstractNode
+
+	//The key used for the HMAC or SecHashKeyRand if a random key is generated
+	Key string
+
+	Mask SecHashKeyMask
+}
+
+func (dir *DirectiveSecHashKey) Name() string {
+	return "SecHashKey"
+}
+
+//Directive is a marker to associate the struct with the Directive interface
+func (dir *DirectiveSecHashKey) Directive() {}
+
+//Children returns all child nodes, this satisfies the Node interface
+func (dir *DirectiveSecHashKey) Children() []Node {
+	return []Node{}
+}
+
+//Random returns true if the key is randomly generated instead of hardcoded in the config
+func (dir *DirectiveSecHashKey) Random() bool {
+	return strings.EqualFold(dir.Key, SecHashKeyRand)
+}
+
+//HashMethod specifies which part of the response body is signed by the hash engine
+type HashMethod string
+
+const (
+	HashMethodHref       HashMethod = "HashHref"
+	HashMethodFormAction HashMethod = "HashFormAction"
+	HashMethodIframeSrc  HashMethod = "HashIframeSrc"
+	HashMethodFrameSrc   HashMethod = "HashFrameSrc"
+	HashMethodLocation   HashMethod = "HashLocation"
+)
+
+//hashMethodTargets maps the hash methods to the HTML element and attribute they sign
+var hashMethodTargets = map[HashMethod][2]string{
+	HashMethodHref:       {"a", "href"},
+	HashMethodFormAction: {"form", "action"},
+	HashMethodIframeSrc:  {"iframe", "src"},
+	HashMethodFrameSrc:   {"frame", "src"},
+}
+
+func (hm HashMethod) Valid() bool {
+	if hm == HashMethodLocation {
+		return true
+	}
+
+	_, found := hashMethodTargets[hm]
+	return found
+}
+
+//Element returns the name of the HTML element of which an attribute is signed.
+// An empty string is returned for HashLocation since it signs the Location response header instead of a HTML element
+func (hm HashMethod) Element() string {
+	return hashMethodTargets[hm][0]
+}
+
+//Attribute returns the name of the HTML attribute which is signed.
+// An empty string is returned for HashLocation since it signs the Location response header instead of a HTML attribute
+func (hm HashMethod) Attribute() string {
+	return hashMethodTargets[hm][1]
+}
+
+//DirectiveSecHashMethodPm Configures what type of HTML data the hash engine should sign based on a list of phrases.
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecHashMethodPm
+type DirectiveSecHashMethodPm struct {
+	AbstractNode
+
+	Method HashMethod
+
+	//The phrases of which at least one has to be present in the value for it to be signed
+	Phrases []string
+}
+
+func (dir *DirectiveSecHashMethodPm) Name() string {
+	return "SecHashMethodPm"
+}
+
+//Directive is a marker to associate the struct with the Directive interface
+func (dir *DirectiveSecHashMethodPm) Directive() {}
+
+//Children returns all child nodes, this satisfies the Node interface
+func (dir *DirectiveSecHashMethodPm) Children() []Node {
+	return []Node{}
+}
+
+//DirectiveSecHashMethodRx Configures what type of HTML data the hash engine should sign based on a regular expression.
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecHashMethodRx
+type DirectiveSecHashMethodRx struct {
+	AbstractNode
+
+	Method HashMethod
+
+	//The regular expression which has to match the value for it to be signed
+	Regex string
+}
+
+func (dir *DirectiveSecHashMethodRx) Name() string {
+	return "SecHashMethodRx"
+}
+
+//Directive is a marker to associate the struct with the Directive interface
+func (dir *DirectiveSecHashMethodRx) Directive() {}
+
+//Children returns all child nodes, this satisfies the Node interface
+func (dir *DirectiveSecHashMethodRx) Children() []Node {
+	return []Node{}
+}
+
+//DirectiveSecHashParam Defines the parameter name that will be used to append the hash into the signed elements.
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecHashParam
+type DirectiveSecHashParam struct {
+	AbstractNode
+	Value string
+}
+
+func (dir *DirectiveSecHashParam) Name() string {
+	return "SecHashParam"
+}
+
+//Directive is a marker to associate the struct with the Directive interface
+func (dir *DirectiveSecHashParam) Directive() {}
+
+//Children returns all child nodes, this satisfies the Node interface
+func (dir *DirectiveSecHashParam) Children() []Node {
+	return []Node{}
+}
+
+//SecInterceptOnErrorValue is the value of the SecInterceptOnError directive
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecInterceptOnError
+type SecInterceptOnErrorValue string
+
+func (sev SecInterceptOnErrorValue) Valid() bool {
+	return sev == ModsecOn ||
+		sev == ModsecOff
+}
+
+//DirectiveSecInterceptOnError Configures how to respond when rule processing fails.
+// When an operator execution fails (returns a value < 0) the rule will be flagged as failed and by default the rule processing continues.
+// When set to On, the rule will be intercepted and the transaction will be disrupted.
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecInterceptOnError
+type DirectiveSecInterceptOnError struct {
+	AbstractNode
+	Value SecInterceptOnErrorValue
+}
+
+func (dir *DirectiveSecInterceptOnError) Name() string {
+	return "SecInterceptOnError"
+}
+
+//Directive is a marker to associate the struct with the Directive interface
+func (dir *DirectiveSecInterceptOnError) Directive() {}
+
+//Children returns all child nodes, this satisfies the Node interface
+func (dir *DirectiveSecInterceptOnError) Children() []Node {
+	return []Node{}
+}
+
+//DirectiveSecMarker Adds a fixed rule marker that can be used as a target in a skipAfter action. A SecMarker directive essentially creates a rule that does nothing and whose only purpose is to carry the given ID.
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#secmarker
+type DirectiveSecMarker struct {
+	AbstractNode
+	Value string
+}
+
+func (dir *DirectiveSecMarker) Name() string {
+	return "SecMarker"
+}
+
+//Directive is a marker to associate the struct with the Directive interface
+func (dir *DirectiveSecMarker) Directive() {}
+
+//Children returns all child nodes, this satisfies the Node interface
+func (dir *DirectiveSecMarker) Children() []Node {
+	return []Node{}
+}
+
+//DirectiveSecPcreMatchLimit Sets the match limit in the PCRE library.
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecPcreMatchLimit
+type DirectiveSecPcreMatchLimit struct {
+	AbstractNode
+	Value int
+}
+
+func (dir *DirectiveSecPcreMatchLimit) Name() string {
+	return "SecPcreMatchLimit"
+}
+
+//Directive is a marker to associate the struct with the Directive interface
+func (dir *DirectiveSecPcreMatchLimit) Directive() {}
+
+//Children returns all child nodes, this satisfies the Node interface
+func (dir *DirectiveSecPcreMatchLimit) Children() []Node {
+	return []Node{}
+}
+
+//DirectiveSecPcreMatchLimitRecursion Sets the match limit recursion in the PCRE library.
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecPcreMatchLimitRecursion
+type DirectiveSecPcreMatchLimitRecursion struct {
+	AbstractNode
+	Value int
+}
+
+func (dir *DirectiveSecPcreMatchLimitRecursion) Name() string {
+	return "SecPcreMatchLimitRecursion"
+}
+
+//Directive is a marker to associate the struct with the Directive interface
+func (dir *DirectiveSecPcreMatchLimitRecursion) Directive() {}
+
+//Children returns all child nodes, this satisfies the Node interface
+func (dir *DirectiveSecPcreMatchLimitRecursion) Children() []Node {
+	return []Node{}
+}
+
+//DirectiveSecRemoteRules Load rules from a given URL.
+// The key is sent to the server in the ModSec-key header, which allows the server to provide different rules to different clients.
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecRemoteRules
+type DirectiveSecRemoteRules struct {
+	AbstractNode
+
+	Key string
+	URL string
+}
+
+func (dir *DirectiveSecRemoteRules) Name() string {
+	return "SecRemoteRules"
+}
+
+//Directive is a marker to associate the struct with the Directive interface
+func (dir *DirectiveSecRemoteRules) Directive() {}
+
+//Children returns all child nodes, this satisfies the Node interface
+func (dir *DirectiveSecRemoteRules) Children() []Node {
+	return []Node{}
+}
+
+//SecRemoteRulesFailActionValue is the value of the SecRemoteRulesFailAction directive
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecRemoteRulesFailAction
+type SecRemoteRulesFailActionValue string
+
+const (
+	//SecRemoteRulesFailActionAbort aborts loading the config if the remote rules can't be downloaded, this is the default
+	SecRemoteRulesFailActionAbort SecRemoteRulesFailActionValue = "Abort"
+
+	//SecRemoteRulesFailActionWarn only warns if the remote rules can't be downloaded and continues without them
+	SecRemoteRulesFailActionWarn SecRemoteRulesFailActionValue = "Warn"
+)
+
+func (sev SecRemoteRulesFailActionValue) Valid() bool {
+	return sev == SecRemoteRulesFailActionAbort ||
+		sev == SecRemoteRulesFailActionWarn
+}
+
+//DirectiveSecRemoteRulesFailAction Defines what action to take when the rules specified by SecRemoteRules can't be downloaded.
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecRemoteRulesFailAction
+type DirectiveSecRemoteRulesFailAction struct {
+	AbstractNode
+	Value SecRemoteRulesFailActionValue
+}
+
+func (dir *DirectiveSecRemoteRulesFailAction) Name() string {
+	return "SecRemoteRulesFailAction"
+}
+
+//Directive is a marker to associate the struct with the Directive interface
+func (dir *DirectiveSecRemoteRulesFailAction) Directive() {}
+
+//Children returns all child nodes, this satisfies the Node interface
+func (dir *DirectiveSecRemoteRulesFailAction) Children() []Node {
+	return []Node{}
+}
+
+//SecRequestBodyAccessValue is the value of the SecRequestBodyAccess directive
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecRequestBodyAccess
+type SecRequestBodyAccessValue string
+
+const (
+	SecRequestBodyAccessOn  SecRequestBodyAccessValue = "On"
+	SecRequestBodyAccessOff SecRequestBodyAccessValue = "Off"
+)
+
+func (sev SecRequestBodyAccessValue) Valid() bool {
+	return sev == SecRequestBodyAccessOn ||
+		sev == SecRequestBodyAccessOff
+}
+
+//DirectiveSecRequestBodyAccessConfigures whether request bodies will be buffered and processed by ModSecurity.
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecRequestBodyAccess
+type DirectiveSecRequestBodyAccess struct {
+	AbstractNode
+	Value SecRequestBodyAccessValue
+}
+
+func (dir *DirectiveSecRequestBodyAccess) Name() string {
+	return "SecRequestBodyAccess"
+}
+
+//Directive is a marker to associate the struct with the Directive interface
+func (dir *DirectiveSecRequestBodyAccess) Directive() {}
+
+//Children returns all child nodes, this satisfies the Node interface
+func (dir *DirectiveSecRequestBodyAccess) Children() []Node {
+	return []Node{}
+}
+
+//BodyLimit is the value of the directives which limit the size of request and response bodies, in bytes.
+type BodyLimit int64
+
+//BodyLimitMax is the hard limit of body limit directives, ModSecurity will not accept a limit larger than 1GB
+const BodyLimitMax BodyLimit = 1073741824
+
+func (bl BodyLimit) Valid() bool {
+	return bl > 0 && bl <= BodyLimitMax
+}
+
+//BodyLimitAction is the value of the SecRequestBodyLimitAction and SecResponseBodyLimitAction directives.
+// It controls what happens once a body limit is reached.
+type BodyLimitAction string
+
+const (
+	//BodyLimitActionReject rejects the transaction once the body limit has been reached
+	BodyLimitActionReject BodyLimitAction = "Reject"
+
+	//BodyLimitActionProcessPartial only processes the part of the body which fits within the limit and passes the rest through
+	BodyLimitActionProcessPartial BodyLimitAction = "ProcessPartial"
+)
+
+func (bla BodyLimitAction) Valid() bool {
+	return bla == BodyLimitActionReject ||
+		bla == BodyLimitActionProcessPartial
+}
+
+//DirectiveSecRequestBodyInMemoryLimit Configures the maximum request body size that ModSecurity will store in memory.
+// When a multipart/form-data request is being processed, once the in-memory limit is reached, the request body will start to be streamed into a temporary file on disk.
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecRequestBodyInMemoryLimit
+type DirectiveSecRequestBodyInMemoryLimit struct {
+	AbstractNode
+	Value BodyLimit
+}
+
+func (dir *DirectiveSecRequestBodyInMemoryLimit) Name() string {
+	return "SecRequestBodyInMemoryLimit"
+}
+
+//Directive is a marker to associate the struct with the Directive interface
+func (dir *DirectiveSecRequestBodyInMemoryLimit) Directive() {}
+
+//Children returns all child nodes, this satisfies the Node interface
+func (dir *DirectiveSecRequestBodyInMemoryLimit) Children() []Node {
+	return []Node{}
+}
+
+//DirectiveSecRequestBodyLimit Configures the maximum request body size ModSecurity will accept for buffering.
+// Anything over the limit will be rejected with status code 413 (Request Entity Too Large). There is a hard limit of 1 GB.
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecRequestBodyLimit
+type DirectiveSecRequestBodyLimit struct {
+	AbstractNode
+	Value BodyLimit
+}
+
+func (dir *DirectiveSecRequestBodyLimit) Name() string {
+	return "SecRequestBodyLimit"
+}
+
+//Directive is a marker to associate the struct with the Directive interface
+func (dir *DirectiveSecRequestBodyLimit) Directive() {}
+
+//Children returns all child nodes, this satisfies the Node interface
+func (dir *DirectiveSecRequestBodyLimit) Children() []Node {
+	return []Node{}
+}
+
+//DirectiveSecRequestBodyLimitAction Controls what happens once a request body limit, configured with SecRequestBodyLimit, is encountered
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecRequestBodyLimitAction
+type DirectiveSecRequestBodyLimitAction struct {
+	AbstractNode
+	Value BodyLimitAction
+}
+
+func (dir *DirectiveSecRequestBodyLimitAction) Name() string {
+	return "SecRequestBodyLimitAction"
+}
+
+//Directive is a marker to associate the struct with the Directive interface
+func (dir *DirectiveSecRequestBodyLimitAction) Directive() {}
+
+//Children returns all child nodes, this satisfies the Node interface
+func (dir *DirectiveSecRequestBodyLimitAction) Children() []Node {
+	return []Node{}
+}
+
+//DirectiveSecRequestBodyNoFilesLimit Configures the maximum request body size ModSecurity will accept for buffering, excluding the size of any files being transported in the request.
+// This directive is useful to reduce susceptibility to DoS attacks when someone is sending request bodies of very large sizes.
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecRequestBodyNoFilesLimit
+type DirectiveSecRequestBodyNoFilesLimit struct {
+	AbstractNode
+	Value BodyLimit
+}
+
+func (dir *DirectiveSecRequestBodyNoFilesLimit) Name() string {
+	return "SecRequestBodyNoFilesLimit"
+}
+
+//Directive is a marker to associate the struct with the Directive interface
+func (dir *DirectiveSecRequestBodyNoFilesLimit) Directive() {}
+
+//Children returns all child nodes, this satisfies the Node interface
+func (dir *DirectiveSecRequestBodyNoFilesLimit) Children() []Node {
+	return []Node{}
+}
+
+//SecResponseBodyAccessValue is the value of the SecResponseBodyAccess directive
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecResponseBodyAccess
+type SecResponseBodyAccessValue string
+
+const (
+	SecResponseBodyAccessOn  SecResponseBodyAccessValue = "On"
+	SecResponseBodyAccessOff SecResponseBodyAccessValue = "Off"
+)
+
+func (sev SecResponseBodyAccessValue) Valid() bool {
+	return sev == SecResponseBodyAccessOn ||
+		sev == SecResponseBodyAccessOff
+}
+
+//DirectiveSecResponseBodyAccess Configures whether response bodies are to be buffered.
+// This directive is required if you plan to inspect HTML responses and implement response blocking.
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecResponseBodyAccess
+type DirectiveSecResponseBodyAccess struct {
+	AbstractNode
+	Value SecResponseBodyAccessValue
+}
+
+func (dir *DirectiveSecResponseBodyAccess) Name() string {
+	return "SecResponseBodyAccess"
+}
+
+//Directive is a marker to associate the struct with the Directive interface
+func (dir *DirectiveSecResponseBodyAccess) Directive() {}
+
+//Children returns all child nodes, this satisfies the Node interface
+func (dir *DirectiveSecResponseBodyAccess) Children() []Node {
+	return []Node{}
+}
+
+//DirectiveSecResponseBodyLimit Configures the maximum response body size that will be accepted for buffering.
+// Anything over this limit will be rejected with status code 500 (Internal Server Error). There is a hard limit of 1 GB.
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecResponseBodyLimit
+type DirectiveSecResponseBodyLimit struct {
+	AbstractNode
+	Value BodyLimit
+}
+
+func (dir *DirectiveSecResponseBodyLimit) Name() string {
+	return "SecResponseBodyLimit"
+}
+
+//Directive is a marker to associate the struct with the Directive interface
+func (dir *DirectiveSecResponseBodyLimit) Directive() {}
+
+//Children returns all child nodes, this satisfies the Node interface
+func (dir *DirectiveSecResponseBodyLimit) Children() []Node {
+	return []Node{}
+}
+
+//DirectiveSecResponseBodyLimitAction Controls what happens once a response body limit, configured with SecResponseBodyLimit, is encountered.
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecResponseBodyLimitAction
+type DirectiveSecResponseBodyLimitAction struct {
+	AbstractNode
+	Value BodyLimitAction
+}
+
+func (dir *DirectiveSecResponseBodyLimitAction) Name() string {
+	return "SecResponseBodyLimitAction"
+}
+
+//Directive is a marker to associate the struct with the Directive interface
+func (dir *DirectiveSecResponseBodyLimitAction) Directive() {}
+
+//Children returns all child nodes, this satisfies the Node interface
+func (dir *DirectiveSecResponseBodyLimitAction) Children() []Node {
+	return []Node{}
+}
+
+//DirectiveSecResponseBodyMimeType Configures which MIME types are to be considered for response body buffering.
+// Multiple SecResponseBodyMimeType directives can be used to add MIME types.
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecResponseBodyMimeType
+type DirectiveSecResponseBodyMimeType struct {
+	AbstractNode
+	Value []string
+}
+
+func (dir *DirectiveSecResponseBodyMimeType) Name() string {
+	return "SecResponseBodyMimeType"
+}
+
+//Directive is a marker to associate the struct with the Directive interface
+func (dir *DirectiveSecResponseBodyMimeType) Directive() {}
+
+//Children returns all child nodes, this satisfies the Node interface
+func (dir *DirectiveSecResponseBodyMimeType) Children() []Node {
+	return []Node{}
+}
+
+//DirectiveSecResponseBodyMimeTypesClear Clears the list of MIME types considered for response body buffering, allowing you to start populating the list from scratch.
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecResponseBodyMimeTypesClear
+type DirectiveSecResponseBodyMimeTypesClear struct {
+	AbstractNode
+}
+
+func (dir *DirectiveSecResponseBodyMimeTypesClear) Name() string {
+	return "SecResponseBodyMimeTypesClear"
+}
+
+//Directive is a marker to associate the struct with the Directive interface
+func (dir *DirectiveSecResponseBodyMimeTypesClear) Directive() {}
+
+//Children returns all child nodes, this satisfies the Node interface
+func (dir *DirectiveSecResponseBodyMimeTypesClear) Children() []Node {
+	return []Node{}
+}
+
+//DirectiveSecRule Creates a rule that will analyze the selected variables using the selected operator.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#secrule
+type DirectiveSecRule struct {
+	AbstractNode
+	Variable    *VariableList
+	Operator    Operator
+	ActionNodes []Action
+}
+
+func (dir *DirectiveSecRule) Name() string {
+	return "SecRule"
+}
+
+//Directive is a marker to associate the struct with the Directive interface
+func (dir *DirectiveSecRule) Directive() {}
+
+//Children returns all child nodes, this satisfies the Node interface
+func (dir *DirectiveSecRule) Children() []Node {
+	nodes := []Node{}
+	if dir.Variable != nil {
+		nodes = append(nodes, dir.Variable)
+	}
+	if dir.Operator != nil {
+		nodes = append(nodes, dir.Operator)
+	}
+	for _, action := range dir.ActionNodes {
+		nodes = append(nodes, Node(action))
+	}
+	return nodes
+}
+
+//Actions returns all actions of the directive
+func (dir *DirectiveSecRule) Actions() []Action {
+	return dir.ActionNodes
+}
+
+func (dir *DirectiveSecRule) AddAction(action Action) {
+	action.SetParent(dir)
+	dir.ActionNodes = append(dir.ActionNodes, action)
+}
+
+//SecRuleEngineValue is the value of the SecRuleEngine directive
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#secruleengine
+type SecRuleEngineValue string
+
+func (sev SecRuleEngineValue) Valid() bool {
+	return sev == ModsecOn ||
+		sev == ModsecOff ||
+		sev == ModsecDetectionOnly
+}
+
+//DirectiveSecRuleEngine Configures the rules engine.
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#secruleengine
+type DirectiveSecRuleEngine struct {
+	AbstractNode
+	Value SecRuleEngineValue
+}
+
+func (dir *DirectiveSecRuleEngine) Name() string {
+	return "SecRuleEngine"
+}
+
+//Directive is a marker to associate the struct with the Directive interface
+func (dir *DirectiveSecRuleEngine) Directive() {}
+
+//Children returns all child nodes, this satisfies the Node interface
+func (dir *DirectiveSecRuleEngine) Children() []Node {
+	return []Node{}
+}
+
+//DirectiveSecRulePerfTime Set a performance threshold for rules. Rules that spend at least the time defined will be logged into audit log Part H as Rules-Performance-Info in the format id=usec,
+// comma separated. The rule performance time is measured in microseconds.
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecRulePerfTime
+type DirectiveSecRulePerfTime struct {
+	AbstractNode
+
+	//The threshold in microseconds
+	Value int
+}
+
+func (dir *DirectiveSecRulePerfTime) Name() string {
+	return "SecRulePerfTime"
+}
+
+//Directive is a marker to associate the struct with the Directive interface
+func (dir *DirectiveSecRulePerfTime) Directive() {}
+
+//Children returns all child nodes, this satisfies the Node interface
+func (dir *DirectiveSecRulePerfTime) Children() []Node {
+	return []Node{}
+}
+
+//DirectiveSecRuleScript Creates a special rule that executes a Lua script to decide whether to match or not.
+// The main difference from SecRule is that there are no targets nor operators.
+// The script can fetch any variable from the ModSecurity context and use any (Lua) operator to test them.
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecRuleScript
+type DirectiveSecRuleScript struct {
+	AbstractNode
+
+	//The path to the Lua script
+	Path string
+
+	//The contents of the script, only set if the loader was asked to resolve scripts
+	Script []byte
+
+	ActionNodes []Action
+}
+
+func (dir *DirectiveSecRuleScript) Name() string {
+	return "SecRuleScript"
+}
+
+//Directive is a marker to associate the struct with the Directive interface
+func (dir *DirectiveSecRuleScript) Directive() {}
+
+//Children returns all child nodes, this satisfies the Node interface
+func (dir *DirectiveSecRuleScript) Children() []Node {
+	nodes := make([]Node, len(dir.ActionNodes))
+	for i, action := range dir.ActionNodes {
+		nodes[i] = Node(action)
+	}
+	return nodes
+}
+
+//Actions returns all actions of the directive
+func (dir *DirectiveSecRuleScript) Actions() []Action {
+	return dir.ActionNodes
+}
+
+func (dir *DirectiveSecRuleScript) AddAction(action Action) {
+	action.SetParent(dir)
+	dir.ActionNodes = append(dir.ActionNodes, action)
+}
+
+//DirectiveSecSensorId Define a sensor ID that will be present into log part H.
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecSensorId
+type DirectiveSecSensorId struct {
+	AbstractNode
+	Value string
+}
+
+func (dir *DirectiveSecSensorId) Name() string {
+	return "SecSensorId"
+}
+
+//Directive is a marker to associate the struct with the Directive interface
+func (dir *DirectiveSecSensorId) Directive() {}
+
+//Children returns all child nodes, this satisfies the Node interface
+func (dir *DirectiveSecSensorId) Children() []Node {
+	return []Node{}
+}
+
+//DirectiveSecServerSignature Instructs ModSecurity to change the data presented in the "Server:" response header token.
+// In order for this directive to work, you must set the Apache ServerTokens directive to Full.
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecServerSignature
+type DirectiveSecServerSignature struct {
+	AbstractNode
+	Value string
+}
+
+func (dir *DirectiveSecServerSignature) Name() string {
+	return "SecServerSignature"
+}
+
+//Directive is a marker to associate the struct with the Directive interface
+func (dir *DirectiveSecServerSignature) Directive() {}
+
+//Children returns all child nodes, this satisfies the Node interface
+func (dir *DirectiveSecServerSignature) Children() []Node {
+	return []Node{}
+}
+
+//SecStatusEngineValue is the value of the SecStatusEngine directive
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecStatusEngine
+type SecStatusEngineValue string
+
+func (sev SecStatusEngineValue) Valid() bool {
+	return sev == ModsecOn ||
+		sev == ModsecOff
+}
+
+//DirectiveSecStatusEngine Controls Status Reporting functionality. Uses DNS-based reporting to send software version information to the ModSecurity Project team.
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecStatusEngine
+type DirectiveSecStatusEngine struct {
+	AbstractNode
+	Value SecStatusEngineValue
+}
+
+func (dir *DirectiveSecStatusEngine) Name() string {
+	return "SecStatusEngine"
+}
+
+//Directive is a marker to associate the struct with the Directive interface
+func (dir *DirectiveSecStatusEngine) Directive() {}
+
+//Children returns all child nodes, this satisfies the Node interface
+func (dir *DirectiveSecStatusEngine) Children() []Node {
+	return []Node{}
+}
+
+//SecStreamInBodyInspectionValue is the value of the SecStreamInBodyInspection directive
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecStreamInBodyInspection
+type SecStreamInBodyInspectionValue string
+
+func (sev SecStreamInBodyInspectionValue) Valid() bool {
+	return sev == ModsecOn ||
+		sev == ModsecOff
+}
+
+//DirectiveSecStreamInBodyInspection Configures the ability to use stream inspection for incoming request data in a re-allocable buffer.
+// For security reasons we are still buffering the stream.
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecStreamInBodyInspection
+type DirectiveSecStreamInBodyInspection struct {
+	AbstractNode
+	Value SecStreamInBodyInspectionValue
+}
+
+func (dir *DirectiveSecStreamInBodyInspection) Name() string {
+	return "SecStreamInBodyInspection"
+}
+
+//Directive is a marker to associate the struct with the Directive interface
+func (dir *DirectiveSecStreamInBodyInspection) Directive() {}
+
+//Children returns all child nodes, this satisfies the Node interface
+func (dir *DirectiveSecStreamInBodyInspection) Children() []Node {
+	return []Node{}
+}
+
+//SecStreamOutBodyInspectionValue is the value of the SecStreamOutBodyInspection directive
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecStreamOutBodyInspection
+type SecStreamOutBodyInspectionValue string
+
+func (sev SecStreamOutBodyInspectionValue) Valid() bool {
+	return sev == ModsecOn ||
+		sev == ModsecOff
+}
+
+//DirectiveSecStreamOutBodyInspection Configures the ability to use stream inspection for outgoing request data in a re-allocable buffer.
+// For security reasons we are still buffering the stream.
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecStreamOutBodyInspection
+type DirectiveSecStreamOutBodyInspection struct {
+	AbstractNode
+	Value SecStreamOutBodyInspectionValue
+}
+
+func (dir *DirectiveSecStreamOutBodyInspection) Name() string {
+	return "SecStreamOutBodyInspection"
+}
+
+//Directive is a marker to associate the struct with the Directive interface
+func (dir *DirectiveSecStreamOutBodyInspection) Directive() {}
+
+//Children returns all child nodes, this satisfies the Node interface
+func (dir *DirectiveSecStreamOutBodyInspection) Children() []Node {
+	return []Node{}
+}
+
+//DirectiveSecTmpDir Configures the directory where temporary files will be created.
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecTmpDir
+type DirectiveSecTmpDir struct {
+	AbstractNode
+	Value string
+}
+
+func (dir *DirectiveSecTmpDir) Name() string {
+	return "SecTmpDir"
+}
+
+//Directive is a marker to associate the struct with the Directive interface
+func (dir *DirectiveSecTmpDir) Directive() {}
+
+//Children returns all child nodes, this satisfies the Node interface
+func (dir *DirectiveSecTmpDir) Children() []Node {
+	return []Node{}
+}
+
+//SecTmpSaveUploadedFilesValue is the value of the SecTmpSaveUploadedFiles directive
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v3.x%29#SecTmpSaveUploadedFiles
+type SecTmpSaveUploadedFilesValue string
+
+const (
+	SecTmpSaveUploadedFilesOn  SecTmpSaveUploadedFilesValue = "On"
+	SecTmpSaveUploadedFilesOff SecTmpSaveUploadedFilesValue = "Off"
+)
+
+func (sev SecTmpSaveUploadedFilesValue) Valid() bool {
+	return sev == SecTmpSaveUploadedFilesOn ||
+		sev == SecTmpSaveUploadedFilesOff
+}
+
+//DirectiveSecTmpSaveUploadedFiles Configures whether or not files uploaded via a multipart POST request will be temporarily saved to the file system.
+// This is required for the @inspectFile operator in libmodsecurity (v3).
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v3.x%29#SecTmpSaveUploadedFiles
+type DirectiveSecTmpSaveUploadedFiles struct {
+	AbstractNode
+	Value SecTmpSaveUploadedFilesValue
+}
+
+func (dir *DirectiveSecTmpSaveUploadedFiles) Name() string {
+	return "SecTmpSaveUploadedFiles"
+}
+
+//Directive is a marker to associate the struct with the Directive interface
+func (dir *DirectiveSecTmpSaveUploadedFiles) Directive() {}
+
+//Children returns all child nodes, this satisfies the Node interface
+func (dir *DirectiveSecTmpSaveUploadedFiles) Children() []Node {
+	return []Node{}
+}
+
+//DirectiveSecUnicodeCodePage Defines which Unicode code point will be used by the urlDecodeUni transformation function during normalization.
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecUnicodeCodePage
+type DirectiveSecUnicodeCodePage struct {
+	AbstractNode
+	Value int
+}
+
+func (dir *DirectiveSecUnicodeCodePage) Name() string {
+	return "SecUnicodeCodePage"
+}
+
+//Directive is a marker to associate the struct with the Directive interface
+func (dir *DirectiveSecUnicodeCodePage) Directive() {}
+
+//Children returns all child nodes, this satisfies the Node interface
+func (dir *DirectiveSecUnicodeCodePage) Children() []Node {
+	return []Node{}
+}
+
+//DirectiveSecUnicodeMapFile Defines the path to the file that will be used by the urlDecodeUni transformation function to map Unicode code points during normalization
+// and optionally specifies the Code Point to use.
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecUnicodeMapFile
+type DirectiveSecUnicodeMapFile struct {
+	AbstractNode
+
+	//The path to the unicode mapping file
+	Path string
+
+	//The code point used for normalization, 0 if it is omitted in which case SecUnicodeCodePage is used
+	CodePage int
+}
+
+func (dir *DirectiveSecUnicodeMapFile) Name() string {
+	return "SecUnicodeMapFile"
+}
+
+//Directive is a marker to associate the struct with the Directive interface
+func (dir *DirectiveSecUnicodeMapFile) Directive() {}
+
+//Children returns all child nodes, this satisfies the Node interface
+func (dir *DirectiveSecUnicodeMapFile) Children() []Node {
+	return []Node{}
+}
+
+//DirectiveSecUploadDir Configures the directory where intercepted files will be stored.
+// This directory must be on the same filesystem as the temporary directory defined with SecTmpDir. This directive is used with SecUploadKeepFiles.
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecUploadDir
+type DirectiveSecUploadDir struct {
+	AbstractNode
+	Value string
+}
+
+func (dir *DirectiveSecUploadDir) Name() string {
+	return "SecUploadDir"
+}
+
+//Directive is a marker to associate the struct with the Directive interface
+func (dir *DirectiveSecUploadDir) Directive() {}
+
+//Children returns all child nodes, this satisfies the Node interface
+func (dir *DirectiveSecUploadDir) Children() []Node {
+	return []Node{}
+}
+
+//DirectiveSecUploadFileLimit Configures the maximum number of file uploads processed in a multipart POST.
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecUploadFileLimit
+type DirectiveSecUploadFileLimit struct {
+	AbstractNode
+	Value int
+}
+
+func (dir *DirectiveSecUploadFileLimit) Name() string {
+	return "SecUploadFileLimit"
+}
+
+//Directive is a marker to associate the struct with the Directive interface
+func (dir *DirectiveSecUploadFileLimit) Directive() {}
+
+//Children returns all child nodes, this satisfies the Node interface
+func (dir *DirectiveSecUploadFileLimit) Children() []Node {
+	return []Node{}
+}
+
+//DirectiveSecUploadFileMode Configures the mode (permissions) of any uploaded files using an octal mode (as used in chmod).
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecUploadFileMode
+type DirectiveSecUploadFileMode struct {
+	AbstractNode
+	Value FileMode
+}
+
+func (dir *DirectiveSecUploadFileMode) Name() string {
+	return "SecUploadFileMode"
+}
+
+//Directive is a marker to associate the struct with the Directive interface
+func (dir *DirectiveSecUploadFileMode) Directive() {}
+
+//Children returns all child nodes, this satisfies the Node interface
+func (dir *DirectiveSecUploadFileMode) Children() []Node {
+	return []Node{}
+}
+
+//SecUploadKeepFilesValue is the value of the SecUploadKeepFiles directive
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecUploadKeepFiles
+type SecUploadKeepFilesValue string
+
+func (sev SecUploadKeepFilesValue) Valid() bool {
+	return sev == ModsecOn ||
+		sev == ModsecOff ||
+		sev == ModsecRelevantOnly
+}
+
+//DirectiveSecUploadKeepFiles Configures whether or not the intercepted files will be kept after transaction is processed.
+// This directive requires the storage directory to be defined (using SecUploadDir).
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecUploadKeepFiles
+type DirectiveSecUploadKeepFiles struct {
+	AbstractNode
+	Value SecUploadKeepFilesValue
+}
+
+func (dir *DirectiveSecUploadKeepFiles) Name() string {
+	return "SecUploadKeepFiles"
+}
+
+//Directive is a marker to associate the struct with the Directive interface
+func (dir *DirectiveSecUploadKeepFiles) Directive() {}
+
+//Children returns all child nodes, this satisfies the Node interface
+func (dir *DirectiveSecUploadKeepFiles) Children() []Node {
+	return []Node{}
+}
+
+//DirectiveSecWebAppId Creates an application namespace, allowing for separate persistent session and user storage.
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecWebAppId
+type DirectiveSecWebAppId struct {
+	AbstractNode
+	Value string
+}
+
+func (dir *DirectiveSecWebAppId) Name() string {
+	return "SecWebAppId"
+}
+
+//Directive is a marker to associate the struct with the Directive interface
+func (dir *DirectiveSecWebAppId) Directive() {}
+
+//Children returns all child nodes, this satisfies the Node interface
+func (dir *DirectiveSecWebAppId) Children() []Node {
+	return []Node{}
+}
+
+//SecXmlExternalEntityValue is the value of the SecXmlExternalEntity directive
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecXmlExternalEntity
+type SecXmlExternalEntityValue string
+
+func (sev SecXmlExternalEntityValue) Valid() bool {
+	return sev == ModsecOn ||
+		sev == ModsecOff
+}
+
+//DirectiveSecXmlExternalEntity Enable or Disable the loading process of xml external entity. Loading external entity without correct verifying process can lead to a security issue.
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-%28v2.x%29#SecXmlExternalEntity
+type DirectiveSecXmlExternalEntity struct {
+	AbstractNode
+	Value SecXmlExternalEntityValue
+}
+
+func (dir *DirectiveSecXmlExternalEntity) Name() string {
+	return "SecXmlExternalEntity"
+}
+
+//Directive is a marker to associate the struct with the Directive interface
+func (dir *DirectiveSecXmlExternalEntity) Directive() {}
+
+//Children returns all child nodes, this satisfies the Node interface
+func (dir *DirectiveSecXmlExternalEntity) Children() []Node {
 	return []Node{}
 }
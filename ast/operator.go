@@ -164,6 +164,26 @@ func (o *OperatorGreaterThan) Children() []Node {
 
 func (o *OperatorGreaterThan) Operator() {}
 
+//OperatorInspectFile Executes an external program for every variable in the target list. The contents of the variable is provided to the script as the first parameter on the command line.
+// The program must be specified as the first parameter to the operator. The program can be a Lua script or any other executable.
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#inspectFile
+type OperatorInspectFile struct {
+	AbstractOperator
+
+	//The path to the program which will be executed
+	Value string
+}
+
+func (o *OperatorInspectFile) Name() string {
+	return "inspectFile"
+}
+
+func (o *OperatorInspectFile) Children() []Node {
+	return []Node{}
+}
+
+func (o *OperatorInspectFile) Operator() {}
+
 //OperatorIPMatch Performs a fast ipv4 or ipv6 match of REMOTE_ADDR variable data.
 // https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#ipMatch
 type OperatorIPMatch struct {
@@ -21,6 +21,38 @@ func (t *TransformBase64Decode) Children() []Node {
 
 func (t *TransformBase64Decode) Transform() {}
 
+//TransformBase64DecodeExt Decodes a Base64-encoded string. Unlike base64Decode, this version uses a forgiving implementation, which ignores invalid characters.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#base64DecodeExt
+type TransformBase64DecodeExt struct {
+	AbstractNode
+}
+
+func (t *TransformBase64DecodeExt) Name() string {
+	return "base64DecodeExt"
+}
+
+func (t *TransformBase64DecodeExt) Children() []Node {
+	return []Node{}
+}
+
+func (t *TransformBase64DecodeExt) Transform() {}
+
+//TransformBase64Encode Encodes input string using Base64 encoding.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#base64Encode
+type TransformBase64Encode struct {
+	AbstractNode
+}
+
+func (t *TransformBase64Encode) Name() string {
+	return "base64Encode"
+}
+
+func (t *TransformBase64Encode) Children() []Node {
+	return []Node{}
+}
+
+func (t *TransformBase64Encode) Transform() {}
+
 //TransformCMDLine In Windows and Unix, commands may be escaped by different means. See reference manual for more details
 //https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#cmdline
 type TransformCMDLine struct {
@@ -72,6 +104,39 @@ func (t *TransformCSSDecode) Children() []Node {
 
 func (t *TransformCSSDecode) Transform() {}
 
+//TransformEscapeSeqDecode Decodes ANSI C escape sequences: \a, \b, \f, \n, \r, \t, \v, \\, \?, \', \", \xHH (hexadecimal), \0OOO (octal).
+// Invalid encodings are left in the output.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#escapeSeqDecode
+type TransformEscapeSeqDecode struct {
+	AbstractNode
+}
+
+func (t *TransformEscapeSeqDecode) Name() string {
+	return "escapeSeqDecode"
+}
+
+func (t *TransformEscapeSeqDecode) Children() []Node {
+	return []Node{}
+}
+
+func (t *TransformEscapeSeqDecode) Transform() {}
+
+//TransformHexDecode Decodes a string that has been encoded using the same algorithm as the one used in hexEncode (see following entry).
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#hexDecode
+type TransformHexDecode struct {
+	AbstractNode
+}
+
+func (t *TransformHexDecode) Name() string {
+	return "hexDecode"
+}
+
+func (t *TransformHexDecode) Children() []Node {
+	return []Node{}
+}
+
+func (t *TransformHexDecode) Transform() {}
+
 //TransformHexEncode Encodes string (possibly containing binary characters) by replacing each input byte with two hexadecimal characters. For example, xyz is encoded as 78797a.
 //https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#hexencode
 type TransformHexEncode struct {
@@ -162,6 +227,23 @@ func (t *TransformLowercase) Children() []Node {
 
 func (t *TransformLowercase) Transform() {}
 
+//TransformMD5 Calculates an MD5 hash from the data in input. The computed hash is in a raw binary form and may need encoded into text to be printed (or logged).
+// Hash functions are commonly used in combination with hexEncode (for example: t:md5,t:hexEncode).
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#md5
+type TransformMD5 struct {
+	AbstractNode
+}
+
+func (t *TransformMD5) Name() string {
+	return "md5"
+}
+
+func (t *TransformMD5) Children() []Node {
+	return []Node{}
+}
+
+func (t *TransformMD5) Transform() {}
+
 //TransformNone Not an actual transformation function, but an instruction to ModSecurity to remove all transformation functions associated with the current rule.
 //https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#none
 type TransformNone struct {
@@ -210,6 +292,87 @@ func (t *TransformNormalizePathWin) Children() []Node {
 
 func (t *TransformNormalizePathWin) Transform() {}
 
+//TransformParityEven7Bit Calculates even parity of 7-bit data replacing the 8th bit of each target byte with the calculated parity bit.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#parityEven7bit
+type TransformParityEven7Bit struct {
+	AbstractNode
+}
+
+func (t *TransformParityEven7Bit) Name() string {
+	return "parityEven7bit"
+}
+
+func (t *TransformParityEven7Bit) Children() []Node {
+	return []Node{}
+}
+
+func (t *TransformParityEven7Bit) Transform() {}
+
+//TransformParityOdd7Bit Calculates odd parity of 7-bit data replacing the 8th bit of each target byte with the calculated parity bit.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#parityOdd7bit
+type TransformParityOdd7Bit struct {
+	AbstractNode
+}
+
+func (t *TransformParityOdd7Bit) Name() string {
+	return "parityOdd7bit"
+}
+
+func (t *TransformParityOdd7Bit) Children() []Node {
+	return []Node{}
+}
+
+func (t *TransformParityOdd7Bit) Transform() {}
+
+//TransformParityZero7Bit Calculates zero parity of 7-bit data replacing the 8th bit of each target byte with a zero-parity bit, which allows inspection of even/odd parity 7-bit data as ASCII7 data.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#parityZero7bit
+type TransformParityZero7Bit struct {
+	AbstractNode
+}
+
+func (t *TransformParityZero7Bit) Name() string {
+	return "parityZero7bit"
+}
+
+func (t *TransformParityZero7Bit) Children() []Node {
+	return []Node{}
+}
+
+func (t *TransformParityZero7Bit) Transform() {}
+
+//TransformRemoveComments Removes each occurrence of comment (/* ... */, --, #).
+// Multiple consecutive occurrences of which will not be compressed.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#removeComments
+type TransformRemoveComments struct {
+	AbstractNode
+}
+
+func (t *TransformRemoveComments) Name() string {
+	return "removeComments"
+}
+
+func (t *TransformRemoveComments) Children() []Node {
+	return []Node{}
+}
+
+func (t *TransformRemoveComments) Transform() {}
+
+//TransformRemoveCommentsChar Removes common comments chars (/*, */, --, #).
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#removeCommentsChar
+type TransformRemoveCommentsChar struct {
+	AbstractNode
+}
+
+func (t *TransformRemoveCommentsChar) Name() string {
+	return "removeCommentsChar"
+}
+
+func (t *TransformRemoveCommentsChar) Children() []Node {
+	return []Node{}
+}
+
+func (t *TransformRemoveCommentsChar) Transform() {}
+
 //TransformRemoveNulls Removes all NUL bytes from input.
 //https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#removeNulls
 type TransformRemoveNulls struct {
@@ -226,6 +389,22 @@ func (t *TransformRemoveNulls) Children() []Node {
 
 func (t *TransformRemoveNulls) Transform() {}
 
+//TransformRemoveWhitespace Removes all whitespace characters from input.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#removeWhitespace
+type TransformRemoveWhitespace struct {
+	AbstractNode
+}
+
+func (t *TransformRemoveWhitespace) Name() string {
+	return "removeWhitespace"
+}
+
+func (t *TransformRemoveWhitespace) Children() []Node {
+	return []Node{}
+}
+
+func (t *TransformRemoveWhitespace) Transform() {}
+
 //TransformReplaceCommentsReplaces each occurrence of a C-style comment (/* ... */) with a single space (multiple consecutive occurrences of which will not be compressed).
 // Unterminated comments will also be replaced with a space (ASCII 0x20). However, a standalone termination of a comment (*/) will not be acted upon.
 //https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#replaceComments
@@ -243,6 +422,102 @@ func (t *TransformReplaceComments) Children() []Node {
 
 func (t *TransformReplaceComments) Transform() {}
 
+//TransformReplaceNulls Replaces NUL bytes in input with space characters (ASCII 0x20).
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#replaceNulls
+type TransformReplaceNulls struct {
+	AbstractNode
+}
+
+func (t *TransformReplaceNulls) Name() string {
+	return "replaceNulls"
+}
+
+func (t *TransformReplaceNulls) Children() []Node {
+	return []Node{}
+}
+
+func (t *TransformReplaceNulls) Transform() {}
+
+//TransformSQLHexDecode Decode sql hex data. Example (0x414243) will be decoded to (ABC). (available with 2.6.3)
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#sqlHexDecode
+type TransformSQLHexDecode struct {
+	AbstractNode
+}
+
+func (t *TransformSQLHexDecode) Name() string {
+	return "sqlHexDecode"
+}
+
+func (t *TransformSQLHexDecode) Children() []Node {
+	return []Node{}
+}
+
+func (t *TransformSQLHexDecode) Transform() {}
+
+//TransformTrim Removes whitespace from both the left and right sides of the input string.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#trim
+type TransformTrim struct {
+	AbstractNode
+}
+
+func (t *TransformTrim) Name() string {
+	return "trim"
+}
+
+func (t *TransformTrim) Children() []Node {
+	return []Node{}
+}
+
+func (t *TransformTrim) Transform() {}
+
+//TransformTrimLeft Removes whitespace from the left side of the input string.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#trimLeft
+type TransformTrimLeft struct {
+	AbstractNode
+}
+
+func (t *TransformTrimLeft) Name() string {
+	return "trimLeft"
+}
+
+func (t *TransformTrimLeft) Children() []Node {
+	return []Node{}
+}
+
+func (t *TransformTrimLeft) Transform() {}
+
+//TransformTrimRight Removes whitespace from the right side of the input string.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#trimRight
+type TransformTrimRight struct {
+	AbstractNode
+}
+
+func (t *TransformTrimRight) Name() string {
+	return "trimRight"
+}
+
+func (t *TransformTrimRight) Children() []Node {
+	return []Node{}
+}
+
+func (t *TransformTrimRight) Transform() {}
+
+//TransformUppercase Converts all characters to uppercase using the current C locale. (available with 2.8.1)
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#uppercase
+type TransformUppercase struct {
+	AbstractNode
+}
+
+func (t *TransformUppercase) Name() string {
+	return "uppercase"
+}
+
+func (t *TransformUppercase) Children() []Node {
+	return []Node{}
+}
+
+func (t *TransformUppercase) Transform() {}
+
 //TransformUrlDecode Decodes a URL-encoded input string.
 // Invalid encodings (i.e., the ones that use non-hexadecimal characters,
 // or the ones that are at the end of string and have one or two bytes missing) are not converted,
@@ -279,6 +554,22 @@ func (t *TransformUrlDecodeUni) Children() []Node {
 
 func (t *TransformUrlDecodeUni) Transform() {}
 
+//TransformUrlEncode Encodes input string using URL encoding.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#urlEncode
+type TransformUrlEncode struct {
+	AbstractNode
+}
+
+func (t *TransformUrlEncode) Name() string {
+	return "urlEncode"
+}
+
+func (t *TransformUrlEncode) Children() []Node {
+	return []Node{}
+}
+
+func (t *TransformUrlEncode) Transform() {}
+
 //TransformUTF8ToUnicode Converts all UTF-8 characters sequences to Unicode. This help input normalization specially for non-english languages minimizing false-positives and false-negatives. (available with 2.7.0)
 //https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#utf8toUnicode
 type TransformUTF8ToUnicode struct {
@@ -122,6 +122,150 @@ type Variable interface {
 	IsCollection() bool
 }
 
+//VariableAvailability describes the limitations of a variable which is not available in every ModSecurity version or server
+type VariableAvailability struct {
+	//ApacheOnly is true if the variable is only available if ModSecurity v2 runs as Apache module
+	ApacheOnly bool
+
+	//UnsupportedV3 is true if the variable is not supported by ModSecurity v3 (libmodsecurity)
+	UnsupportedV3 bool
+}
+
+//RestrictedVariable is implemented by variables which are not available in every ModSecurity version or server
+type RestrictedVariable interface {
+	Variable
+	Availability() VariableAvailability
+}
+
+//KnownVariables returns a new instance of every variable defined by ModSecurity, which excludes custom collections
+func KnownVariables() []Variable {
+	return []Variable{
+		&VariableArgs{},
+		&VariableArgsCombinedSize{},
+		&VariableArgsGet{},
+		&VariableArgsGetNames{},
+		&VariableArgsNames{},
+		&VariableArgsPost{},
+		&VariableArgsPostNames{},
+		&VariableAuthType{},
+		&VariableDuration{},
+		&VariableEnv{},
+		&VariableFiles{},
+		&VariableFilesCombinedSize{},
+		&VariableFilesNames{},
+		&VariableFilesSizes{},
+		&VariableFilesTmpNames{},
+		&VariableFilesTmpContent{},
+		&VariableFullRequest{},
+		&VariableFullRequestLength{},
+		&VariableGEO{},
+		&VariableGlobal{},
+		&VariableHighestSeverity{},
+		&VariableInboundDataError{},
+		&VariableIP{},
+		&VariableMatchedVar{},
+		&VariableMatchedVarName{},
+		&VariableMatchedVars{},
+		&VariableMatchedVarsNames{},
+		&VariableModsecBuild{},
+		&VariableMultipartBoundaryQuoted{},
+		&VariableMultipartBoundaryWhitespace{},
+		&VariableMultipartCRLFLFLines{},
+		&VariableMultipartDataAfter{},
+		&VariableMultipartDataBefore{},
+		&VariableMultipartFileLimitExceeded{},
+		&VariableMultipartFilename{},
+		&VariableMultipartHeaderFolding{},
+		&VariableMultipartInvalidHeaderFolding{},
+		&VariableMultipartInvalidPart{},
+		&VariableMultipartInvalidQuoting{},
+		&VariableMultipartLFLine{},
+		&VariableMultipartMissingSemicolon{},
+		&VariableMultipartName{},
+		&VariableMultipartStructError{},
+		&VariableMultipartUnmatchedBoundary{},
+		&VariableOutboundDataError{},
+		&VariablePathInfo{},
+		&VariablePerfAll{},
+		&VariablePerfCombined{},
+		&VariablePerfGC{},
+		&VariablePerfLogging{},
+		&VariablePerfPhase1{},
+		&VariablePerfPhase2{},
+		&VariablePerfPhase3{},
+		&VariablePerfPhase4{},
+		&VariablePerfPhase5{},
+		&VariablePerfRules{},
+		&VariablePerfSread{},
+		&VariablePerfSwrite{},
+		&VariableQueryString{},
+		&VariableRemoteAddress{},
+		&VariableRemoteHost{},
+		&VariableRemotePort{},
+		&VariableRemoteUser{},
+		&VariableRequestBodyError{},
+		&VariableRequestBodyErrorMessage{},
+		&VariableRequestBodyProcessor{},
+		&VariableRequestBodyProcessorError{},
+		&VariableRequestBasename{},
+		&VariableRequestBody{},
+		&VariableRequestBodyLength{},
+		&VariableRequestCookies{},
+		&VariableRequestCookiesNames{},
+		&VariableRequestFilename{},
+		&VariableRequestHeaders{},
+		&VariableRequestHeadersNames{},
+		&VariableRequestLine{},
+		&VariableRequestMethod{},
+		&VariableRequestProtocol{},
+		&VariableRequestURI{},
+		&VariableRequestURIRaw{},
+		&VariableResponseBody{},
+		&VariableResponseContentLength{},
+		&VariableResponseContentType{},
+		&VariableResponseHeaders{},
+		&VariableResponseHeadersNames{},
+		&VariableResponseProtocol{},
+		&VariableResponseStatus{},
+		&VariableResource{},
+		&VariableRule{},
+		&VariableScriptBasename{},
+		&VariableScriptFilename{},
+		&VariableScriptGID{},
+		&VariableScriptGroupname{},
+		&VariableScriptMode{},
+		&VariableScriptUID{},
+		&VariableScriptUsername{},
+		&VariableSdbmDeleteError{},
+		&VariableServerAddress{},
+		&VariableServerName{},
+		&VariableServerPort{},
+		&VariableSession{},
+		&VariableSessionID{},
+		&VariableStatusLine{},
+		&VariableStreamInputBody{},
+		&VariableStreamOutputBody{},
+		&VariableTime{},
+		&VariableTimeDay{},
+		&VariableTimeEpoch{},
+		&VariableTimeHour{},
+		&VariableTimeMin{},
+		&VariableTimeMon{},
+		&VariableTimeSec{},
+		&VariableTimeWDay{},
+		&VariableTimeYear{},
+		&VariableTransientTransactionCollection{},
+		&VariableUniqueID{},
+		&VariableURLEncodedError{},
+		&VariableUser{},
+		&VariableUserAgentIP{},
+		&VariableUserID{},
+		&VariableWebAppID{},
+		&VariableWebserverErrorLog{},
+		&VariableXML{},
+	}
+}
+
 //VariableCustomCollection is used to describe a variable which is not part of the Modsec config specification but is defined by the user.
 // Users can create custom collections using the initcol action. If we encounter a unknown variable name, we have to assume it is a custom collection
 type VariableCustomCollection struct {
@@ -236,6 +380,63 @@ func (v *VariableArgsNames) Children() []Node {
 	return []Node{}
 }
 
+//VariableArgsPost is similar to ARGS, but only contains arguments from the POST body.
+// It is a collection which is populated by the request body processor, so it is available starting with phase 2.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#ARGS_POST
+type VariableArgsPost struct {
+	AbstractNode
+}
+
+func (v *VariableArgsPost) Name() string {
+	return "ARGS_POST"
+}
+
+func (v *VariableArgsPost) IsCollection() bool {
+	return true
+}
+
+func (v *VariableArgsPost) Children() []Node {
+	return []Node{}
+}
+
+//VariableArgsPostNames is similar to ARGS_NAMES, but contains only the names of request body parameters.
+// It is a collection which is populated by the request body processor, so it is available starting with phase 2.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#ARGS_POST_NAMES
+type VariableArgsPostNames struct {
+	AbstractNode
+}
+
+func (v *VariableArgsPostNames) Name() string {
+	return "ARGS_POST_NAMES"
+}
+
+func (v *VariableArgsPostNames) IsCollection() bool {
+	return true
+}
+
+func (v *VariableArgsPostNames) Children() []Node {
+	return []Node{}
+}
+
+//VariableAuthType This variable holds the authentication method used to validate a user, if any of the methods built into HTTP are used.
+// In a reverse-proxy deployment, this information will not be available if the authentication is handled in the backend web server.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#AUTH_TYPE
+type VariableAuthType struct {
+	AbstractNode
+}
+
+func (v *VariableAuthType) Name() string {
+	return "AUTH_TYPE"
+}
+
+func (v *VariableAuthType) IsCollection() bool {
+	return false
+}
+
+func (v *VariableAuthType) Children() []Node {
+	return []Node{}
+}
+
 //VariableDuration Contains the number of milliseconds elapsed since the beginning of the current transaction.
 //https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#DURATION
 type VariableDuration struct {
@@ -254,6 +455,24 @@ func (v *VariableDuration) Children() []Node {
 	return []Node{}
 }
 
+//VariableEnv Collection that provides access to environment variables set by ModSecurity or other server modules. Requires a single parameter to specify the name of the desired variable.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#ENV
+type VariableEnv struct {
+	AbstractNode
+}
+
+func (v *VariableEnv) Name() string {
+	return "ENV"
+}
+
+func (v *VariableEnv) IsCollection() bool {
+	return true
+}
+
+func (v *VariableEnv) Children() []Node {
+	return []Node{}
+}
+
 //VariableFiles Contains a collection of original file names (as they were called on the remote user’s filesys- tem). Available only on inspected multipart/form-data requests.
 //https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#FILES
 type VariableFiles struct {
@@ -308,416 +527,2196 @@ func (v *VariableFilesNames) Children() []Node {
 	return []Node{}
 }
 
-//VariableGEO is a collection populated by the results of the last @geoLookup operator. The collection can be used to match geographical fields looked from an IP address or hostname.
-//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#GEO
-type VariableGEO struct {
+//VariableFilesSizes Contains a list of individual file sizes. Useful for implementing a size limitation on individual uploaded files.
+// It is a collection which is only available on inspected multipart/form-data requests, starting with phase 2.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#FILES_SIZES
+type VariableFilesSizes struct {
 	AbstractNode
 }
 
-func (v *VariableGEO) Name() string {
-	return "GEO"
+func (v *VariableFilesSizes) Name() string {
+	return "FILES_SIZES"
 }
 
-func (v *VariableGEO) IsCollection() bool {
+func (v *VariableFilesSizes) IsCollection() bool {
 	return true
 }
 
-func (v *VariableGEO) Children() []Node {
+func (v *VariableFilesSizes) Children() []Node {
 	return []Node{}
 }
 
-//VariableMatchedVars Similar to MATCHED_VAR except that it is a collection of all matches for the current operator check.
-//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#MATCHED_VARS
-type VariableMatchedVars struct {
+//VariableFilesTmpNames Contains a list of temporary files’ names on the disk. Useful when used together with @inspectFile.
+// It is a collection which is only available on inspected multipart/form-data requests, starting with phase 2.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#FILES_TMPNAMES
+type VariableFilesTmpNames struct {
 	AbstractNode
 }
 
-func (v *VariableMatchedVars) Name() string {
-	return "MATCHED_VARS"
+func (v *VariableFilesTmpNames) Name() string {
+	return "FILES_TMPNAMES"
 }
 
-func (v *VariableMatchedVars) IsCollection() bool {
+func (v *VariableFilesTmpNames) IsCollection() bool {
 	return true
 }
 
-func (v *VariableMatchedVars) Children() []Node {
+func (v *VariableFilesTmpNames) Children() []Node {
 	return []Node{}
 }
 
-//VariableMatchedVarsNames Similar to MATCHED_VAR_NAME except that it is a collection of all matches for the current operator check.
-//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#MATCHED_VARS_NAMES
-type VariableMatchedVarsNames struct {
+//VariableFilesTmpContent Contains a key-value set where value is the content of the file which was uploaded. Useful when used together with @fuzzyHash.
+// It is a collection which is only available on inspected multipart/form-data requests, starting with phase 2.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#FILES_TMP_CONTENT
+type VariableFilesTmpContent struct {
 	AbstractNode
 }
 
-func (v *VariableMatchedVarsNames) Name() string {
-	return "MATCHED_VARS_NAMES"
+func (v *VariableFilesTmpContent) Name() string {
+	return "FILES_TMP_CONTENT"
 }
 
-func (v *VariableMatchedVarsNames) IsCollection() bool {
+func (v *VariableFilesTmpContent) IsCollection() bool {
 	return true
 }
 
-func (v *VariableMatchedVarsNames) Children() []Node {
+func (v *VariableFilesTmpContent) Children() []Node {
 	return []Node{}
 }
 
-//VariableMultipartStructError MULTIPART_STRICT_ERROR will be set to 1 when any of the following variables is also set to 1:
-// REQBODY_PROCESSOR_ERROR, MULTIPART_BOUNDARY_QUOTED, MULTIPART_BOUNDARY_WHITESPACE, MULTIPART_DATA_BEFORE,
-// MULTIPART_DATA_AFTER, MULTIPART_HEADER_FOLDING, MULTIPART_LF_LINE, MULTIPART_MISSING_SEMICOLON MULTIPART_INVALID_QUOTING MULTIPART_INVALID_HEADER_FOLDING MULTIPART_FILE_LIMIT_EXCEEDED.
-// Each of these variables covers one unusual (although sometimes legal) aspect of the request body in multipart/form-data format.
-// Your policies should always contain a rule to check either this variable (easier) or one or more individual variables (if you know exactly what you want to accomplish).
-// Depending on the rate of false positives and your default policy you should decide whether to block or just warn when the rule is triggered.
-//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#MULTIPART_STRICT_ERROR
-type VariableMultipartStructError struct {
+//VariableFullRequest Contains the complete request: request line, request headers and request body (if any).
+// The request body is only included if SecRequestBodyAccess is On, so the complete value is available starting with phase 2.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#FULL_REQUEST
+type VariableFullRequest struct {
 	AbstractNode
 }
 
-func (v *VariableMultipartStructError) Name() string {
-	return "MULTIPART_STRICT_ERROR"
+func (v *VariableFullRequest) Name() string {
+	return "FULL_REQUEST"
 }
 
-func (v *VariableMultipartStructError) IsCollection() bool {
+func (v *VariableFullRequest) IsCollection() bool {
 	return false
 }
 
-func (v *VariableMultipartStructError) Children() []Node {
+func (v *VariableFullRequest) Children() []Node {
 	return []Node{}
 }
 
-//VariableQueryString Contains the query string part of a request URI. The value in QUERY_STRING is always provided raw, without URL decoding taking place.
-//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#QUERY_STRING
-type VariableQueryString struct {
+//VariableFullRequestLength Represents the amount of bytes that FULL_REQUEST may use.
+// Like FULL_REQUEST the complete value is available starting with phase 2.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#FULL_REQUEST_LENGTH
+type VariableFullRequestLength struct {
 	AbstractNode
 }
 
-func (v *VariableQueryString) Name() string {
-	return "QUERY_STRING"
+func (v *VariableFullRequestLength) Name() string {
+	return "FULL_REQUEST_LENGTH"
 }
 
-func (v *VariableQueryString) IsCollection() bool {
+func (v *VariableFullRequestLength) IsCollection() bool {
 	return false
 }
 
-func (v *VariableQueryString) Children() []Node {
+func (v *VariableFullRequestLength) Children() []Node {
 	return []Node{}
 }
 
-//VariableRemoteAddress This variable holds the IP address of the remote client.
-//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#REMOTE_ADDR
-type VariableRemoteAddress struct {
+//VariableGEO is a collection populated by the results of the last @geoLookup operator. The collection can be used to match geographical fields looked from an IP address or hostname.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#GEO
+type VariableGEO struct {
 	AbstractNode
 }
 
-func (v *VariableRemoteAddress) Name() string {
-	return "REMOTE_ADDR"
+func (v *VariableGEO) Name() string {
+	return "GEO"
 }
 
-func (v *VariableRemoteAddress) IsCollection() bool {
-	return false
+func (v *VariableGEO) IsCollection() bool {
+	return true
 }
 
-func (v *VariableRemoteAddress) Children() []Node {
+func (v *VariableGEO) Children() []Node {
 	return []Node{}
 }
 
-//VariableRequestBodyError Contains the status of the request body processor used for request body parsing. The values can be 0 (no error) or 1 (error).
-// This variable will be set by request body processors (typically the multipart/request-data parser, JSON or the XML parser) when they fail to do their work.
-//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#REQBODY_ERROR
-type VariableRequestBodyError struct {
+//VariableGlobal is a persistent collection which is shared by all transactions. It has to be initialized with the initcol action (i.e. initcol:global=global) before it can be used.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#initcol
+type VariableGlobal struct {
 	AbstractNode
 }
 
-func (v *VariableRequestBodyError) Name() string {
-	return "REQBODY_ERROR"
+func (v *VariableGlobal) Name() string {
+	return "GLOBAL"
 }
 
-func (v *VariableRequestBodyError) IsCollection() bool {
-	return false
+func (v *VariableGlobal) IsCollection() bool {
+	return true
 }
 
-func (v *VariableRequestBodyError) Children() []Node {
+func (v *VariableGlobal) Children() []Node {
 	return []Node{}
 }
 
-//VariableRequestBodyProcessor Contains the name of the currently used request body processor. The possible values are URLENCODED, MULTIPART, and XML.
-//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#REQBODY_PROCESSOR
-type VariableRequestBodyProcessor struct {
+//VariableHighestSeverity This variable holds the highest severity of any rules that have matched so far.
+// Severities are numeric values and thus can be used with comparison operators such as @lt, and so on. A value of 255 indicates that no severity has been set.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#HIGHEST_SEVERITY
+type VariableHighestSeverity struct {
 	AbstractNode
 }
 
-func (v *VariableRequestBodyProcessor) Name() string {
-	return "REQBODY_PROCESSOR"
+func (v *VariableHighestSeverity) Name() string {
+	return "HIGHEST_SEVERITY"
 }
 
-func (v *VariableRequestBodyProcessor) IsCollection() bool {
+func (v *VariableHighestSeverity) IsCollection() bool {
 	return false
 }
 
-func (v *VariableRequestBodyProcessor) Children() []Node {
+func (v *VariableHighestSeverity) Children() []Node {
 	return []Node{}
 }
 
-//VariableRequestBasename This variable holds just the filename part of REQUEST_FILENAME (e.g., index.php).
-//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#request_body
-type VariableRequestBasename struct {
+//VariableInboundDataError This variable will be set to 1 when the request body size is above the setting configured by SecRequestBodyLimit directive.
+// Your policies should always contain a rule to check this variable. It is available starting with phase 2.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#INBOUND_DATA_ERROR
+type VariableInboundDataError struct {
 	AbstractNode
 }
 
-func (v *VariableRequestBasename) Name() string {
-	return "REQUEST_BASENAME"
+func (v *VariableInboundDataError) Name() string {
+	return "INBOUND_DATA_ERROR"
 }
 
-func (v *VariableRequestBasename) IsCollection() bool {
+func (v *VariableInboundDataError) IsCollection() bool {
 	return false
 }
 
-func (v *VariableRequestBasename) Children() []Node {
+func (v *VariableInboundDataError) Children() []Node {
 	return []Node{}
 }
 
-//VariableRequestBody Holds the raw request body.
-// This variable is available only if the URLENCODED request body processor was used,
-// which will occur by default when the application/x-www-form-urlencoded content type is detected,
-// or if the use of the URLENCODED request body parser was forced.
-//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#request_body
-type VariableRequestBody struct {
+//VariableIP is a persistent collection which stores information about the client IP address. It has to be initialized with the initcol action (i.e. initcol:ip=%{REMOTE_ADDR}) before it can be used.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#initcol
+type VariableIP struct {
 	AbstractNode
 }
 
-func (v *VariableRequestBody) Name() string {
-	return "REQUEST_BODY"
+func (v *VariableIP) Name() string {
+	return "IP"
 }
 
-func (v *VariableRequestBody) IsCollection() bool {
-	return false
+func (v *VariableIP) IsCollection() bool {
+	return true
 }
 
-func (v *VariableRequestBody) Children() []Node {
+func (v *VariableIP) Children() []Node {
 	return []Node{}
 }
 
-//VariableRequestCookies This variable is a collection of all of request cookies
-//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#request_cookies
-type VariableRequestCookies struct {
+//VariableMatchedVar This variable holds the value of the most-recently matched variable.
+// It is similar to the TX:0, but it is automatically supported by all operators and there is no need to specify the capture action.
+// It is set during rule evaluation, so it is available in every phase, but only refers to matches in the current transaction.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#MATCHED_VAR
+type VariableMatchedVar struct {
 	AbstractNode
 }
 
-func (v *VariableRequestCookies) Name() string {
-	return "REQUEST_COOKIES"
+func (v *VariableMatchedVar) Name() string {
+	return "MATCHED_VAR"
 }
 
-func (v *VariableRequestCookies) IsCollection() bool {
-	return true
+func (v *VariableMatchedVar) IsCollection() bool {
+	return false
 }
 
-func (v *VariableRequestCookies) Children() []Node {
+func (v *VariableMatchedVar) Children() []Node {
 	return []Node{}
 }
 
-//VariableRequestCookiesNames This variable is a collection of the names of all request cookies
-//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#request_cookies_names
-type VariableRequestCookiesNames struct {
+//VariableMatchedVarName This variable holds the full name of the variable that was matched against.
+// It is set during rule evaluation, so it is available in every phase, but only refers to matches in the current transaction.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#MATCHED_VAR_NAME
+type VariableMatchedVarName struct {
 	AbstractNode
 }
 
-func (v *VariableRequestCookiesNames) Name() string {
-	return "REQUEST_COOKIES_NAMES"
+func (v *VariableMatchedVarName) Name() string {
+	return "MATCHED_VAR_NAME"
 }
 
-func (v *VariableRequestCookiesNames) IsCollection() bool {
-	return true
+func (v *VariableMatchedVarName) IsCollection() bool {
+	return false
 }
 
-func (v *VariableRequestCookiesNames) Children() []Node {
+func (v *VariableMatchedVarName) Children() []Node {
 	return []Node{}
 }
 
-//VariableRequestFilename This variable holds the relative request URL without the query string part (e.g., /index.php).
-//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#request_cookies_names
-type VariableRequestFilename struct {
+//VariableMatchedVars Similar to MATCHED_VAR except that it is a collection of all matches for the current operator check.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#MATCHED_VARS
+type VariableMatchedVars struct {
 	AbstractNode
 }
 
-func (v *VariableRequestFilename) Name() string {
-	return "REQUEST_FILENAME"
+func (v *VariableMatchedVars) Name() string {
+	return "MATCHED_VARS"
 }
 
-func (v *VariableRequestFilename) IsCollection() bool {
-	return false
+func (v *VariableMatchedVars) IsCollection() bool {
+	return true
 }
 
-func (v *VariableRequestFilename) Children() []Node {
+func (v *VariableMatchedVars) Children() []Node {
 	return []Node{}
 }
 
-//VariableRequestHeaders This variable can be used as either a collection of all of the request headers or can be used to inspect selected headers (by using the REQUEST_HEADERS:Header-Name syntax).
-//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#request_headers
-type VariableRequestHeaders struct {
+//VariableMatchedVarsNames Similar to MATCHED_VAR_NAME except that it is a collection of all matches for the current operator check.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#MATCHED_VARS_NAMES
+type VariableMatchedVarsNames struct {
 	AbstractNode
 }
 
-func (v *VariableRequestHeaders) Name() string {
-	return "REQUEST_HEADERS"
+func (v *VariableMatchedVarsNames) Name() string {
+	return "MATCHED_VARS_NAMES"
 }
 
-func (v *VariableRequestHeaders) IsCollection() bool {
+func (v *VariableMatchedVarsNames) IsCollection() bool {
 	return true
 }
 
-func (v *VariableRequestHeaders) Children() []Node {
+func (v *VariableMatchedVarsNames) Children() []Node {
 	return []Node{}
 }
 
-//VariableRequestHeadersNames This variable is a collection of the names of all of the request headers.
-//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#request_headers_names
-type VariableRequestHeadersNames struct {
+//VariableModsecBuild This variable holds the ModSecurity build number. This variable is intended to be used to check the build number prior to using a feature that is available only in a certain build.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#MODSEC_BUILD
+type VariableModsecBuild struct {
 	AbstractNode
 }
 
-func (v *VariableRequestHeadersNames) Name() string {
-	return "REQUEST_HEADERS_NAMES"
+func (v *VariableModsecBuild) Name() string {
+	return "MODSEC_BUILD"
 }
 
-func (v *VariableRequestHeadersNames) IsCollection() bool {
-	return true
+func (v *VariableModsecBuild) IsCollection() bool {
+	return false
 }
 
-func (v *VariableRequestHeadersNames) Children() []Node {
+func (v *VariableModsecBuild) Children() []Node {
 	return []Node{}
 }
 
-//VariableRequestLine This variable holds the complete request line sent to the server (including the request method and HTTP version information).
-//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#REQUEST_LINE
-type VariableRequestLine struct {
+//VariableMultipartBoundaryQuoted will be set to 1 when the boundary parameter of the Content-Type header is quoted.
+// It is set by the multipart request body processor, so it is available starting with phase 2.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#MULTIPART_BOUNDARY_QUOTED
+type VariableMultipartBoundaryQuoted struct {
 	AbstractNode
 }
 
-func (v *VariableRequestLine) Name() string {
-	return "REQUEST_LINE"
+func (v *VariableMultipartBoundaryQuoted) Name() string {
+	return "MULTIPART_BOUNDARY_QUOTED"
 }
 
-func (v *VariableRequestLine) IsCollection() bool {
+func (v *VariableMultipartBoundaryQuoted) IsCollection() bool {
 	return false
 }
 
-func (v *VariableRequestLine) Children() []Node {
+func (v *VariableMultipartBoundaryQuoted) Children() []Node {
 	return []Node{}
 }
 
-//VariableRequestMethod This variable holds the request method used in the transaction.
-//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#REQUEST_METHOD
-type VariableRequestMethod struct {
+//VariableMultipartBoundaryWhitespace will be set to 1 when the boundary parameter of the Content-Type header contains whitespace.
+// It is set by the multipart request body processor, so it is available starting with phase 2.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#MULTIPART_BOUNDARY_WHITESPACE
+type VariableMultipartBoundaryWhitespace struct {
 	AbstractNode
 }
 
-func (v *VariableRequestMethod) Name() string {
-	return "REQUEST_METHOD"
+func (v *VariableMultipartBoundaryWhitespace) Name() string {
+	return "MULTIPART_BOUNDARY_WHITESPACE"
 }
 
-func (v *VariableRequestMethod) IsCollection() bool {
+func (v *VariableMultipartBoundaryWhitespace) IsCollection() bool {
 	return false
 }
 
-func (v *VariableRequestMethod) Children() []Node {
+func (v *VariableMultipartBoundaryWhitespace) Children() []Node {
 	return []Node{}
 }
 
-//VariableRequestProtocol This variable holds the request protocol version information.
-//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#REQUEST_PROTOCOL
-type VariableRequestProtocol struct {
+//VariableMultipartCRLFLFLines This flag variable will be set to 1 whenever a multi-part request uses mixed line terminators.
+// The multipart/form-data RFC requires CRLF sequence to be used to terminate lines.
+// It is set by the multipart request body processor, so it is available starting with phase 2.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#MULTIPART_CRLF_LF_LINES
+type VariableMultipartCRLFLFLines struct {
 	AbstractNode
 }
 
-func (v *VariableRequestProtocol) Name() string {
-	return "REQUEST_PROTOCOL"
+func (v *VariableMultipartCRLFLFLines) Name() string {
+	return "MULTIPART_CRLF_LF_LINES"
 }
 
-func (v *VariableRequestProtocol) IsCollection() bool {
+func (v *VariableMultipartCRLFLFLines) IsCollection() bool {
 	return false
 }
 
-func (v *VariableRequestProtocol) Children() []Node {
+func (v *VariableMultipartCRLFLFLines) Children() []Node {
 	return []Node{}
 }
 
-//VariableRequestURI This variable holds the full request URL including the query string data (e.g., /index.php? p=X).
-// However, it will never contain a domain name, even if it was provided on the request line.
-//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#REQUEST_URI
-type VariableRequestURI struct {
+//VariableMultipartDataAfter will be set to 1 when there is data after the last boundary of the request body.
+// It is set by the multipart request body processor, so it is available starting with phase 2.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#MULTIPART_DATA_AFTER
+type VariableMultipartDataAfter struct {
 	AbstractNode
 }
 
-func (v *VariableRequestURI) Name() string {
-	return "REQUEST_URI"
+func (v *VariableMultipartDataAfter) Name() string {
+	return "MULTIPART_DATA_AFTER"
 }
 
-func (v *VariableRequestURI) IsCollection() bool {
+func (v *VariableMultipartDataAfter) IsCollection() bool {
 	return false
 }
 
-func (v *VariableRequestURI) Children() []Node {
+func (v *VariableMultipartDataAfter) Children() []Node {
 	return []Node{}
 }
 
-//VariableRequestURIRaw Same as REQUEST_URI but will contain the domain name if it was provided on the request line (e.g., http://www.example.com/index.php?p=X).
-//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#REQUEST_URI_RAW
-type VariableRequestURIRaw struct {
+//VariableMultipartDataBefore will be set to 1 when there is data before the first boundary of the request body.
+// It is set by the multipart request body processor, so it is available starting with phase 2.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#MULTIPART_DATA_BEFORE
+type VariableMultipartDataBefore struct {
 	AbstractNode
 }
 
-func (v *VariableRequestURIRaw) Name() string {
-	return "REQUEST_URI_RAW"
+func (v *VariableMultipartDataBefore) Name() string {
+	return "MULTIPART_DATA_BEFORE"
 }
 
-func (v *VariableRequestURIRaw) IsCollection() bool {
+func (v *VariableMultipartDataBefore) IsCollection() bool {
 	return false
 }
 
-func (v *VariableRequestURIRaw) Children() []Node {
+func (v *VariableMultipartDataBefore) Children() []Node {
 	return []Node{}
 }
 
-//VariableTransientTransactionCollection This is the transient transaction collection,
-// which is used to store pieces of data, create a transaction anomaly score, and so on.
-// The variables placed into this collection are available only until the transaction is complete.
-//
-//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#tx
-type VariableTransientTransactionCollection struct {
+//VariableMultipartFileLimitExceeded will be set to 1 when the number of uploaded files exceeds the limit set by SecUploadFileLimit.
+// It is set by the multipart request body processor, so it is available starting with phase 2.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#MULTIPART_FILE_LIMIT_EXCEEDED
+type VariableMultipartFileLimitExceeded struct {
 	AbstractNode
 }
 
-func (v *VariableTransientTransactionCollection) Name() string {
-	return "TX"
+func (v *VariableMultipartFileLimitExceeded) Name() string {
+	return "MULTIPART_FILE_LIMIT_EXCEEDED"
 }
 
-func (v *VariableTransientTransactionCollection) IsCollection() bool {
-	return true
+func (v *VariableMultipartFileLimitExceeded) IsCollection() bool {
+	return false
 }
 
-func (v *VariableTransientTransactionCollection) Children() []Node {
+func (v *VariableMultipartFileLimitExceeded) Children() []Node {
 	return []Node{}
 }
 
-//VariableUniqueID This variable holds the data created by mod_unique_id.
-// The UNIQUE_ID environment variable is constructed by encoding the 112-bit (32-bit IP address, 32 bit pid, 32 bit time stamp, 16 bit counter) quadruple using the alphabet [A-Za-z0-9@-] in a manner similar to MIME base64 encoding, producing 19 characters.
-//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#UNIQUE_ID
-type VariableUniqueID struct {
+//VariableMultipartFilename This variable contains the multipart data from field FILENAME.
+// It is set by the multipart request body processor, so it is available starting with phase 2.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#MULTIPART_FILENAME
+type VariableMultipartFilename struct {
 	AbstractNode
 }
 
-func (v *VariableUniqueID) Name() string {
-	return "UNIQUE_ID"
+func (v *VariableMultipartFilename) Name() string {
+	return "MULTIPART_FILENAME"
 }
 
-func (v *VariableUniqueID) IsCollection() bool {
+func (v *VariableMultipartFilename) IsCollection() bool {
 	return false
 }
 
-func (v *VariableUniqueID) Children() []Node {
+func (v *VariableMultipartFilename) Children() []Node {
 	return []Node{}
 }
 
+//VariableMultipartHeaderFolding will be set to 1 when a part header is folded over multiple lines.
+// It is set by the multipart request body processor, so it is available starting with phase 2.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#MULTIPART_HEADER_FOLDING
+type VariableMultipartHeaderFolding struct {
+	AbstractNode
+}
+
+func (v *VariableMultipartHeaderFolding) Name() string {
+	return "MULTIPART_HEADER_FOLDING"
+}
+
+func (v *VariableMultipartHeaderFolding) IsCollection() bool {
+	return false
+}
+
+func (v *VariableMultipartHeaderFolding) Children() []Node {
+	return []Node{}
+}
+
+//VariableMultipartInvalidHeaderFolding will be set to 1 when a part header is folded in a invalid way.
+// It is set by the multipart request body processor, so it is available starting with phase 2.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#MULTIPART_INVALID_HEADER_FOLDING
+type VariableMultipartInvalidHeaderFolding struct {
+	AbstractNode
+}
+
+func (v *VariableMultipartInvalidHeaderFolding) Name() string {
+	return "MULTIPART_INVALID_HEADER_FOLDING"
+}
+
+func (v *VariableMultipartInvalidHeaderFolding) IsCollection() bool {
+	return false
+}
+
+func (v *VariableMultipartInvalidHeaderFolding) Children() []Node {
+	return []Node{}
+}
+
+//VariableMultipartInvalidPart will be set to 1 when a part of the request body is invalid, for example when the part has no Content-Disposition header.
+// It is set by the multipart request body processor, so it is available starting with phase 2.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#MULTIPART_INVALID_PART
+type VariableMultipartInvalidPart struct {
+	AbstractNode
+}
+
+func (v *VariableMultipartInvalidPart) Name() string {
+	return "MULTIPART_INVALID_PART"
+}
+
+func (v *VariableMultipartInvalidPart) IsCollection() bool {
+	return false
+}
+
+func (v *VariableMultipartInvalidPart) Children() []Node {
+	return []Node{}
+}
+
+//VariableMultipartInvalidQuoting will be set to 1 when the quoting of a parameter in a Content-Disposition header is invalid.
+// It is set by the multipart request body processor, so it is available starting with phase 2.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#MULTIPART_INVALID_QUOTING
+type VariableMultipartInvalidQuoting struct {
+	AbstractNode
+}
+
+func (v *VariableMultipartInvalidQuoting) Name() string {
+	return "MULTIPART_INVALID_QUOTING"
+}
+
+func (v *VariableMultipartInvalidQuoting) IsCollection() bool {
+	return false
+}
+
+func (v *VariableMultipartInvalidQuoting) Children() []Node {
+	return []Node{}
+}
+
+//VariableMultipartLFLine will be set to 1 when a line of the request body is terminated by a LF instead of a CRLF.
+// It is set by the multipart request body processor, so it is available starting with phase 2.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#MULTIPART_LF_LINE
+type VariableMultipartLFLine struct {
+	AbstractNode
+}
+
+func (v *VariableMultipartLFLine) Name() string {
+	return "MULTIPART_LF_LINE"
+}
+
+func (v *VariableMultipartLFLine) IsCollection() bool {
+	return false
+}
+
+func (v *VariableMultipartLFLine) Children() []Node {
+	return []Node{}
+}
+
+//VariableMultipartMissingSemicolon will be set to 1 when a semicolon is missing in the Content-Disposition header of a part.
+// The variable is also known as MULTIPART_SEMICOLON_MISSING.
+// It is set by the multipart request body processor, so it is available starting with phase 2.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#MULTIPART_MISSING_SEMICOLON
+type VariableMultipartMissingSemicolon struct {
+	AbstractNode
+}
+
+func (v *VariableMultipartMissingSemicolon) Name() string {
+	return "MULTIPART_MISSING_SEMICOLON"
+}
+
+func (v *VariableMultipartMissingSemicolon) IsCollection() bool {
+	return false
+}
+
+func (v *VariableMultipartMissingSemicolon) Children() []Node {
+	return []Node{}
+}
+
+//VariableMultipartName This variable contains the multipart data from field NAME.
+// It is set by the multipart request body processor, so it is available starting with phase 2.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#MULTIPART_NAME
+type VariableMultipartName struct {
+	AbstractNode
+}
+
+func (v *VariableMultipartName) Name() string {
+	return "MULTIPART_NAME"
+}
+
+func (v *VariableMultipartName) IsCollection() bool {
+	return false
+}
+
+func (v *VariableMultipartName) Children() []Node {
+	return []Node{}
+}
+
+//VariableMultipartStructError MULTIPART_STRICT_ERROR will be set to 1 when any of the following variables is also set to 1:
+// REQBODY_PROCESSOR_ERROR, MULTIPART_BOUNDARY_QUOTED, MULTIPART_BOUNDARY_WHITESPACE, MULTIPART_DATA_BEFORE,
+// MULTIPART_DATA_AFTER, MULTIPART_HEADER_FOLDING, MULTIPART_LF_LINE, MULTIPART_MISSING_SEMICOLON MULTIPART_INVALID_QUOTING MULTIPART_INVALID_HEADER_FOLDING MULTIPART_FILE_LIMIT_EXCEEDED.
+// Each of these variables covers one unusual (although sometimes legal) aspect of the request body in multipart/form-data format.
+// Your policies should always contain a rule to check either this variable (easier) or one or more individual variables (if you know exactly what you want to accomplish).
+// Depending on the rate of false positives and your default policy you should decide whether to block or just warn when the rule is triggered.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#MULTIPART_STRICT_ERROR
+type VariableMultipartStructError struct {
+	AbstractNode
+}
+
+func (v *VariableMultipartStructError) Name() string {
+	return "MULTIPART_STRICT_ERROR"
+}
+
+func (v *VariableMultipartStructError) IsCollection() bool {
+	return false
+}
+
+func (v *VariableMultipartStructError) Children() []Node {
+	return []Node{}
+}
+
+//VariableMultipartUnmatchedBoundary Set to 1 when, during the parsing phase of a multipart/request-body, ModSecurity encounters what feels like a boundary but it is not.
+// Such an event may occur when evasion of ModSecurity is attempted.
+// It is set by the multipart request body processor, so it is available starting with phase 2.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#MULTIPART_UNMATCHED_BOUNDARY
+type VariableMultipartUnmatchedBoundary struct {
+	AbstractNode
+}
+
+func (v *VariableMultipartUnmatchedBoundary) Name() string {
+	return "MULTIPART_UNMATCHED_BOUNDARY"
+}
+
+func (v *VariableMultipartUnmatchedBoundary) IsCollection() bool {
+	return false
+}
+
+func (v *VariableMultipartUnmatchedBoundary) Children() []Node {
+	return []Node{}
+}
+
+//VariableOutboundDataError This variable will be set to 1 when the response body size is above the setting configured by SecResponseBodyLimit directive.
+// Your policies should always contain a rule to check this variable.
+// Depending on the rate of false positives and your default policy you should decide whether to block or just warn when the rule is triggered.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#OUTBOUND_DATA_ERROR
+type VariableOutboundDataError struct {
+	AbstractNode
+}
+
+func (v *VariableOutboundDataError) Name() string {
+	return "OUTBOUND_DATA_ERROR"
+}
+
+func (v *VariableOutboundDataError) IsCollection() bool {
+	return false
+}
+
+func (v *VariableOutboundDataError) Children() []Node {
+	return []Node{}
+}
+
+//VariablePathInfo Contains the extra request URI information, also known as path info. (For example, in the URI /index.php/123, /123 is the path info.)
+// Available only in embedded deployments.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#PATH_INFO
+type VariablePathInfo struct {
+	AbstractNode
+}
+
+func (v *VariablePathInfo) Name() string {
+	return "PATH_INFO"
+}
+
+func (v *VariablePathInfo) IsCollection() bool {
+	return false
+}
+
+func (v *VariablePathInfo) Children() []Node {
+	return []Node{}
+}
+
+//VariablePerfAll This special variable contains a string that’s a combination of all other performance variables, arranged in the same order in which they appear in the Stopwatch2 audit log header.
+// It’s intended for use in custom Apache logs.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#PERF_ALL
+type VariablePerfAll struct {
+	AbstractNode
+}
+
+func (v *VariablePerfAll) Name() string {
+	return "PERF_ALL"
+}
+
+func (v *VariablePerfAll) IsCollection() bool {
+	return false
+}
+
+func (v *VariablePerfAll) Children() []Node {
+	return []Node{}
+}
+
+//Availability returns the limitations of the variable, this satisfies the RestrictedVariable interface
+func (v *VariablePerfAll) Availability() VariableAvailability {
+	return VariableAvailability{
+		UnsupportedV3: true,
+	}
+}
+
+//VariablePerfCombined Contains the time, in microseconds, spent in ModSecurity during the current transaction.
+// The value in this variable is arrived to by adding all the performance variables except PERF_SREAD (the time spent reading from persistent storage is already included in the phase measurements).
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#PERF_COMBINED
+type VariablePerfCombined struct {
+	AbstractNode
+}
+
+func (v *VariablePerfCombined) Name() string {
+	return "PERF_COMBINED"
+}
+
+func (v *VariablePerfCombined) IsCollection() bool {
+	return false
+}
+
+func (v *VariablePerfCombined) Children() []Node {
+	return []Node{}
+}
+
+//Availability returns the limitations of the variable, this satisfies the RestrictedVariable interface
+func (v *VariablePerfCombined) Availability() VariableAvailability {
+	return VariableAvailability{
+		UnsupportedV3: true,
+	}
+}
+
+//VariablePerfGC Contains the time, in microseconds, spent performing garbage collection.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#PERF_GC
+type VariablePerfGC struct {
+	AbstractNode
+}
+
+func (v *VariablePerfGC) Name() string {
+	return "PERF_GC"
+}
+
+func (v *VariablePerfGC) IsCollection() bool {
+	return false
+}
+
+func (v *VariablePerfGC) Children() []Node {
+	return []Node{}
+}
+
+//Availability returns the limitations of the variable, this satisfies the RestrictedVariable interface
+func (v *VariablePerfGC) Availability() VariableAvailability {
+	return VariableAvailability{
+		UnsupportedV3: true,
+	}
+}
+
+//VariablePerfLogging Contains the time, in microseconds, spent in audit logging.
+// This value is known only after the handling of a transaction is finalized, which means that it can only be logged using mod_log_config and the %{VARNAME}M syntax.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#PERF_LOGGING
+type VariablePerfLogging struct {
+	AbstractNode
+}
+
+func (v *VariablePerfLogging) Name() string {
+	return "PERF_LOGGING"
+}
+
+func (v *VariablePerfLogging) IsCollection() bool {
+	return false
+}
+
+func (v *VariablePerfLogging) Children() []Node {
+	return []Node{}
+}
+
+//Availability returns the limitations of the variable, this satisfies the RestrictedVariable interface
+func (v *VariablePerfLogging) Availability() VariableAvailability {
+	return VariableAvailability{
+		UnsupportedV3: true,
+	}
+}
+
+//VariablePerfPhase1 Contains the time, in microseconds, spent processing phase 1.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#PERF_PHASE1
+type VariablePerfPhase1 struct {
+	AbstractNode
+}
+
+func (v *VariablePerfPhase1) Name() string {
+	return "PERF_PHASE1"
+}
+
+func (v *VariablePerfPhase1) IsCollection() bool {
+	return false
+}
+
+func (v *VariablePerfPhase1) Children() []Node {
+	return []Node{}
+}
+
+//Availability returns the limitations of the variable, this satisfies the RestrictedVariable interface
+func (v *VariablePerfPhase1) Availability() VariableAvailability {
+	return VariableAvailability{
+		UnsupportedV3: true,
+	}
+}
+
+//VariablePerfPhase2 Contains the time, in microseconds, spent processing phase 2.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#PERF_PHASE2
+type VariablePerfPhase2 struct {
+	AbstractNode
+}
+
+func (v *VariablePerfPhase2) Name() string {
+	return "PERF_PHASE2"
+}
+
+func (v *VariablePerfPhase2) IsCollection() bool {
+	return false
+}
+
+func (v *VariablePerfPhase2) Children() []Node {
+	return []Node{}
+}
+
+//Availability returns the limitations of the variable, this satisfies the RestrictedVariable interface
+func (v *VariablePerfPhase2) Availability() VariableAvailability {
+	return VariableAvailability{
+		UnsupportedV3: true,
+	}
+}
+
+//VariablePerfPhase3 Contains the time, in microseconds, spent processing phase 3.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#PERF_PHASE3
+type VariablePerfPhase3 struct {
+	AbstractNode
+}
+
+func (v *VariablePerfPhase3) Name() string {
+	return "PERF_PHASE3"
+}
+
+func (v *VariablePerfPhase3) IsCollection() bool {
+	return false
+}
+
+func (v *VariablePerfPhase3) Children() []Node {
+	return []Node{}
+}
+
+//Availability returns the limitations of the variable, this satisfies the RestrictedVariable interface
+func (v *VariablePerfPhase3) Availability() VariableAvailability {
+	return VariableAvailability{
+		UnsupportedV3: true,
+	}
+}
+
+//VariablePerfPhase4 Contains the time, in microseconds, spent processing phase 4.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#PERF_PHASE4
+type VariablePerfPhase4 struct {
+	AbstractNode
+}
+
+func (v *VariablePerfPhase4) Name() string {
+	return "PERF_PHASE4"
+}
+
+func (v *VariablePerfPhase4) IsCollection() bool {
+	return false
+}
+
+func (v *VariablePerfPhase4) Children() []Node {
+	return []Node{}
+}
+
+//Availability returns the limitations of the variable, this satisfies the RestrictedVariable interface
+func (v *VariablePerfPhase4) Availability() VariableAvailability {
+	return VariableAvailability{
+		UnsupportedV3: true,
+	}
+}
+
+//VariablePerfPhase5 Contains the time, in microseconds, spent processing phase 5.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#PERF_PHASE5
+type VariablePerfPhase5 struct {
+	AbstractNode
+}
+
+func (v *VariablePerfPhase5) Name() string {
+	return "PERF_PHASE5"
+}
+
+func (v *VariablePerfPhase5) IsCollection() bool {
+	return false
+}
+
+func (v *VariablePerfPhase5) Children() []Node {
+	return []Node{}
+}
+
+//Availability returns the limitations of the variable, this satisfies the RestrictedVariable interface
+func (v *VariablePerfPhase5) Availability() VariableAvailability {
+	return VariableAvailability{
+		UnsupportedV3: true,
+	}
+}
+
+//VariablePerfRules is a collection, that is populated with the rules hitting the performance threshold defined with SecRulePerfTime.
+// The collection contains the time spent processing the rule in microseconds next to the rule ID as key.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#PERF_RULES
+type VariablePerfRules struct {
+	AbstractNode
+}
+
+func (v *VariablePerfRules) Name() string {
+	return "PERF_RULES"
+}
+
+func (v *VariablePerfRules) IsCollection() bool {
+	return true
+}
+
+func (v *VariablePerfRules) Children() []Node {
+	return []Node{}
+}
+
+//Availability returns the limitations of the variable, this satisfies the RestrictedVariable interface
+func (v *VariablePerfRules) Availability() VariableAvailability {
+	return VariableAvailability{
+		UnsupportedV3: true,
+	}
+}
+
+//VariablePerfSread Contains the time, in microseconds, spent reading from persistent storage.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#PERF_SREAD
+type VariablePerfSread struct {
+	AbstractNode
+}
+
+func (v *VariablePerfSread) Name() string {
+	return "PERF_SREAD"
+}
+
+func (v *VariablePerfSread) IsCollection() bool {
+	return false
+}
+
+func (v *VariablePerfSread) Children() []Node {
+	return []Node{}
+}
+
+//Availability returns the limitations of the variable, this satisfies the RestrictedVariable interface
+func (v *VariablePerfSread) Availability() VariableAvailability {
+	return VariableAvailability{
+		UnsupportedV3: true,
+	}
+}
+
+//VariablePerfSwrite Contains the time, in microseconds, spent writing to persistent storage.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#PERF_SWRITE
+type VariablePerfSwrite struct {
+	AbstractNode
+}
+
+func (v *VariablePerfSwrite) Name() string {
+	return "PERF_SWRITE"
+}
+
+func (v *VariablePerfSwrite) IsCollection() bool {
+	return false
+}
+
+func (v *VariablePerfSwrite) Children() []Node {
+	return []Node{}
+}
+
+//Availability returns the limitations of the variable, this satisfies the RestrictedVariable interface
+func (v *VariablePerfSwrite) Availability() VariableAvailability {
+	return VariableAvailability{
+		UnsupportedV3: true,
+	}
+}
+
+//VariableQueryString Contains the query string part of a request URI. The value in QUERY_STRING is always provided raw, without URL decoding taking place.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#QUERY_STRING
+type VariableQueryString struct {
+	AbstractNode
+}
+
+func (v *VariableQueryString) Name() string {
+	return "QUERY_STRING"
+}
+
+func (v *VariableQueryString) IsCollection() bool {
+	return false
+}
+
+func (v *VariableQueryString) Children() []Node {
+	return []Node{}
+}
+
+//VariableRemoteAddress This variable holds the IP address of the remote client.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#REMOTE_ADDR
+type VariableRemoteAddress struct {
+	AbstractNode
+}
+
+func (v *VariableRemoteAddress) Name() string {
+	return "REMOTE_ADDR"
+}
+
+func (v *VariableRemoteAddress) IsCollection() bool {
+	return false
+}
+
+func (v *VariableRemoteAddress) Children() []Node {
+	return []Node{}
+}
+
+//VariableRemoteHost If the Apache directive HostnameLookups is set to On, then this variable will hold the remote hostname resolved through DNS.
+// If the directive is set to Off, this variable it will hold the remote IP address (same as REMOTE_ADDR).
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#REMOTE_HOST
+type VariableRemoteHost struct {
+	AbstractNode
+}
+
+func (v *VariableRemoteHost) Name() string {
+	return "REMOTE_HOST"
+}
+
+func (v *VariableRemoteHost) IsCollection() bool {
+	return false
+}
+
+func (v *VariableRemoteHost) Children() []Node {
+	return []Node{}
+}
+
+//VariableRemotePort This variable holds information on the source port that the client used when initiating the connection to our web server.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#REMOTE_PORT
+type VariableRemotePort struct {
+	AbstractNode
+}
+
+func (v *VariableRemotePort) Name() string {
+	return "REMOTE_PORT"
+}
+
+func (v *VariableRemotePort) IsCollection() bool {
+	return false
+}
+
+func (v *VariableRemotePort) Children() []Node {
+	return []Node{}
+}
+
+//VariableRemoteUser This variable holds the username of the authenticated user. If there are no password access controls in place (Basic or Digest authentication), then this variable will be empty.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#REMOTE_USER
+type VariableRemoteUser struct {
+	AbstractNode
+}
+
+func (v *VariableRemoteUser) Name() string {
+	return "REMOTE_USER"
+}
+
+func (v *VariableRemoteUser) IsCollection() bool {
+	return false
+}
+
+func (v *VariableRemoteUser) Children() []Node {
+	return []Node{}
+}
+
+//VariableRequestBodyError Contains the status of the request body processor used for request body parsing. The values can be 0 (no error) or 1 (error).
+// This variable will be set by request body processors (typically the multipart/request-data parser, JSON or the XML parser) when they fail to do their work.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#REQBODY_ERROR
+type VariableRequestBodyError struct {
+	AbstractNode
+}
+
+func (v *VariableRequestBodyError) Name() string {
+	return "REQBODY_ERROR"
+}
+
+func (v *VariableRequestBodyError) IsCollection() bool {
+	return false
+}
+
+func (v *VariableRequestBodyError) Children() []Node {
+	return []Node{}
+}
+
+//VariableRequestBodyErrorMessage If there’s been an error during request body parsing, the variable will contain the following error message.
+// It is set by the request body processor, so it is available starting with phase 2.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#REQBODY_ERROR_MSG
+type VariableRequestBodyErrorMessage struct {
+	AbstractNode
+}
+
+func (v *VariableRequestBodyErrorMessage) Name() string {
+	return "REQBODY_ERROR_MSG"
+}
+
+func (v *VariableRequestBodyErrorMessage) IsCollection() bool {
+	return false
+}
+
+func (v *VariableRequestBodyErrorMessage) Children() []Node {
+	return []Node{}
+}
+
+//VariableRequestBodyProcessor Contains the name of the currently used request body processor. The possible values are URLENCODED, MULTIPART, and XML.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#REQBODY_PROCESSOR
+type VariableRequestBodyProcessor struct {
+	AbstractNode
+}
+
+func (v *VariableRequestBodyProcessor) Name() string {
+	return "REQBODY_PROCESSOR"
+}
+
+func (v *VariableRequestBodyProcessor) IsCollection() bool {
+	return false
+}
+
+func (v *VariableRequestBodyProcessor) Children() []Node {
+	return []Node{}
+}
+
+//VariableRequestBodyProcessorError Contains the status of the request body processor, 1 if it failed and 0 otherwise.
+// This variable is deprecated, it is set for compatibility with older rules. Please use REQBODY_ERROR instead.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#REQBODY_PROCESSOR_ERROR
+type VariableRequestBodyProcessorError struct {
+	AbstractNode
+}
+
+func (v *VariableRequestBodyProcessorError) Name() string {
+	return "REQBODY_PROCESSOR_ERROR"
+}
+
+func (v *VariableRequestBodyProcessorError) IsCollection() bool {
+	return false
+}
+
+func (v *VariableRequestBodyProcessorError) Children() []Node {
+	return []Node{}
+}
+
+//VariableRequestBasename This variable holds just the filename part of REQUEST_FILENAME (e.g., index.php).
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#request_body
+type VariableRequestBasename struct {
+	AbstractNode
+}
+
+func (v *VariableRequestBasename) Name() string {
+	return "REQUEST_BASENAME"
+}
+
+func (v *VariableRequestBasename) IsCollection() bool {
+	return false
+}
+
+func (v *VariableRequestBasename) Children() []Node {
+	return []Node{}
+}
+
+//VariableRequestBody Holds the raw request body.
+// This variable is available only if the URLENCODED request body processor was used,
+// which will occur by default when the application/x-www-form-urlencoded content type is detected,
+// or if the use of the URLENCODED request body parser was forced.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#request_body
+type VariableRequestBody struct {
+	AbstractNode
+}
+
+func (v *VariableRequestBody) Name() string {
+	return "REQUEST_BODY"
+}
+
+func (v *VariableRequestBody) IsCollection() bool {
+	return false
+}
+
+func (v *VariableRequestBody) Children() []Node {
+	return []Node{}
+}
+
+//VariableRequestBodyLength Contains the number of bytes read from a request body. It is available starting with phase 2.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#REQUEST_BODY_LENGTH
+type VariableRequestBodyLength struct {
+	AbstractNode
+}
+
+func (v *VariableRequestBodyLength) Name() string {
+	return "REQUEST_BODY_LENGTH"
+}
+
+func (v *VariableRequestBodyLength) IsCollection() bool {
+	return false
+}
+
+func (v *VariableRequestBodyLength) Children() []Node {
+	return []Node{}
+}
+
+//VariableRequestCookies This variable is a collection of all of request cookies
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#request_cookies
+type VariableRequestCookies struct {
+	AbstractNode
+}
+
+func (v *VariableRequestCookies) Name() string {
+	return "REQUEST_COOKIES"
+}
+
+func (v *VariableRequestCookies) IsCollection() bool {
+	return true
+}
+
+func (v *VariableRequestCookies) Children() []Node {
+	return []Node{}
+}
+
+//VariableRequestCookiesNames This variable is a collection of the names of all request cookies
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#request_cookies_names
+type VariableRequestCookiesNames struct {
+	AbstractNode
+}
+
+func (v *VariableRequestCookiesNames) Name() string {
+	return "REQUEST_COOKIES_NAMES"
+}
+
+func (v *VariableRequestCookiesNames) IsCollection() bool {
+	return true
+}
+
+func (v *VariableRequestCookiesNames) Children() []Node {
+	return []Node{}
+}
+
+//VariableRequestFilename This variable holds the relative request URL without the query string part (e.g., /index.php).
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#request_cookies_names
+type VariableRequestFilename struct {
+	AbstractNode
+}
+
+func (v *VariableRequestFilename) Name() string {
+	return "REQUEST_FILENAME"
+}
+
+func (v *VariableRequestFilename) IsCollection() bool {
+	return false
+}
+
+func (v *VariableRequestFilename) Children() []Node {
+	return []Node{}
+}
+
+//VariableRequestHeaders This variable can be used as either a collection of all of the request headers or can be used to inspect selected headers (by using the REQUEST_HEADERS:Header-Name syntax).
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#request_headers
+type VariableRequestHeaders struct {
+	AbstractNode
+}
+
+func (v *VariableRequestHeaders) Name() string {
+	return "REQUEST_HEADERS"
+}
+
+func (v *VariableRequestHeaders) IsCollection() bool {
+	return true
+}
+
+func (v *VariableRequestHeaders) Children() []Node {
+	return []Node{}
+}
+
+//VariableRequestHeadersNames This variable is a collection of the names of all of the request headers.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#request_headers_names
+type VariableRequestHeadersNames struct {
+	AbstractNode
+}
+
+func (v *VariableRequestHeadersNames) Name() string {
+	return "REQUEST_HEADERS_NAMES"
+}
+
+func (v *VariableRequestHeadersNames) IsCollection() bool {
+	return true
+}
+
+func (v *VariableRequestHeadersNames) Children() []Node {
+	return []Node{}
+}
+
+//VariableRequestLine This variable holds the complete request line sent to the server (including the request method and HTTP version information).
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#REQUEST_LINE
+type VariableRequestLine struct {
+	AbstractNode
+}
+
+func (v *VariableRequestLine) Name() string {
+	return "REQUEST_LINE"
+}
+
+func (v *VariableRequestLine) IsCollection() bool {
+	return false
+}
+
+func (v *VariableRequestLine) Children() []Node {
+	return []Node{}
+}
+
+//VariableRequestMethod This variable holds the request method used in the transaction.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#REQUEST_METHOD
+type VariableRequestMethod struct {
+	AbstractNode
+}
+
+func (v *VariableRequestMethod) Name() string {
+	return "REQUEST_METHOD"
+}
+
+func (v *VariableRequestMethod) IsCollection() bool {
+	return false
+}
+
+func (v *VariableRequestMethod) Children() []Node {
+	return []Node{}
+}
+
+//VariableRequestProtocol This variable holds the request protocol version information.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#REQUEST_PROTOCOL
+type VariableRequestProtocol struct {
+	AbstractNode
+}
+
+func (v *VariableRequestProtocol) Name() string {
+	return "REQUEST_PROTOCOL"
+}
+
+func (v *VariableRequestProtocol) IsCollection() bool {
+	return false
+}
+
+func (v *VariableRequestProtocol) Children() []Node {
+	return []Node{}
+}
+
+//VariableRequestURI This variable holds the full request URL including the query string data (e.g., /index.php? p=X).
+// However, it will never contain a domain name, even if it was provided on the request line.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#REQUEST_URI
+type VariableRequestURI struct {
+	AbstractNode
+}
+
+func (v *VariableRequestURI) Name() string {
+	return "REQUEST_URI"
+}
+
+func (v *VariableRequestURI) IsCollection() bool {
+	return false
+}
+
+func (v *VariableRequestURI) Children() []Node {
+	return []Node{}
+}
+
+//VariableRequestURIRaw Same as REQUEST_URI but will contain the domain name if it was provided on the request line (e.g., http://www.example.com/index.php?p=X).
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#REQUEST_URI_RAW
+type VariableRequestURIRaw struct {
+	AbstractNode
+}
+
+func (v *VariableRequestURIRaw) Name() string {
+	return "REQUEST_URI_RAW"
+}
+
+func (v *VariableRequestURIRaw) IsCollection() bool {
+	return false
+}
+
+func (v *VariableRequestURIRaw) Children() []Node {
+	return []Node{}
+}
+
+//VariableResponseBody This variable holds the data for the response body, but only when response body buffering is enabled.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#RESPONSE_BODY
+type VariableResponseBody struct {
+	AbstractNode
+}
+
+func (v *VariableResponseBody) Name() string {
+	return "RESPONSE_BODY"
+}
+
+func (v *VariableResponseBody) IsCollection() bool {
+	return false
+}
+
+func (v *VariableResponseBody) Children() []Node {
+	return []Node{}
+}
+
+//VariableResponseContentLength Response body length in bytes. Can be available starting with phase 3, but it does not have to be
+// (as the length of response body is not always known in advance).
+// If the size is not known, this variable will contain a zero.
+// If RESPONSE_CONTENT_LENGTH contains a zero in phase 5 that means the actual size of the response body was 0.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#RESPONSE_CONTENT_LENGTH
+type VariableResponseContentLength struct {
+	AbstractNode
+}
+
+func (v *VariableResponseContentLength) Name() string {
+	return "RESPONSE_CONTENT_LENGTH"
+}
+
+func (v *VariableResponseContentLength) IsCollection() bool {
+	return false
+}
+
+func (v *VariableResponseContentLength) Children() []Node {
+	return []Node{}
+}
+
+//VariableResponseContentType Response content type. Available only starting with phase 3.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#RESPONSE_CONTENT_TYPE
+type VariableResponseContentType struct {
+	AbstractNode
+}
+
+func (v *VariableResponseContentType) Name() string {
+	return "RESPONSE_CONTENT_TYPE"
+}
+
+func (v *VariableResponseContentType) IsCollection() bool {
+	return false
+}
+
+func (v *VariableResponseContentType) Children() []Node {
+	return []Node{}
+}
+
+//VariableResponseHeaders This variable refers to response headers, in the same way as REQUEST_HEADERS does to request headers.
+// This variable may not have access to some headers when reverse proxy is used.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#RESPONSE_HEADERS
+type VariableResponseHeaders struct {
+	AbstractNode
+}
+
+func (v *VariableResponseHeaders) Name() string {
+	return "RESPONSE_HEADERS"
+}
+
+func (v *VariableResponseHeaders) IsCollection() bool {
+	return true
+}
+
+func (v *VariableResponseHeaders) Children() []Node {
+	return []Node{}
+}
+
+//VariableResponseHeadersNames This variable is a collection of the response header names.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#RESPONSE_HEADERS_NAMES
+type VariableResponseHeadersNames struct {
+	AbstractNode
+}
+
+func (v *VariableResponseHeadersNames) Name() string {
+	return "RESPONSE_HEADERS_NAMES"
+}
+
+func (v *VariableResponseHeadersNames) IsCollection() bool {
+	return true
+}
+
+func (v *VariableResponseHeadersNames) Children() []Node {
+	return []Node{}
+}
+
+//VariableResponseProtocol This variable holds the HTTP response protocol information.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#RESPONSE_PROTOCOL
+type VariableResponseProtocol struct {
+	AbstractNode
+}
+
+func (v *VariableResponseProtocol) Name() string {
+	return "RESPONSE_PROTOCOL"
+}
+
+func (v *VariableResponseProtocol) IsCollection() bool {
+	return false
+}
+
+func (v *VariableResponseProtocol) Children() []Node {
+	return []Node{}
+}
+
+//VariableResponseStatus This variable holds the HTTP response status code.
+// This variable may not work as expected in embedded mode, as Apache sometimes handles certain requests differently, and without invoking ModSecurity (all other hooks).
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#RESPONSE_STATUS
+type VariableResponseStatus struct {
+	AbstractNode
+}
+
+func (v *VariableResponseStatus) Name() string {
+	return "RESPONSE_STATUS"
+}
+
+func (v *VariableResponseStatus) IsCollection() bool {
+	return false
+}
+
+func (v *VariableResponseStatus) Children() []Node {
+	return []Node{}
+}
+
+//VariableResource is a persistent collection which stores information about a resource (i.e. a URL) of the application.
+// It has to be initialized with the setrsc or initcol action before it can be used.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#initcol
+type VariableResource struct {
+	AbstractNode
+}
+
+func (v *VariableResource) Name() string {
+	return "RESOURCE"
+}
+
+func (v *VariableResource) IsCollection() bool {
+	return true
+}
+
+func (v *VariableResource) Children() []Node {
+	return []Node{}
+}
+
+//VariableRule This is a special collection that provides access to the id, rev, severity, logdata, and msg fields of the rule that triggered the action.
+// It can be used to refer to only the same rule in which it resides.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#RULE
+type VariableRule struct {
+	AbstractNode
+}
+
+func (v *VariableRule) Name() string {
+	return "RULE"
+}
+
+func (v *VariableRule) IsCollection() bool {
+	return true
+}
+
+func (v *VariableRule) Children() []Node {
+	return []Node{}
+}
+
+//VariableScriptBasename This variable holds just the local filename part of SCRIPT_FILENAME.
+// Not available in proxy mode.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#SCRIPT_BASENAME
+type VariableScriptBasename struct {
+	AbstractNode
+}
+
+func (v *VariableScriptBasename) Name() string {
+	return "SCRIPT_BASENAME"
+}
+
+func (v *VariableScriptBasename) IsCollection() bool {
+	return false
+}
+
+func (v *VariableScriptBasename) Children() []Node {
+	return []Node{}
+}
+
+//Availability returns the limitations of the variable, this satisfies the RestrictedVariable interface
+func (v *VariableScriptBasename) Availability() VariableAvailability {
+	return VariableAvailability{
+		ApacheOnly:    true,
+		UnsupportedV3: true,
+	}
+}
+
+//VariableScriptFilename This variable holds the full internal path to the script that will be used to serve the request.
+// Not available in proxy mode.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#SCRIPT_FILENAME
+type VariableScriptFilename struct {
+	AbstractNode
+}
+
+func (v *VariableScriptFilename) Name() string {
+	return "SCRIPT_FILENAME"
+}
+
+func (v *VariableScriptFilename) IsCollection() bool {
+	return false
+}
+
+func (v *VariableScriptFilename) Children() []Node {
+	return []Node{}
+}
+
+//Availability returns the limitations of the variable, this satisfies the RestrictedVariable interface
+func (v *VariableScriptFilename) Availability() VariableAvailability {
+	return VariableAvailability{
+		ApacheOnly:    true,
+		UnsupportedV3: true,
+	}
+}
+
+//VariableScriptGID This variable holds the numerical identifier of the group owner of the script.
+// Not available in proxy mode.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#SCRIPT_GID
+type VariableScriptGID struct {
+	AbstractNode
+}
+
+func (v *VariableScriptGID) Name() string {
+	return "SCRIPT_GID"
+}
+
+func (v *VariableScriptGID) IsCollection() bool {
+	return false
+}
+
+func (v *VariableScriptGID) Children() []Node {
+	return []Node{}
+}
+
+//Availability returns the limitations of the variable, this satisfies the RestrictedVariable interface
+func (v *VariableScriptGID) Availability() VariableAvailability {
+	return VariableAvailability{
+		ApacheOnly:    true,
+		UnsupportedV3: true,
+	}
+}
+
+//VariableScriptGroupname This variable holds the name of the group owner of the script.
+// Not available in proxy mode.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#SCRIPT_GROUPNAME
+type VariableScriptGroupname struct {
+	AbstractNode
+}
+
+func (v *VariableScriptGroupname) Name() string {
+	return "SCRIPT_GROUPNAME"
+}
+
+func (v *VariableScriptGroupname) IsCollection() bool {
+	return false
+}
+
+func (v *VariableScriptGroupname) Children() []Node {
+	return []Node{}
+}
+
+//Availability returns the limitations of the variable, this satisfies the RestrictedVariable interface
+func (v *VariableScriptGroupname) Availability() VariableAvailability {
+	return VariableAvailability{
+		ApacheOnly:    true,
+		UnsupportedV3: true,
+	}
+}
+
+//VariableScriptMode This variable holds the script’s permissions mode data (e.g., 644).
+// Not available in proxy mode.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#SCRIPT_MODE
+type VariableScriptMode struct {
+	AbstractNode
+}
+
+func (v *VariableScriptMode) Name() string {
+	return "SCRIPT_MODE"
+}
+
+func (v *VariableScriptMode) IsCollection() bool {
+	return false
+}
+
+func (v *VariableScriptMode) Children() []Node {
+	return []Node{}
+}
+
+//Availability returns the limitations of the variable, this satisfies the RestrictedVariable interface
+func (v *VariableScriptMode) Availability() VariableAvailability {
+	return VariableAvailability{
+		ApacheOnly:    true,
+		UnsupportedV3: true,
+	}
+}
+
+//VariableScriptUID This variable holds the numerical identifier of the owner of the script.
+// Not available in proxy mode.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#SCRIPT_UID
+type VariableScriptUID struct {
+	AbstractNode
+}
+
+func (v *VariableScriptUID) Name() string {
+	return "SCRIPT_UID"
+}
+
+func (v *VariableScriptUID) IsCollection() bool {
+	return false
+}
+
+func (v *VariableScriptUID) Children() []Node {
+	return []Node{}
+}
+
+//Availability returns the limitations of the variable, this satisfies the RestrictedVariable interface
+func (v *VariableScriptUID) Availability() VariableAvailability {
+	return VariableAvailability{
+		ApacheOnly:    true,
+		UnsupportedV3: true,
+	}
+}
+
+//VariableScriptUsername This variable holds the username of the owner of the script.
+// Not available in proxy mode.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#SCRIPT_USERNAME
+type VariableScriptUsername struct {
+	AbstractNode
+}
+
+func (v *VariableScriptUsername) Name() string {
+	return "SCRIPT_USERNAME"
+}
+
+func (v *VariableScriptUsername) IsCollection() bool {
+	return false
+}
+
+func (v *VariableScriptUsername) Children() []Node {
+	return []Node{}
+}
+
+//Availability returns the limitations of the variable, this satisfies the RestrictedVariable interface
+func (v *VariableScriptUsername) Availability() VariableAvailability {
+	return VariableAvailability{
+		ApacheOnly:    true,
+		UnsupportedV3: true,
+	}
+}
+
+//VariableSdbmDeleteError This variable is set to 1 when APR fails to delete SDBM entries.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#SDBM_DELETE_ERROR
+type VariableSdbmDeleteError struct {
+	AbstractNode
+}
+
+func (v *VariableSdbmDeleteError) Name() string {
+	return "SDBM_DELETE_ERROR"
+}
+
+func (v *VariableSdbmDeleteError) IsCollection() bool {
+	return false
+}
+
+func (v *VariableSdbmDeleteError) Children() []Node {
+	return []Node{}
+}
+
+//Availability returns the limitations of the variable, this satisfies the RestrictedVariable interface
+func (v *VariableSdbmDeleteError) Availability() VariableAvailability {
+	return VariableAvailability{
+		UnsupportedV3: true,
+	}
+}
+
+//VariableServerAddress Contains the IP address of the server.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#SERVER_ADDR
+type VariableServerAddress struct {
+	AbstractNode
+}
+
+func (v *VariableServerAddress) Name() string {
+	return "SERVER_ADDR"
+}
+
+func (v *VariableServerAddress) IsCollection() bool {
+	return false
+}
+
+func (v *VariableServerAddress) Children() []Node {
+	return []Node{}
+}
+
+//VariableServerName Contains the transaction’s hostname or IP address, taken from the request itself (which means that, in principle, it should not be trusted).
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#SERVER_NAME
+type VariableServerName struct {
+	AbstractNode
+}
+
+func (v *VariableServerName) Name() string {
+	return "SERVER_NAME"
+}
+
+func (v *VariableServerName) IsCollection() bool {
+	return false
+}
+
+func (v *VariableServerName) Children() []Node {
+	return []Node{}
+}
+
+//VariableServerPort Contains the local port that the web server (or reverse proxy) is listening on.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#SERVER_PORT
+type VariableServerPort struct {
+	AbstractNode
+}
+
+func (v *VariableServerPort) Name() string {
+	return "SERVER_PORT"
+}
+
+func (v *VariableServerPort) IsCollection() bool {
+	return false
+}
+
+func (v *VariableServerPort) Children() []Node {
+	return []Node{}
+}
+
+//VariableSession This variable is a collection that contains session information. It becomes available only after setsid is executed.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#SESSION
+type VariableSession struct {
+	AbstractNode
+}
+
+func (v *VariableSession) Name() string {
+	return "SESSION"
+}
+
+func (v *VariableSession) IsCollection() bool {
+	return true
+}
+
+func (v *VariableSession) Children() []Node {
+	return []Node{}
+}
+
+//VariableSessionID This variable contains the value set with setsid.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#SESSIONID
+type VariableSessionID struct {
+	AbstractNode
+}
+
+func (v *VariableSessionID) Name() string {
+	return "SESSIONID"
+}
+
+func (v *VariableSessionID) IsCollection() bool {
+	return false
+}
+
+func (v *VariableSessionID) Children() []Node {
+	return []Node{}
+}
+
+//VariableStatusLine This variable holds the full status line sent by the server (including the request method and HTTP version information).
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#STATUS_LINE
+type VariableStatusLine struct {
+	AbstractNode
+}
+
+func (v *VariableStatusLine) Name() string {
+	return "STATUS_LINE"
+}
+
+func (v *VariableStatusLine) IsCollection() bool {
+	return false
+}
+
+func (v *VariableStatusLine) Children() []Node {
+	return []Node{}
+}
+
+//VariableStreamInputBody This variable give access to the raw request body content. This variable is best used for two use-cases:
+// inspection of the unmodified request body and modification of the request body (using @rsub operator or a Lua script).
+// Only available if SecStreamInBodyInspection is On, starting with phase 2.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#STREAM_INPUT_BODY
+type VariableStreamInputBody struct {
+	AbstractNode
+}
+
+func (v *VariableStreamInputBody) Name() string {
+	return "STREAM_INPUT_BODY"
+}
+
+func (v *VariableStreamInputBody) IsCollection() bool {
+	return false
+}
+
+func (v *VariableStreamInputBody) Children() []Node {
+	return []Node{}
+}
+
+//VariableStreamOutputBody This variable give access to the raw response body content.
+// This variable is best used for two use-cases: inspection of the unmodified response body and modification of the response body
+// (using @rsub operator or a Lua script). Only available if SecStreamOutBodyInspection is On.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#STREAM_OUTPUT_BODY
+type VariableStreamOutputBody struct {
+	AbstractNode
+}
+
+func (v *VariableStreamOutputBody) Name() string {
+	return "STREAM_OUTPUT_BODY"
+}
+
+func (v *VariableStreamOutputBody) IsCollection() bool {
+	return false
+}
+
+func (v *VariableStreamOutputBody) Children() []Node {
+	return []Node{}
+}
+
+//VariableTime This variable holds a formatted string representing the time (hour:minute:second).
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#TIME
+type VariableTime struct {
+	AbstractNode
+}
+
+func (v *VariableTime) Name() string {
+	return "TIME"
+}
+
+func (v *VariableTime) IsCollection() bool {
+	return false
+}
+
+func (v *VariableTime) Children() []Node {
+	return []Node{}
+}
+
+//VariableTimeDay This variable holds the current date (1–31).
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#TIME_DAY
+type VariableTimeDay struct {
+	AbstractNode
+}
+
+func (v *VariableTimeDay) Name() string {
+	return "TIME_DAY"
+}
+
+func (v *VariableTimeDay) IsCollection() bool {
+	return false
+}
+
+func (v *VariableTimeDay) Children() []Node {
+	return []Node{}
+}
+
+//VariableTimeEpoch This variable holds the time in seconds since 1970.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#TIME_EPOCH
+type VariableTimeEpoch struct {
+	AbstractNode
+}
+
+func (v *VariableTimeEpoch) Name() string {
+	return "TIME_EPOCH"
+}
+
+func (v *VariableTimeEpoch) IsCollection() bool {
+	return false
+}
+
+func (v *VariableTimeEpoch) Children() []Node {
+	return []Node{}
+}
+
+//VariableTimeHour This variable holds the current hour value (0–23).
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#TIME_HOUR
+type VariableTimeHour struct {
+	AbstractNode
+}
+
+func (v *VariableTimeHour) Name() string {
+	return "TIME_HOUR"
+}
+
+func (v *VariableTimeHour) IsCollection() bool {
+	return false
+}
+
+func (v *VariableTimeHour) Children() []Node {
+	return []Node{}
+}
+
+//VariableTimeMin This variable holds the current minute value (0–59).
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#TIME_MIN
+type VariableTimeMin struct {
+	AbstractNode
+}
+
+func (v *VariableTimeMin) Name() string {
+	return "TIME_MIN"
+}
+
+func (v *VariableTimeMin) IsCollection() bool {
+	return false
+}
+
+func (v *VariableTimeMin) Children() []Node {
+	return []Node{}
+}
+
+//VariableTimeMon This variable holds the current month value (0–11).
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#TIME_MON
+type VariableTimeMon struct {
+	AbstractNode
+}
+
+func (v *VariableTimeMon) Name() string {
+	return "TIME_MON"
+}
+
+func (v *VariableTimeMon) IsCollection() bool {
+	return false
+}
+
+func (v *VariableTimeMon) Children() []Node {
+	return []Node{}
+}
+
+//VariableTimeSec This variable holds the current second value (0–59).
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#TIME_SEC
+type VariableTimeSec struct {
+	AbstractNode
+}
+
+func (v *VariableTimeSec) Name() string {
+	return "TIME_SEC"
+}
+
+func (v *VariableTimeSec) IsCollection() bool {
+	return false
+}
+
+func (v *VariableTimeSec) Children() []Node {
+	return []Node{}
+}
+
+//VariableTimeWDay This variable holds the current weekday value (0–6), where Sunday is 0.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#TIME_WDAY
+type VariableTimeWDay struct {
+	AbstractNode
+}
+
+func (v *VariableTimeWDay) Name() string {
+	return "TIME_WDAY"
+}
+
+func (v *VariableTimeWDay) IsCollection() bool {
+	return false
+}
+
+func (v *VariableTimeWDay) Children() []Node {
+	return []Node{}
+}
+
+//VariableTimeYear This variable holds the current four-digit year value.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#TIME_YEAR
+type VariableTimeYear struct {
+	AbstractNode
+}
+
+func (v *VariableTimeYear) Name() string {
+	return "TIME_YEAR"
+}
+
+func (v *VariableTimeYear) IsCollection() bool {
+	return false
+}
+
+func (v *VariableTimeYear) Children() []Node {
+	return []Node{}
+}
+
+//VariableTransientTransactionCollection This is the transient transaction collection,
+// which is used to store pieces of data, create a transaction anomaly score, and so on.
+// The variables placed into this collection are available only until the transaction is complete.
+//
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#tx
+type VariableTransientTransactionCollection struct {
+	AbstractNode
+}
+
+func (v *VariableTransientTransactionCollection) Name() string {
+	return "TX"
+}
+
+func (v *VariableTransientTransactionCollection) IsCollection() bool {
+	return true
+}
+
+func (v *VariableTransientTransactionCollection) Children() []Node {
+	return []Node{}
+}
+
+//VariableUniqueID This variable holds the data created by mod_unique_id.
+// The UNIQUE_ID environment variable is constructed by encoding the 112-bit (32-bit IP address, 32 bit pid, 32 bit time stamp, 16 bit counter) quadruple using the alphabet [A-Za-z0-9@-] in a manner similar to MIME base64 encoding, producing 19 characters.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#UNIQUE_ID
+type VariableUniqueID struct {
+	AbstractNode
+}
+
+func (v *VariableUniqueID) Name() string {
+	return "UNIQUE_ID"
+}
+
+func (v *VariableUniqueID) IsCollection() bool {
+	return false
+}
+
+func (v *VariableUniqueID) Children() []Node {
+	return []Node{}
+}
+
+//VariableURLEncodedError This variable is created when an invalid URL encoding is encountered during the parsing of a query string (on every request)
+// or during the parsing of an application/x-www-form-urlencoded request body (only on the requests that use the URLENCODED request body processor).
+// It is available starting with phase 1 for the query string and phase 2 for the request body.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#URLENCODED_ERROR
+type VariableURLEncodedError struct {
+	AbstractNode
+}
+
+func (v *VariableURLEncodedError) Name() string {
+	return "URLENCODED_ERROR"
+}
+
+func (v *VariableURLEncodedError) IsCollection() bool {
+	return false
+}
+
+func (v *VariableURLEncodedError) Children() []Node {
+	return []Node{}
+}
+
+//VariableUser This variable is a collection that contains user information. It becomes available only after setuid is executed.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#USER
+type VariableUser struct {
+	AbstractNode
+}
+
+func (v *VariableUser) Name() string {
+	return "USER"
+}
+
+func (v *VariableUser) IsCollection() bool {
+	return true
+}
+
+func (v *VariableUser) Children() []Node {
+	return []Node{}
+}
+
+//VariableUserAgentIP This variable is created when running modsecurity with apache2.4 and will contains the client ip address set by mod_remoteip in proxied connections.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#USERAGENT_IP
+type VariableUserAgentIP struct {
+	AbstractNode
+}
+
+func (v *VariableUserAgentIP) Name() string {
+	return "USERAGENT_IP"
+}
+
+func (v *VariableUserAgentIP) IsCollection() bool {
+	return false
+}
+
+func (v *VariableUserAgentIP) Children() []Node {
+	return []Node{}
+}
+
+//VariableUserID This variable contains the value set with setuid.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#USERID
+type VariableUserID struct {
+	AbstractNode
+}
+
+func (v *VariableUserID) Name() string {
+	return "USERID"
+}
+
+func (v *VariableUserID) IsCollection() bool {
+	return false
+}
+
+func (v *VariableUserID) Children() []Node {
+	return []Node{}
+}
+
+//VariableWebAppID This variable contains the current application name, which is set in configuration using SecWebAppId.
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#WEBAPPID
+type VariableWebAppID struct {
+	AbstractNode
+}
+
+func (v *VariableWebAppID) Name() string {
+	return "WEBAPPID"
+}
+
+func (v *VariableWebAppID) IsCollection() bool {
+	return false
+}
+
+func (v *VariableWebAppID) Children() []Node {
+	return []Node{}
+}
+
+//VariableWebserverErrorLog Contains zero or more error messages produced by the web server.
+// This variable is best accessed from phase 5 (logging).
+//https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#WEBSERVER_ERROR_LOG
+type VariableWebserverErrorLog struct {
+	AbstractNode
+}
+
+func (v *VariableWebserverErrorLog) Name() string {
+	return "WEBSERVER_ERROR_LOG"
+}
+
+func (v *VariableWebserverErrorLog) IsCollection() bool {
+	return true
+}
+
+func (v *VariableWebserverErrorLog) Children() []Node {
+	return []Node{}
+}
+
+//Availability returns the limitations of the variable, this satisfies the RestrictedVariable interface
+func (v *VariableWebserverErrorLog) Availability() VariableAvailability {
+	return VariableAvailability{
+		ApacheOnly:    true,
+		UnsupportedV3: true,
+	}
+}
+
 //VariableXML Special collection used to interact with the XML parser.
 // It can be used standalone as a target for the validateDTD and validateSchema operator.
 // Otherwise, it must contain a valid XPath expression, which will then be evaluated against a previously parsed XML DOM tree.
@@ -0,0 +1,17 @@
+//Package clock abstracts the current time, so time dependent behaviour like collection expiry can be tested
+package clock
+
+import "time"
+
+//Clock supplies the current time
+type Clock interface {
+	Now() time.Time
+}
+
+//System is the clock of the operating system
+type System struct{}
+
+//Now returns time.Now()
+func (System) Now() time.Time {
+	return time.Now()
+}
@@ -0,0 +1,42 @@
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+//Fake is a clock which only changes when it is set or advanced, so time dependent behaviour can be tested step by step.
+// It is safe for concurrent use.
+type Fake struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+//NewFake creates a fake clock which is set to now
+func NewFake(now time.Time) *Fake {
+	return &Fake{now: now}
+}
+
+//Now returns the time to which the clock was set
+func (f *Fake) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.now
+}
+
+//Set sets the clock to now
+func (f *Fake) Set(now time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.now = now
+}
+
+//Advance moves the clock forward by d
+func (f *Fake) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.now = f.now.Add(d)
+}
@@ -0,0 +1,26 @@
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFake(t *testing.T) {
+	start := time.Unix(1000, 0)
+	fake := NewFake(start)
+
+	if !fake.Now().Equal(start) {
+		t.Errorf("got %s, want %s", fake.Now(), start)
+	}
+
+	fake.Advance(90 * time.Second)
+	if want := start.Add(90 * time.Second); !fake.Now().Equal(want) {
+		t.Errorf("got %s after Advance, want %s", fake.Now(), want)
+	}
+
+	later := time.Unix(5000, 0)
+	fake.Set(later)
+	if !fake.Now().Equal(later) {
+		t.Errorf("got %s after Set, want %s", fake.Now(), later)
+	}
+}
@@ -0,0 +1,103 @@
+//Package collection contains the key value collections which hold the values of ModSecurity variables during a transaction
+package collection
+
+import "strings"
+
+//Entry is a single key value pair in a collection
+type Entry struct {
+	Key   string
+	Value string
+}
+
+//Collection is an ordered list of key value pairs, like ModSecurity collections keys are case insensitive and a key can occur more than once.
+// Variables which are not a collection, like REQUEST_URI, are stored as a collection with a single entry with an empty key.
+// A collection is not safe for concurrent use.
+type Collection struct {
+	entries []Entry
+}
+
+//New creates a new empty collection
+func New() *Collection {
+	return &Collection{}
+}
+
+//Add adds a value to the collection, existing values with the same key are kept
+func (c *Collection) Add(key, value string) {
+	c.entries = append(c.entries, Entry{Key: key, Value: value})
+}
+
+//Set replaces all values of the key with a single value
+func (c *Collection) Set(key, value string) {
+	for i := range c.entries {
+		if strings.EqualFold(c.entries[i].Key, key) {
+			c.entries[i].Value = value
+			c.deleteFrom(i+1, key)
+			return
+		}
+	}
+
+	c.Add(key, value)
+}
+
+//Get returns all values of the key, in the order they were added
+func (c *Collection) Get(key string) []string {
+	values := []string{}
+	for _, entry := range c.entries {
+		if strings.EqualFold(entry.Key, key) {
+			values = append(values, entry.Value)
+		}
+	}
+
+	return values
+}
+
+//First returns the first value of the key, false is returned if the key doesn't exist
+func (c *Collection) First(key string) (string, bool) {
+	for _, entry := range c.entries {
+		if strings.EqualFold(entry.Key, key) {
+			return entry.Value, true
+		}
+	}
+
+	return "", false
+}
+
+//Has returns true if the key exists
+func (c *Collection) Has(key string) bool {
+	_, found := c.First(key)
+	return found
+}
+
+//Delete removes all values of the key
+func (c *Collection) Delete(key string) {
+	c.deleteFrom(0, key)
+}
+
+func (c *Collection) deleteFrom(start int, key string) {
+	entries := c.entries[:start]
+	for _, entry := range c.entries[start:] {
+		if !strings.EqualFold(entry.Key, key) {
+			entries = append(entries, entry)
+		}
+	}
+
+	c.entries = entries
+}
+
+//Clear removes all entries
+func (c *Collection) Clear() {
+	c.entries = nil
+}
+
+//Entries returns a copy of all entries, in the order they were added
+func (c *Collection) Entries() []Entry {
+	entries := make([]Entry, len(c.entries))
+	copy(entries, c.entries)
+
+	return entries
+}
+
+//Len returns the amount of entries
+func (c *Collection) Len() int {
+	return len(c.entries)
+}
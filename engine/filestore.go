@@ -0,0 +1,90 @@
+package engine
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+//fileBackend stores every record as JSON file in a directory per collection
+type fileBackend struct {
+	dir string
+}
+
+//NewFileStore creates a collection store which keeps records in files under dir, which should be the value of SecDataDir.
+// Updates are atomic within the process, the files should not be shared between processes.
+func NewFileStore(dir string, opts ...StoreOption) (CollectionStore, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to use data dir '%s': %w", dir, err)
+	}
+
+	if !info.IsDir() {
+		return nil, fmt.Errorf("Unable to use data dir '%s': not a directory", dir)
+	}
+
+	return newStore(&fileBackend{dir: dir}, opts), nil
+}
+
+//path returns the file of the record, the key is hashed since it can contain any character
+func (fb *fileBackend) path(coll, key string) string {
+	//Collection names come from rules, characters which could escape the data dir are replaced
+	dir := strings.Map(func(r rune) rune {
+		if r == '_' || r >= 'A' && r <= 'Z' || r >= 'a' && r <= 'z' || r >= '0' && r <= '9' {
+			return r
+		}
+
+		return '_'
+	}, coll)
+
+	hash := sha256.Sum256([]byte(key))
+	return filepath.Join(fb.dir, dir, hex.EncodeToString(hash[:])+".json")
+}
+
+func (fb *fileBackend) load(coll, key string) (*record, error) {
+	data, err := os.ReadFile(fb.path(coll, key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("Unable to read collection '%s': %w", coll, err)
+	}
+
+	r := &record{}
+	if err := json.Unmarshal(data, r); err != nil {
+		return nil, fmt.Errorf("Unable to decode collection '%s': %w", coll, err)
+	}
+
+	return r, nil
+}
+
+func (fb *fileBackend) save(coll, key string, r *record) error {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("Unable to encode collection '%s': %w", coll, err)
+	}
+
+	path := fb.path(coll, key)
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("Unable to write collection '%s': %w", coll, err)
+	}
+
+	//Write to a temporary file first, so a crash can't leave a partially written record
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("Unable to write collection '%s': %w", coll, err)
+	}
+
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("Unable to write collection '%s': %w", coll, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,116 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/dylandreimerink/go-modsec-parser/lookup"
+	"github.com/dylandreimerink/go-modsec-parser/parser"
+)
+
+const geoBlockingRules = `
+SecRule REMOTE_ADDR "@geoLookup" "id:1,phase:1,deny,status:451,chain"
+SecRule GEO:COUNTRY_CODE "@streq GB" "t:none"
+`
+
+func TestGeoLookup(t *testing.T) {
+	tests := []struct {
+		name       string
+		conf       string
+		opts       func(t *testing.T) []Option
+		remoteAddr string
+
+		wantCountry string
+		wantStatus  int
+	}{
+		{
+			name:        "SecGeoLookupDb blocked",
+			conf:        "SecGeoLookupDb ../testdata/geo.mmdb\n" + geoBlockingRules,
+			remoteAddr:  "81.2.69.142",
+			wantCountry: "GB",
+			wantStatus:  451,
+		},
+		{
+			name:        "SecGeoLookupDb allowed",
+			conf:        "SecGeoLookupDb ../testdata/geo.mmdb\n" + geoBlockingRules,
+			remoteAddr:  "89.160.20.1",
+			wantCountry: "SE",
+		},
+		{
+			name:       "not found",
+			conf:       "SecGeoLookupDb ../testdata/geo.mmdb\n" + geoBlockingRules,
+			remoteAddr: "127.0.0.1",
+		},
+		{
+			name: "WithGeoLookup",
+			conf: "SecGeoLookupDb /does/not/exist.mmdb\n" + geoBlockingRules,
+			opts: func(t *testing.T) []Option {
+				geo, err := lookup.OpenGeoDB("../testdata/geo.mmdb")
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+
+				return []Option{WithGeoLookup(geo)}
+			},
+			remoteAddr:  "81.2.69.142",
+			wantCountry: "GB",
+			wantStatus:  451,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			doc, err := parser.Parse("geo.conf", test.conf)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var opts []Option
+			if test.opts != nil {
+				opts = test.opts(t)
+			}
+
+			rs, err := NewRuleset(doc, opts...)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			tx := rs.NewTransaction()
+			tx.SetVariable("REMOTE_ADDR", test.remoteAddr)
+			iv := tx.ProcessPhase(PhaseRequestHeaders)
+
+			country, _ := tx.Collection("GEO").First("COUNTRY_CODE")
+			if country != test.wantCountry {
+				t.Errorf("got GEO:COUNTRY_CODE '%s', want '%s'", country, test.wantCountry)
+			}
+
+			status := 0
+			if iv != nil {
+				status = iv.Status
+			}
+
+			if status != test.wantStatus {
+				t.Errorf("got status %d, want %d", status, test.wantStatus)
+			}
+		})
+	}
+}
+
+func TestGeoLookupErrors(t *testing.T) {
+	doc, err := parser.Parse("geo.conf", "SecGeoLookupDb /does/not/exist.mmdb\n")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := NewRuleset(doc); err == nil {
+		t.Error("expected an error for a missing database")
+	}
+
+	doc, err = parser.Parse("geo.conf", geoBlockingRules)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := NewRuleset(doc); err == nil {
+		t.Error("expected an error for geoLookup without a database")
+	}
+}
@@ -0,0 +1,113 @@
+package engine
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dylandreimerink/go-modsec-parser/ast"
+)
+
+//Intervention is the disruptive action which was triggered by a rule, modelled on the intervention of libmodsecurity.
+// The allow action doesn't cause an intervention since the transaction is allowed to proceed.
+type Intervention struct {
+	//The HTTP status code to respond with, 403 unless changed with the status action.
+	// For redirect it is 302, or the value of the status action if it is 301, 302, 303 or 307. For proxy it is 0.
+	Status int
+
+	//The location for redirect or the backend URL for proxy, empty for other actions
+	URL string
+
+	//The name of the disruptive action: "deny", "drop", "redirect" or "proxy"
+	Action string
+
+	//The id of the rule which triggered the intervention
+	RuleID int
+
+	//The log message, which contains the expanded msg and logdata of the rule
+	Log string
+
+	//The duration the server should wait before executing the action, set with the pause action
+	Pause time.Duration
+}
+
+//newIntervention creates the intervention for the disruptive action of a matched rule
+func newIntervention(r *rule, matched *MatchedRule, url string) *Intervention {
+	iv := &Intervention{
+		Status: 403,
+		URL:    url,
+		Action: r.disruptive.Name(),
+		RuleID: r.id,
+		Pause:  time.Duration(r.pause) * time.Millisecond,
+	}
+
+	if r.status != 0 {
+		iv.Status = r.status
+	}
+
+	var log strings.Builder
+	switch r.disruptive.(type) {
+	case *ast.ActionDrop:
+		log.WriteString("Access denied with connection close")
+	case *ast.ActionRedirect:
+		switch r.status {
+		case 301, 302, 303, 307:
+		default:
+			iv.Status = 302
+		}
+
+		fmt.Fprintf(&log, "Access denied with redirection to %s using status %d", url, iv.Status)
+	case *ast.ActionProxy:
+		iv.Status = 0
+		fmt.Fprintf(&log, "Access denied using proxy to %s", url)
+	default:
+		fmt.Fprintf(&log, "Access denied with code %d", iv.Status)
+	}
+
+	fmt.Fprintf(&log, " (phase %d).", matched.Phase)
+
+	if matched.Message != "" {
+		fmt.Fprintf(&log, " [msg %q]", matched.Message)
+	}
+
+	if matched.LogData != "" {
+		fmt.Fprintf(&log, " [data %q]", matched.LogData)
+	}
+
+	fmt.Fprintf(&log, " [id \"%d\"]", r.id)
+
+	iv.Log = log.String()
+
+	return iv
+}
+
+//MatchedRule is a rule or chain which matched during a transaction
+type MatchedRule struct {
+	//The id of the rule, 0 if the rule has no id
+	ID int
+
+	//The phase in which the rule matched
+	Phase int
+
+	//The directive of the rule, for a chain this is the first rule of the chain
+	Rule ast.Directive
+
+	//The expanded msg and logdata actions of the rule
+	Message string
+	LogData string
+
+	//The value of the severity action, 0 if the rule has no severity
+	Severity int
+
+	//The values which matched, of all rules in the chain
+	MatchedVars []MatchedVar
+}
+
+//MatchedVar is a variable value which matched the operator of a rule
+type MatchedVar struct {
+	//The name of the variable, i.e. "ARGS:id" or "REQUEST_URI"
+	Name string
+
+	//The value after transformations
+	Value string
+}
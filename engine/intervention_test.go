@@ -0,0 +1,75 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dylandreimerink/go-modsec-parser/parser"
+)
+
+func TestBlockDefaultAction(t *testing.T) {
+	tests := []struct {
+		name string
+		conf string
+
+		wantAction string
+		wantStatus int
+		wantPause  time.Duration
+	}{
+		{
+			name:       "deny and pause",
+			conf:       "SecDefaultAction \"phase:2,deny,pause:100\"\n",
+			wantAction: "deny",
+			wantStatus: 403,
+			wantPause:  100 * time.Millisecond,
+		},
+		{
+			name:       "pause before deny",
+			conf:       "SecDefaultAction \"phase:2,pause:100,deny,status:429\"\n",
+			wantAction: "deny",
+			wantStatus: 429,
+			wantPause:  100 * time.Millisecond,
+		},
+		{
+			name: "pause without disruptive action",
+			conf: "SecDefaultAction \"phase:2,pass,pause:100\"\n",
+		},
+		{
+			name: "no default action",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			doc, err := parser.Parse("block.conf", test.conf+`SecRule ARGS:q "@streq attack" "id:1,phase:2,block"`)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			rs, err := NewRuleset(doc)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			tx := rs.NewTransaction()
+			tx.Collection("ARGS").Add("q", "attack")
+			tx.ProcessPhase(PhaseRequestHeaders)
+			iv := tx.ProcessPhase(PhaseRequestBody)
+
+			if test.wantAction == "" {
+				if iv != nil {
+					t.Fatalf("got intervention %+v, want none", iv)
+				}
+				return
+			}
+
+			if iv == nil {
+				t.Fatal("expected an intervention")
+			}
+
+			if iv.Action != test.wantAction || iv.Status != test.wantStatus || iv.Pause != test.wantPause || iv.RuleID != 1 {
+				t.Errorf("got intervention %+v, want %s with status %d and pause %s", iv, test.wantAction, test.wantStatus, test.wantPause)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package engine
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/dylandreimerink/go-modsec-parser/lint"
+	"github.com/dylandreimerink/go-modsec-parser/operator"
+	"github.com/dylandreimerink/go-modsec-parser/parser"
+)
+
+const lookaroundRules = `
+SecRule ARGS:q "@rx foo(?=bar)" "id:1,phase:1,deny,status:403"
+SecRule ARGS:q "@rx ^admin$" "id:2,phase:1,deny,status:401"
+`
+
+func TestUnsupportedRegexSkipped(t *testing.T) {
+	doc, err := parser.Parse("regex.conf", lookaroundRules)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	//Without diagnostics the rule is skipped instead of failing the ruleset
+	rs, err := NewRuleset(doc)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if iv := process(rs, "foobar"); iv != nil {
+		t.Errorf("got status %d for a skipped rule", iv.Status)
+	}
+
+	if iv := process(rs, "admin"); iv == nil || iv.Status != 401 {
+		t.Errorf("got %v, want status 401", iv)
+	}
+
+	var diagnostics []lint.Diagnostic
+	_, err = NewRuleset(doc, WithDiagnostics(func(d lint.Diagnostic) {
+		diagnostics = append(diagnostics, d)
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(diagnostics) != 1 || !strings.Contains(diagnostics[0].Message, "Unsupported regular expression 'foo(?=bar)'") {
+		t.Errorf("unexpected diagnostics %v", diagnostics)
+	}
+}
+
+func TestRegexFallback(t *testing.T) {
+	doc, err := parser.Parse("regex.conf", lookaroundRules)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	rs, err := NewRuleset(doc, WithRegexFallback(func(pattern string) (operator.Regexp, error) {
+		//Stand-in for a PCRE engine, which only knows the lookahead of this test
+		return regexp.MustCompile(`foo(bar)`), nil
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if iv := process(rs, "foobar"); iv == nil || iv.Status != 403 {
+		t.Errorf("got %v, want status 403", iv)
+	}
+}
+
+func process(rs *Ruleset, q string) *Intervention {
+	tx := rs.NewTransaction()
+	tx.Collection("ARGS").Add("q", q)
+
+	return tx.ProcessPhase(PhaseRequestHeaders)
+}
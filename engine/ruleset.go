@@ -0,0 +1,550 @@
+//Package engine evaluates parsed rulesets against transactions, phase by phase like ModSecurity does
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/dylandreimerink/go-modsec-parser/ast"
+	"github.com/dylandreimerink/go-modsec-parser/clock"
+	"github.com/dylandreimerink/go-modsec-parser/lint"
+	"github.com/dylandreimerink/go-modsec-parser/lookup"
+	"github.com/dylandreimerink/go-modsec-parser/macro"
+	"github.com/dylandreimerink/go-modsec-parser/operator"
+	"github.com/dylandreimerink/go-modsec-parser/transform"
+)
+
+//The phases in which rules are evaluated
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#Processing_Phases
+const (
+	PhaseRequestHeaders  = 1
+	PhaseRequestBody     = 2
+	PhaseResponseHeaders = 3
+	PhaseResponseBody    = 4
+	PhaseLogging         = 5
+)
+
+//defaultPhase is the phase of rules without phase action
+const defaultPhase = PhaseRequestBody
+
+//Ruleset is the compiled form of a parsed document. A ruleset is immutable, so it can be used by multiple transactions concurrently.
+type Ruleset struct {
+	//The value of the last SecRuleEngine directive. Unlike ModSecurity, which is off by default,
+	// the rule engine is on if the document doesn't contain a SecRuleEngine directive.
+	engine ast.SecRuleEngineValue
+
+	//The rules and markers of every phase in order of definition, indexed by phase number
+	phases [PhaseLogging + 1][]*rule
+
+	//The index of the rules and markers in a phase by skipAfter target, which is a rule id or marker name
+	skipTargets [PhaseLogging + 1]map[string]int
+
+	//The store of the collections initialized with initcol, nil if they only exist during a transaction
+	store CollectionStore
+
+	//The value of SecCollectionTimeout
+	collectionTimeout time.Duration
+
+	//The clock of the TIME variables and DURATION
+	clock clock.Clock
+}
+
+//rule is a compiled SecRule or SecAction. A chain is a list of rules linked by the chain field,
+// the id, phase, disruptive action and flow actions of the chain are taken from the first rule.
+type rule struct {
+	directive ast.Directive
+
+	id    int
+	phase int
+	tags  []string
+
+	//The name of the SecMarker, if set the rule is a marker and not evaluated
+	marker string
+
+	targets    []*target
+	exclusions []*target
+
+	//The compiled operator, nil for SecAction which always matches
+	matcher  operator.Matcher
+	pipeline transform.Pipeline
+
+	capture    bool
+	multiMatch bool
+
+	//The non-disruptive actions which are executed for every match
+	actions []ast.Action
+
+	//The disruptive action of the rule, block is replaced by the disruptive action of SecDefaultAction
+	disruptive ast.Action
+	status     int
+	pause      int
+	skipAfter  string
+	message    *ast.ExpandableString
+	logData    *ast.ExpandableString
+	severity   int
+
+	//The next rule of the chain
+	chain *rule
+}
+
+//target is a compiled variable selector
+type target struct {
+	//The upper case name of the variable
+	name string
+
+	//Select a single key of a collection
+	key string
+
+	//Select all keys of a collection which match the regex
+	keyRegex *regexp.Regexp
+
+	//Count the selected values instead of inspecting them
+	count bool
+}
+
+//Option changes the way a ruleset is compiled
+type Option func(*compiler)
+
+type compiler struct {
+	operatorOptions []operator.Option
+	diagnostics     func(lint.Diagnostic)
+	store           CollectionStore
+	clock           clock.Clock
+
+	//True if the geo database is set with WithGeoLookup, in which case SecGeoLookupDb is ignored
+	geoLookup bool
+
+	//The actions of the last SecDefaultAction of every phase, which are inherited by the rules which follow it
+	defaults [PhaseLogging + 1][]ast.Action
+}
+
+//WithDataFS sets the file system from which data files, like the ones of the pmFromFile operator, are read
+func WithDataFS(fsys fs.FS) Option {
+	return func(c *compiler) {
+		c.operatorOptions = append(c.operatorOptions, operator.WithDataFS(fsys))
+	}
+}
+
+//WithDiagnostics makes the compiler skip rules which can't be compiled, for example rules with an invalid phase.
+// Skipped rules are reported to the function as warning, without this option NewRuleset returns an error instead.
+// Rules which use an operator, transformation or regular expression which isn't supported are always skipped,
+// so a ruleset like CRS can be loaded, this option reports them.
+func WithDiagnostics(report func(lint.Diagnostic)) Option {
+	return func(c *compiler) {
+		c.diagnostics = report
+	}
+}
+
+//WithGeoLookup sets the database which is used by the geoLookup operator to fill the GEO collection.
+// Without this option the database is opened from the path of the SecGeoLookupDb directive.
+func WithGeoLookup(geo operator.GeoLookup) Option {
+	return func(c *compiler) {
+		c.geoLookup = true
+		c.operatorOptions = append(c.operatorOptions, operator.WithGeoLookup(geo))
+	}
+}
+
+//WithRegexFallback sets the compiler which is used for rx patterns that the Go regexp package doesn't support, like
+// lookarounds and backreferences. Without this option rules with such a pattern are skipped, see operator.WithRegexFallback.
+func WithRegexFallback(compile operator.RegexCompiler) Option {
+	return func(c *compiler) {
+		c.operatorOptions = append(c.operatorOptions, operator.WithRegexFallback(compile))
+	}
+}
+
+//WithCollectionStore sets the store in which collections initialized with initcol are persisted across transactions.
+// Without a store these collections only exist during the transaction.
+func WithCollectionStore(store CollectionStore) Option {
+	return func(c *compiler) {
+		c.store = store
+	}
+}
+
+//WithClock sets the clock which is used for the TIME variables and DURATION, the system clock is used by default.
+// The clock of a collection store is set with WithStoreClock.
+func WithClock(c clock.Clock) Option {
+	return func(comp *compiler) {
+		comp.clock = c
+	}
+}
+
+//NewRuleset compiles the rules in the document
+func NewRuleset(doc *ast.Document, opts ...Option) (*Ruleset, error) {
+	c := &compiler{
+		clock: clock.System{},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	rs := &Ruleset{
+		engine:            ast.ModsecOn,
+		store:             c.store,
+		collectionTimeout: defaultCollectionTimeout * time.Second,
+		clock:             c.clock,
+	}
+
+	for phase := range rs.skipTargets {
+		rs.skipTargets[phase] = map[string]int{}
+	}
+
+	var (
+		//The rules of the chain which is being compiled
+		chain []*rule
+
+		//The directive of the first rule of the chain
+		chainStart ast.Directive
+
+		//The phase of the first rule of the chain, which determines the default actions of all rules in the chain
+		chainPhase int
+
+		//True if one of the rules of the chain can't be compiled, in which case the whole chain is skipped
+		chainFailed bool
+	)
+
+	for _, dir := range doc.Directives() {
+		switch dir := dir.(type) {
+		case *ast.DirectiveSecRuleEngine:
+			rs.engine = dir.Value
+
+		case *ast.DirectiveSecCollectionTimeout:
+			rs.collectionTimeout = time.Duration(dir.Value) * time.Second
+
+		case *ast.DirectiveSecGeoLookupDb:
+			if err := c.openGeoDB(dir); err != nil {
+				return nil, err
+			}
+
+		case *ast.DirectiveSecDefaultAction:
+			if err := c.setDefaults(dir); err != nil {
+				return nil, err
+			}
+
+		case *ast.DirectiveSecMarker:
+			for phase := PhaseRequestHeaders; phase <= PhaseLogging; phase++ {
+				rs.skipTargets[phase][dir.Value] = len(rs.phases[phase])
+				rs.phases[phase] = append(rs.phases[phase], &rule{directive: dir, marker: dir.Value})
+			}
+
+		case *ast.DirectiveSecRule, *ast.DirectiveSecAction:
+			if len(chain) == 0 {
+				chainStart = dir
+				chainPhase = rulePhase(dir)
+			}
+
+			r, err := c.compileRule(dir, chainPhase)
+			if err != nil {
+				//Unsupported features only skip the rule, so a single rule doesn't fail the whole ruleset
+				unsupported := errors.Is(err, operator.ErrUnsupported) || errors.Is(err, transform.ErrUnsupported)
+				if c.diagnostics == nil && !unsupported {
+					return nil, err
+				}
+
+				if c.diagnostics != nil {
+					c.diagnostics(lint.Diagnostic{
+						Severity: lint.SeverityWarning,
+						Node:     dir,
+						Message:  err.Error() + ", the rule is skipped",
+					})
+				}
+
+				chainFailed = true
+			}
+
+			chain = append(chain, r)
+			if isChained(dir) {
+				continue
+			}
+
+			if !chainFailed {
+				rs.add(chain)
+			}
+
+			chain, chainFailed = nil, false
+		}
+	}
+
+	//Like ModSecurity a chain without a following rule is an error, the incomplete chain is never added
+	if len(chain) > 0 {
+		err := fmt.Errorf("The chain of %s has no following rule", describe(&rule{directive: chainStart, id: ruleID(chainStart)}))
+		if c.diagnostics == nil {
+			return nil, err
+		}
+
+		c.diagnostics(lint.Diagnostic{
+			Severity: lint.SeverityWarning,
+			Node:     chainStart,
+			Message:  err.Error() + ", the rule is skipped",
+		})
+	}
+
+	return rs, nil
+}
+
+//add links the rules of the chain and adds the first rule to its phase
+func (rs *Ruleset) add(chain []*rule) {
+	for i := 1; i < len(chain); i++ {
+		chain[i-1].chain = chain[i]
+	}
+
+	r := chain[0]
+	if r.id != 0 {
+		rs.skipTargets[r.phase][strconv.Itoa(r.id)] = len(rs.phases[r.phase])
+	}
+
+	rs.phases[r.phase] = append(rs.phases[r.phase], r)
+}
+
+//isChained returns true if the directive has the chain action
+func isChained(dir ast.Directive) bool {
+	for _, action := range directiveActions(dir) {
+		if _, ok := action.(*ast.ActionChain); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+//directiveActions returns the actions of SecRule, SecAction and SecDefaultAction directives
+func directiveActions(dir ast.Directive) []ast.Action {
+	switch dir := dir.(type) {
+	case *ast.DirectiveSecRule:
+		return dir.Actions()
+	case *ast.DirectiveSecAction:
+		return dir.Actions()
+	case *ast.DirectiveSecDefaultAction:
+		return dir.Actions()
+	}
+
+	return nil
+}
+
+//openGeoDB opens the database of the geoLookup operator, unless one was set with WithGeoLookup.
+// Like ModSecurity the database is opened when the config is loaded, a missing database is an error.
+func (c *compiler) openGeoDB(dir *ast.DirectiveSecGeoLookupDb) error {
+	if c.geoLookup {
+		return nil
+	}
+
+	geo, err := lookup.OpenGeoDB(dir.Value)
+	if err != nil {
+		return fmt.Errorf("Unable to open %s '%s': %w", dir.Name(), dir.Value, err)
+	}
+
+	c.operatorOptions = append(c.operatorOptions, operator.WithGeoLookup(geo))
+
+	return nil
+}
+
+//setDefaults validates the actions of SecDefaultAction and stores them as the defaults of its phase
+func (c *compiler) setDefaults(dir *ast.DirectiveSecDefaultAction) error {
+	phase := rulePhase(dir)
+	if phase < PhaseRequestHeaders || phase > PhaseLogging {
+		return fmt.Errorf("Invalid phase '%d' in %s", phase, dir.Name())
+	}
+
+	defaults := []ast.Action{}
+	for _, action := range dir.Actions() {
+		switch action.(type) {
+		case *ast.ActionPhase:
+			//The phase only selects the rules to which the defaults apply
+		case *ast.ActionID, *ast.ActionChain, *ast.ActionSkipAfter, *ast.ActionBlock:
+			return fmt.Errorf("%s may not contain the '%s' action", dir.Name(), action.Name())
+		default:
+			defaults = append(defaults, action)
+		}
+	}
+
+	c.defaults[phase] = defaults
+
+	return nil
+}
+
+//rulePhase returns the value of the phase action of the directive, or the default phase if it has none
+func rulePhase(dir ast.Directive) int {
+	phase := defaultPhase
+	for _, action := range directiveActions(dir) {
+		if phaseAction, ok := action.(*ast.ActionPhase); ok {
+			phase = phaseAction.Value
+		}
+	}
+
+	return phase
+}
+
+//ruleID returns the value of the id action of the directive, or 0 if it has none
+func ruleID(dir ast.Directive) int {
+	id := 0
+	for _, action := range directiveActions(dir) {
+		if idAction, ok := action.(*ast.ActionID); ok {
+			id = idAction.Value
+		}
+	}
+
+	return id
+}
+
+//compileRule compiles a SecRule or SecAction, the default actions of the phase of the chain are prepended to its actions.
+func (c *compiler) compileRule(dir ast.Directive, chainPhase int) (*rule, error) {
+	r := &rule{
+		directive: dir,
+		phase:     defaultPhase,
+	}
+
+	var (
+		actions  = directiveActions(dir)
+		variable *ast.VariableList
+		op       ast.Operator
+	)
+
+	if chainPhase >= PhaseRequestHeaders && chainPhase <= PhaseLogging {
+		actions = append(append([]ast.Action{}, c.defaults[chainPhase]...), actions...)
+	}
+
+	if secRule, ok := dir.(*ast.DirectiveSecRule); ok {
+		variable = secRule.Variable
+		op = secRule.Operator
+	}
+
+	for _, action := range actions {
+		switch action := action.(type) {
+		case *ast.ActionID:
+			r.id = action.Value
+		case *ast.ActionPhase:
+			r.phase = action.Value
+		case *ast.ActionCapture:
+			r.capture = true
+		case *ast.ActionMultiMatch:
+			r.multiMatch = true
+		case *ast.ActionSkipAfter:
+			r.skipAfter = staticString(action.Value)
+		case *ast.ActionTag:
+			r.tags = append(r.tags, staticString(action.Value))
+		case *ast.ActionMessage:
+			r.message = action.Value
+		case *ast.ActionLogData:
+			r.logData = action.Value
+		case *ast.ActionSeverity:
+			r.severity = action.Value
+		case *ast.ActionStatus:
+			r.status = action.Value
+		case *ast.ActionPause:
+			//Like ModSecurity the pause is applied before the disruptive action, it isn't a disruptive action itself
+			r.pause = action.Value
+		case *ast.ActionSetVar, *ast.ActionCTL, *ast.ActionInitcol, *ast.ActionExpireVar:
+			r.actions = append(r.actions, action)
+		default:
+			//Like ModSecurity the last disruptive action wins
+			if action.ActionType() == ast.ACTION_TYPE_DISRUPTIVE {
+				r.disruptive = action
+			}
+		}
+	}
+
+	if r.phase < PhaseRequestHeaders || r.phase > PhaseLogging {
+		return nil, fmt.Errorf("Invalid phase '%d' in %s", r.phase, describe(r))
+	}
+
+	//block uses the disruptive action of SecDefaultAction, which is pass if there is none
+	if _, ok := r.disruptive.(*ast.ActionBlock); ok {
+		r.disruptive = nil
+		for _, action := range c.defaults[chainPhase] {
+			if _, ok := action.(*ast.ActionPause); ok {
+				continue
+			}
+
+			if action.ActionType() == ast.ACTION_TYPE_DISRUPTIVE {
+				r.disruptive = action
+			}
+		}
+	}
+
+	var err error
+	r.pipeline, err = transform.NewPipeline(actions)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", describe(r), err)
+	}
+
+	if variable != nil {
+		for _, selector := range variable.VariableSelectors {
+			t, err := compileTarget(selector.Variable, selector.CollectionSelector)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", describe(r), err)
+			}
+
+			switch selector.SelectorOperation {
+			case ast.VARIABLE_SELECTION_REMOVE:
+				r.exclusions = append(r.exclusions, t)
+			case ast.VARIABLE_SELECTION_COUNT:
+				t.count = true
+				r.targets = append(r.targets, t)
+			default:
+				r.targets = append(r.targets, t)
+			}
+		}
+	}
+
+	if op != nil {
+		r.matcher, err = operator.Compile(op, c.operatorOptions...)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", describe(r), err)
+		}
+	}
+
+	return r, nil
+}
+
+func compileTarget(variable ast.Variable, selection ast.VariableCollectionSelection) (*target, error) {
+	t := &target{
+		name: variable.Name(),
+	}
+
+	switch selection := selection.(type) {
+	case *ast.KeyVariableCollectionSelection:
+		t.key = selection.Value
+
+	case *ast.RegexVariableCollectionSelection:
+		//Like ModSecurity, keys are selected case insensitive
+		re, err := regexp.Compile("(?is)" + selection.Value)
+		if err != nil {
+			return nil, fmt.Errorf("Unable to compile key selection '%s' of '%s': %w", selection.Value, t.name, err)
+		}
+
+		t.keyRegex = re
+	}
+
+	return t, nil
+}
+
+//selects returns true if the target selects the key of the variable
+func (t *target) selects(name, key string) bool {
+	if t.name != name {
+		return false
+	}
+
+	if t.keyRegex != nil {
+		return t.keyRegex.MatchString(key)
+	}
+
+	return t.key == "" || equalFold(t.key, key)
+}
+
+//describe returns a description of the rule which can be used in messages, i.e. "SecRule 920100"
+func describe(r *rule) string {
+	if r.id == 0 {
+		return r.directive.Name() + " without id"
+	}
+
+	return fmt.Sprintf("%s %d", r.directive.Name(), r.id)
+}
+
+//staticString expands a string which can't contain macros at runtime
+func staticString(str *ast.ExpandableString) string {
+	value, _ := macro.Expand(str, macro.Lenient(nil))
+	return value
+}
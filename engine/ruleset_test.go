@@ -0,0 +1,88 @@
+package engine
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dylandreimerink/go-modsec-parser/lint"
+	"github.com/dylandreimerink/go-modsec-parser/parser"
+)
+
+func TestIncompleteChain(t *testing.T) {
+	doc, err := parser.Parse("chain.conf", `
+SecRule ARGS:a "@streq 1" "id:1,phase:1,deny"
+SecRule ARGS:b "@streq 1" "id:2,phase:1,deny,chain"
+`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err = NewRuleset(doc)
+	if err == nil || !strings.Contains(err.Error(), "The chain of SecRule 2 has no following rule") {
+		t.Fatalf("got error %v, want an error for the incomplete chain", err)
+	}
+
+	var diagnostics []lint.Diagnostic
+	rs, err := NewRuleset(doc, WithDiagnostics(func(d lint.Diagnostic) {
+		diagnostics = append(diagnostics, d)
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(diagnostics) != 1 || diagnostics[0].Severity != lint.SeverityWarning {
+		t.Errorf("unexpected diagnostics %v", diagnostics)
+	}
+
+	//The complete rule is still evaluated
+	tx := rs.NewTransaction()
+	tx.Collection("ARGS").Add("a", "1")
+	if iv := tx.ProcessPhase(PhaseRequestHeaders); iv == nil || iv.RuleID != 1 {
+		t.Errorf("got %+v, want an intervention of rule 1", iv)
+	}
+}
+
+func TestUnsupportedRulesSkipped(t *testing.T) {
+	doc, err := parser.Parse("unsupported.conf", `
+SecRule ARGS "@detectXSS" "id:1,phase:1,deny"
+SecRule FILES_TMPNAMES "@inspectFile /usr/local/bin/scan.pl" "id:2,phase:2,deny"
+SecRule REMOTE_ADDR "@rbl sbl-xbl.spamhaus.org" "id:3,phase:1,deny"
+SecRule ARGS:q "@rx foo(?=bar)" "id:4,phase:1,deny"
+SecRule ARGS:q "@streq attack" "id:5,phase:1,deny,status:401"
+`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	rs, err := NewRuleset(doc)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tx := rs.NewTransaction()
+	tx.Collection("ARGS").Add("q", "attack")
+	if iv := tx.ProcessPhase(PhaseRequestHeaders); iv == nil || iv.RuleID != 5 {
+		t.Errorf("got %+v, want an intervention of rule 5", iv)
+	}
+
+	var diagnostics []lint.Diagnostic
+	if _, err := NewRuleset(doc, WithDiagnostics(func(d lint.Diagnostic) {
+		diagnostics = append(diagnostics, d)
+	})); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(diagnostics) != 4 {
+		t.Errorf("got diagnostics %v, want one for each unsupported rule", diagnostics)
+	}
+
+	//Other compile errors still fail the ruleset
+	doc, err = parser.Parse("invalid.conf", `SecRule REMOTE_ADDR "@geoLookup" "id:1,phase:1,deny"`+"\n")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := NewRuleset(doc); err == nil {
+		t.Error("expected an error for geoLookup without a database")
+	}
+}
@@ -0,0 +1,282 @@
+package engine
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dylandreimerink/go-modsec-parser/clock"
+	"github.com/dylandreimerink/go-modsec-parser/collection"
+)
+
+//defaultCollectionTimeout is the default of SecCollectionTimeout, in seconds
+const defaultCollectionTimeout = 3600
+
+//pruneInterval is the minimum time between two sweeps for expired records
+const pruneInterval = time.Minute
+
+//CollectionStore persists the collections which are initialized with initcol, like IP and SESSION, across transactions.
+// A record is identified by the name of the collection and the key given to initcol. A record expires if it isn't
+// updated within its timeout and a variable expires once the TTL set with expirevar has passed.
+// A store must be safe for concurrent use.
+//
+// The timeout passed to every method is the value of SecCollectionTimeout, it is used for records which are created
+// by the call because they didn't exist or were expired.
+type CollectionStore interface {
+	//Init returns the variables of the record, isNew is true if the record didn't exist or was expired
+	Init(collection, key string, timeout time.Duration) (vars []collection.Entry, isNew bool, err error)
+
+	//Set sets the value of a variable
+	Set(collection, key string, timeout time.Duration, variable, value string) error
+
+	//Increment atomically adds delta to the integer value of a variable and returns the new value,
+	// a variable which doesn't exist or isn't a number is 0
+	Increment(collection, key string, timeout time.Duration, variable string, delta int) (int, error)
+
+	//Delete removes a variable
+	Delete(collection, key string, timeout time.Duration, variable string) error
+
+	//Expire removes the variable once the TTL has passed
+	Expire(collection, key string, timeout time.Duration, variable string, ttl time.Duration) error
+}
+
+//StoreOption changes the behaviour of a collection store
+type StoreOption func(*store)
+
+//WithStoreClock sets the clock which is used for the expiry of records and variables
+func WithStoreClock(c clock.Clock) StoreOption {
+	return func(s *store) {
+		s.clock = c
+	}
+}
+
+//record is a persistent collection
+type record struct {
+	Timeout    time.Duration    `json:"timeout"`
+	LastUpdate time.Time        `json:"last_update"`
+	Vars       []*storeVariable `json:"vars"`
+}
+
+type storeVariable struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+
+	//The time at which the variable expires, zero if it doesn't expire
+	Expires time.Time `json:"expires,omitempty"`
+}
+
+//backend loads and saves records, a nil record is returned if it doesn't exist
+type backend interface {
+	load(collection, key string) (*record, error)
+	save(collection, key string, r *record) error
+}
+
+//pruner is implemented by backends which remove expired records, so records of clients which don't return
+// don't accumulate
+type pruner interface {
+	prune(now time.Time)
+}
+
+//store implements the expiry and atomic updates of records on top of a backend
+type store struct {
+	mu      sync.Mutex
+	backend backend
+	clock   clock.Clock
+
+	//The time of the last sweep for expired records
+	lastPrune time.Time
+}
+
+func newStore(b backend, opts []StoreOption) *store {
+	s := &store{
+		backend: b,
+		clock:   clock.System{},
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+//load returns the record without expired variables, or a new record if it doesn't exist or has expired
+func (s *store) load(coll, key string, timeout time.Duration) (*record, bool, error) {
+	r, err := s.backend.load(strings.ToUpper(coll), key)
+	if err != nil {
+		return nil, false, err
+	}
+
+	now := s.clock.Now()
+	if r == nil || r.expired(now) {
+		return &record{Timeout: timeout}, true, nil
+	}
+
+	vars := r.Vars[:0]
+	for _, v := range r.Vars {
+		if v.Expires.IsZero() || now.Before(v.Expires) {
+			vars = append(vars, v)
+		}
+	}
+	r.Vars = vars
+
+	return r, false, nil
+}
+
+//update loads the record, applies the change and saves it as one atomic operation
+func (s *store) update(coll, key string, timeout time.Duration, change func(r *record) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, _, err := s.load(coll, key, timeout)
+	if err != nil {
+		return err
+	}
+
+	if err := change(r); err != nil {
+		return err
+	}
+
+	return s.save(coll, key, r)
+}
+
+//save saves the record and sweeps the backend for expired records once per prune interval
+func (s *store) save(coll, key string, r *record) error {
+	now := s.clock.Now()
+	r.LastUpdate = now
+
+	if err := s.backend.save(strings.ToUpper(coll), key, r); err != nil {
+		return err
+	}
+
+	if p, ok := s.backend.(pruner); ok && now.Sub(s.lastPrune) >= pruneInterval {
+		p.prune(now)
+		s.lastPrune = now
+	}
+
+	return nil
+}
+
+func (s *store) Init(coll, key string, timeout time.Duration) ([]collection.Entry, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, isNew, err := s.load(coll, key, timeout)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if isNew {
+		if err := s.save(coll, key, r); err != nil {
+			return nil, false, err
+		}
+	}
+
+	vars := make([]collection.Entry, 0, len(r.Vars))
+	for _, v := range r.Vars {
+		vars = append(vars, collection.Entry{Key: v.Name, Value: v.Value})
+	}
+
+	return vars, isNew, nil
+}
+
+func (s *store) Set(coll, key string, timeout time.Duration, variable, value string) error {
+	return s.update(coll, key, timeout, func(r *record) error {
+		r.variable(variable).Value = value
+		return nil
+	})
+}
+
+func (s *store) Increment(coll, key string, timeout time.Duration, variable string, delta int) (int, error) {
+	var value int
+	err := s.update(coll, key, timeout, func(r *record) error {
+		v := r.variable(variable)
+		value = toInt(v.Value) + delta
+		v.Value = strconv.Itoa(value)
+		return nil
+	})
+
+	return value, err
+}
+
+func (s *store) Delete(coll, key string, timeout time.Duration, variable string) error {
+	return s.update(coll, key, timeout, func(r *record) error {
+		vars := r.Vars[:0]
+		for _, v := range r.Vars {
+			if !strings.EqualFold(v.Name, variable) {
+				vars = append(vars, v)
+			}
+		}
+		r.Vars = vars
+
+		return nil
+	})
+}
+
+func (s *store) Expire(coll, key string, timeout time.Duration, variable string, ttl time.Duration) error {
+	return s.update(coll, key, timeout, func(r *record) error {
+		r.variable(variable).Expires = s.clock.Now().Add(ttl)
+		return nil
+	})
+}
+
+//expired returns true if the record wasn't updated within its timeout
+func (r *record) expired(now time.Time) bool {
+	return now.Sub(r.LastUpdate) > r.Timeout
+}
+
+//variable returns the variable with the case insensitive name, it is created if it doesn't exist
+func (r *record) variable(name string) *storeVariable {
+	for _, v := range r.Vars {
+		if strings.EqualFold(v.Name, name) {
+			return v
+		}
+	}
+
+	v := &storeVariable{Name: name}
+	r.Vars = append(r.Vars, v)
+
+	return v
+}
+
+//memoryBackend keeps records in memory, they are lost when the process exits
+type memoryBackend struct {
+	records map[string]*record
+}
+
+//NewMemoryStore creates a collection store which keeps records in memory.
+// Expired records are removed from memory once per minute, when a record is saved.
+func NewMemoryStore(opts ...StoreOption) CollectionStore {
+	return newStore(&memoryBackend{records: map[string]*record{}}, opts)
+}
+
+func (mb *memoryBackend) load(coll, key string) (*record, error) {
+	r, found := mb.records[coll+"\x00"+key]
+	if !found {
+		return nil, nil
+	}
+
+	//Return a copy, so changes are only stored by save
+	cp := *r
+	cp.Vars = make([]*storeVariable, len(r.Vars))
+	for i, v := range r.Vars {
+		vCopy := *v
+		cp.Vars[i] = &vCopy
+	}
+
+	return &cp, nil
+}
+
+func (mb *memoryBackend) save(coll, key string, r *record) error {
+	mb.records[coll+"\x00"+key] = r
+	return nil
+}
+
+func (mb *memoryBackend) prune(now time.Time) {
+	for id, r := range mb.records {
+		if r.expired(now) {
+			delete(mb.records, id)
+		}
+	}
+}
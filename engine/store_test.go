@@ -0,0 +1,240 @@
+package engine
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dylandreimerink/go-modsec-parser/clock"
+	"github.com/dylandreimerink/go-modsec-parser/parser"
+)
+
+//testStores returns a memory and a file store which use the clock
+func testStores(t *testing.T, c clock.Clock) map[string]CollectionStore {
+	t.Helper()
+
+	fileStore, err := NewFileStore(t.TempDir(), WithStoreClock(c))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return map[string]CollectionStore{
+		"memory": NewMemoryStore(WithStoreClock(c)),
+		"file":   fileStore,
+	}
+}
+
+func TestStoreUpdateTimeout(t *testing.T) {
+	fc := clock.NewFake(time.Unix(1000, 0))
+
+	for name, store := range testStores(t, fc) {
+		t.Run(name, func(t *testing.T) {
+			//A record which is created by an update uses the given timeout, not the default of SecCollectionTimeout
+			if _, err := store.Increment("IP", "1.2.3.4", 10*time.Second, "counter", 1); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			fc.Advance(5 * time.Second)
+			if err := store.Set("IP", "1.2.3.4", 10*time.Second, "flag", "1"); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			fc.Advance(5 * time.Second)
+			vars, isNew, err := store.Init("IP", "1.2.3.4", 10*time.Second)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if isNew || len(vars) != 2 {
+				t.Fatalf("got %v and isNew %v, want the record which was updated 5 seconds ago", vars, isNew)
+			}
+
+			fc.Advance(11 * time.Second)
+			if _, isNew, _ := store.Init("IP", "1.2.3.4", 10*time.Second); !isNew {
+				t.Error("the record didn't expire after its timeout")
+			}
+		})
+	}
+}
+
+func TestCollectionExpiry(t *testing.T) {
+	doc, err := parser.Parse("expiry.conf", `
+SecCollectionTimeout 60
+SecAction "id:1,phase:1,initcol:ip=%{REMOTE_ADDR},setvar:ip.requests=+1,setvar:ip.tmp=1,expirevar:ip.tmp=10,pass,nolog"
+`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	fc := clock.NewFake(time.Unix(1000, 0))
+
+	for name, store := range testStores(t, fc) {
+		t.Run(name, func(t *testing.T) {
+			rs, err := NewRuleset(doc, WithCollectionStore(store))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			steps := []struct {
+				advance    time.Duration
+				remoteAddr string
+
+				wantRequests string
+				wantIsNew    string
+			}{
+				{remoteAddr: "1.2.3.4", wantRequests: "1", wantIsNew: "1"},
+				{advance: 30 * time.Second, remoteAddr: "1.2.3.4", wantRequests: "2", wantIsNew: "0"},
+				{remoteAddr: "5.6.7.8", wantRequests: "1", wantIsNew: "1"},
+				//The timeout is reset by every update
+				{advance: 59 * time.Second, remoteAddr: "1.2.3.4", wantRequests: "3", wantIsNew: "0"},
+				{advance: 61 * time.Second, remoteAddr: "1.2.3.4", wantRequests: "1", wantIsNew: "1"},
+			}
+
+			for i, step := range steps {
+				fc.Advance(step.advance)
+
+				tx := rs.NewTransaction()
+				tx.SetVariable("REMOTE_ADDR", step.remoteAddr)
+				tx.ProcessPhase(PhaseRequestHeaders)
+
+				ip := tx.Collection("IP")
+				requests, _ := ip.First("requests")
+				isNew, _ := ip.First("IS_NEW")
+				timeout, _ := ip.First("TIMEOUT")
+				if requests != step.wantRequests || isNew != step.wantIsNew || timeout != "60" {
+					t.Errorf("step %d: got requests '%s', IS_NEW '%s' and TIMEOUT '%s', want '%s', '%s' and '60'",
+						i, requests, isNew, timeout, step.wantRequests, step.wantIsNew)
+				}
+			}
+		})
+	}
+}
+
+func TestExpireVar(t *testing.T) {
+	fc := clock.NewFake(time.Unix(1000, 0))
+
+	for name, store := range testStores(t, fc) {
+		t.Run(name, func(t *testing.T) {
+			vars, _, err := store.Init("SESSION", "abc", time.Hour)
+			if err != nil || len(vars) != 0 {
+				t.Fatalf("got %v and error %v, want an empty record", vars, err)
+			}
+
+			store.Set("SESSION", "abc", time.Hour, "token", "1")
+			store.Set("SESSION", "abc", time.Hour, "user", "admin")
+			store.Expire("SESSION", "abc", time.Hour, "token", 10*time.Second)
+
+			fc.Advance(9 * time.Second)
+			if vars, _, _ := store.Init("SESSION", "abc", time.Hour); len(vars) != 2 {
+				t.Errorf("got %v before the TTL has passed", vars)
+			}
+
+			fc.Advance(time.Second)
+			vars, isNew, _ := store.Init("SESSION", "abc", time.Hour)
+			if isNew || len(vars) != 1 || vars[0].Key != "user" {
+				t.Errorf("got %v and isNew %v, want only the variable without TTL", vars, isNew)
+			}
+
+			//An expired variable starts at 0 again
+			if value, _ := store.Increment("SESSION", "abc", time.Hour, "token", 5); value != 5 {
+				t.Errorf("got %d after incrementing an expired variable, want 5", value)
+			}
+		})
+	}
+}
+
+//TestDoSProtection follows the DoS protection of the core rule set, a client is blocked for 300 seconds
+// if it sends more than 3 requests within a burst window of 60 seconds
+func TestDoSProtection(t *testing.T) {
+	doc, err := parser.Parse("dos.conf", `
+SecCollectionTimeout 600
+SecAction "id:1,phase:1,pass,nolog,initcol:ip=%{REMOTE_ADDR}"
+SecRule IP:dos_block "@eq 1" "id:2,phase:1,deny,status:429"
+SecAction "id:3,phase:1,setvar:ip.dos_burst=+1,expirevar:ip.dos_burst=60,pass,nolog"
+SecRule IP:dos_burst "@gt 3" "id:4,phase:1,setvar:ip.dos_block=1,expirevar:ip.dos_block=300,pass,nolog"
+`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	fc := clock.NewFake(time.Unix(1000, 0))
+
+	for name, store := range testStores(t, fc) {
+		t.Run(name, func(t *testing.T) {
+			rs, err := NewRuleset(doc, WithClock(fc), WithCollectionStore(store))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			steps := []struct {
+				advance    time.Duration
+				remoteAddr string
+				wantStatus int
+			}{
+				{remoteAddr: "1.2.3.4"},
+				{advance: time.Second, remoteAddr: "1.2.3.4"},
+				{advance: time.Second, remoteAddr: "1.2.3.4"},
+				//The fourth request within the burst window blocks the client from the next request on
+				{advance: time.Second, remoteAddr: "1.2.3.4"},
+				{advance: time.Second, remoteAddr: "1.2.3.4", wantStatus: 429},
+				{remoteAddr: "5.6.7.8"},
+				//The burst counter has expired, but the client is still blocked
+				{advance: 61 * time.Second, remoteAddr: "1.2.3.4", wantStatus: 429},
+				{advance: 237 * time.Second, remoteAddr: "1.2.3.4", wantStatus: 429},
+				//300 seconds after the fourth request the block has expired
+				{advance: time.Second, remoteAddr: "1.2.3.4"},
+			}
+
+			for i, step := range steps {
+				fc.Advance(step.advance)
+
+				tx := rs.NewTransaction()
+				tx.SetVariable("REMOTE_ADDR", step.remoteAddr)
+
+				status := 0
+				if iv := tx.ProcessPhase(PhaseRequestHeaders); iv != nil {
+					status = iv.Status
+				}
+
+				if status != step.wantStatus {
+					t.Errorf("step %d: got status %d, want %d", i, status, step.wantStatus)
+				}
+			}
+		})
+	}
+}
+
+func TestMemoryStorePrune(t *testing.T) {
+	fc := clock.NewFake(time.Unix(1000, 0))
+	memoryStore := NewMemoryStore(WithStoreClock(fc))
+	records := memoryStore.(*store).backend.(*memoryBackend).records
+
+	//Like initcol:ip=%{REMOTE_ADDR}, every client creates a record
+	for i := 0; i < 100; i++ {
+		if _, _, err := memoryStore.Init("IP", fmt.Sprintf("10.0.0.%d", i), time.Minute); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if len(records) != 100 {
+		t.Fatalf("got %d records, want 100", len(records))
+	}
+
+	//A client which returns keeps its record
+	fc.Advance(50 * time.Second)
+	memoryStore.Set("IP", "10.0.0.1", time.Minute, "requests", "2")
+
+	fc.Advance(20 * time.Second)
+	if _, _, err := memoryStore.Init("IP", "10.0.1.1", time.Minute); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(records) != 2 {
+		t.Errorf("got %d records after the timeout, want 2", len(records))
+	}
+
+	vars, isNew, _ := memoryStore.Init("IP", "10.0.0.1", time.Minute)
+	if isNew || len(vars) != 1 {
+		t.Errorf("got %v and isNew %v, want the record of the returning client", vars, isNew)
+	}
+}
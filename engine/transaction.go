@@ -0,0 +1,636 @@
+package engine
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dylandreimerink/go-modsec-parser/ast"
+	"github.com/dylandreimerink/go-modsec-parser/collection"
+	"github.com/dylandreimerink/go-modsec-parser/macro"
+)
+
+//Transaction is the state of a single request and response which is evaluated against a ruleset.
+// A transaction is not safe for concurrent use.
+type Transaction struct {
+	ruleset *Ruleset
+
+	//The rule engine mode, which can be changed per transaction with ctl:ruleEngine
+	engine ast.SecRuleEngineValue
+
+	//The collections which hold the values of variables, by upper case variable name
+	collections map[string]*collection.Collection
+
+	matched      []*MatchedRule
+	intervention *Intervention
+
+	//Set by the allow action, no more rules are evaluated except in the logging phase
+	allowed bool
+
+	//The rules and targets which are removed with ctl actions for this transaction only
+	removedRules   []ruleFilter
+	removedTargets []targetFilter
+
+	//The key of the collections which are initialized with initcol, by upper case collection name
+	persistent map[string]string
+
+	//The time at which the transaction started, DURATION is relative to it
+	start time.Time
+}
+
+//ruleFilter selects rules by id range or tag
+type ruleFilter struct {
+	startID int
+	endID   int
+	tag     *regexp.Regexp
+}
+
+func (f ruleFilter) selects(r *rule) bool {
+	if f.tag == nil {
+		return r.id >= f.startID && r.id <= f.endID
+	}
+
+	for _, tag := range r.tags {
+		if f.tag.MatchString(tag) {
+			return true
+		}
+	}
+
+	return false
+}
+
+//targetFilter removes a target from the rules selected by the rule filter
+type targetFilter struct {
+	rules  ruleFilter
+	target *target
+}
+
+//match is a value which matched the operator of a single rule
+type match struct {
+	MatchedVar
+
+	//The captures of the operator, only set if the rule has the capture action
+	captures []string
+}
+
+//NewTransaction creates a new transaction, the variables of the transaction must be set before the phases are processed
+func (rs *Ruleset) NewTransaction() *Transaction {
+	return &Transaction{
+		ruleset:     rs,
+		engine:      rs.engine,
+		collections: map[string]*collection.Collection{},
+		persistent:  map[string]string{},
+		start:       rs.clock.Now(),
+	}
+}
+
+//Collection returns the collection which holds the values of the variable, it is created if it doesn't exist yet.
+// The name is case insensitive.
+func (tx *Transaction) Collection(name string) *collection.Collection {
+	name = strings.ToUpper(name)
+
+	coll, found := tx.collections[name]
+	if !found {
+		coll = collection.New()
+		tx.collections[name] = coll
+	}
+
+	return coll
+}
+
+//SetVariable sets the value of a variable which isn't a collection, like REQUEST_URI
+func (tx *Transaction) SetVariable(name, value string) {
+	tx.Collection(name).Set("", value)
+}
+
+//Variable returns the value of a variable which isn't a collection, false is returned if the variable isn't set
+func (tx *Transaction) Variable(name string) (string, bool) {
+	coll, found := tx.collections[strings.ToUpper(name)]
+	if !found || coll.Len() == 0 {
+		return "", false
+	}
+
+	return coll.Entries()[0].Value, true
+}
+
+//ResolveMacro returns the value of the variable or collection member referenced by the macro
+func (tx *Transaction) ResolveMacro(m *ast.StringMacro) (string, bool) {
+	if m.Collection == "" {
+		return tx.Variable(m.Variable)
+	}
+
+	coll, found := tx.collections[strings.ToUpper(m.Collection)]
+	if !found {
+		return "", false
+	}
+
+	return coll.First(m.Variable)
+}
+
+//MatchedRules returns the rules which matched so far, in the order in which they matched
+func (tx *Transaction) MatchedRules() []*MatchedRule {
+	return tx.matched
+}
+
+//Intervention returns the disruptive intervention of the transaction, nil if no disruptive action was triggered
+func (tx *Transaction) Intervention() *Intervention {
+	return tx.intervention
+}
+
+//RuleEngine returns the rule engine mode of the transaction, which can be changed by rules with ctl:ruleEngine
+func (tx *Transaction) RuleEngine() ast.SecRuleEngineValue {
+	return tx.engine
+}
+
+//ProcessPhase evaluates the rules of the phase and returns the intervention of the transaction, which is nil if
+// no disruptive action was triggered. After an intervention or the allow action only the logging phase is evaluated.
+// In DetectionOnly mode rules are evaluated but disruptive actions are not executed.
+func (tx *Transaction) ProcessPhase(phase int) *Intervention {
+	if phase < PhaseRequestHeaders || phase > PhaseLogging {
+		return tx.intervention
+	}
+
+	if phase != PhaseLogging && (tx.intervention != nil || tx.allowed) {
+		return tx.intervention
+	}
+
+	tx.setTime()
+
+	rules := tx.ruleset.phases[phase]
+	for i := 0; i < len(rules); i++ {
+		if tx.engine == ast.ModsecOff {
+			break
+		}
+
+		r := rules[i]
+		if r.marker != "" || tx.removed(r) {
+			continue
+		}
+
+		matches, ok := tx.evaluate(r)
+		if !ok {
+			continue
+		}
+
+		tx.executeActions(r, matches)
+		matched := tx.matchedRule(r, phase, matches)
+		tx.matched = append(tx.matched, matched)
+
+		//Disruptive actions are ignored in the logging phase since the transaction is already complete
+		if phase != PhaseLogging && tx.disrupt(r, matched) {
+			break
+		}
+
+		if r.skipAfter != "" {
+			target, found := tx.ruleset.skipTargets[phase][r.skipAfter]
+			if !found || target <= i {
+				break
+			}
+
+			i = target
+		}
+	}
+
+	return tx.intervention
+}
+
+//setTime sets the TIME variables and DURATION to the time of the clock of the ruleset,
+// they are updated at the start of every phase
+func (tx *Transaction) setTime() {
+	now := tx.ruleset.clock.Now()
+
+	tx.SetVariable((&ast.VariableTime{}).Name(), now.Format("15:04:05"))
+	tx.SetVariable((&ast.VariableTimeDay{}).Name(), strconv.Itoa(now.Day()))
+	tx.SetVariable((&ast.VariableTimeEpoch{}).Name(), strconv.FormatInt(now.Unix(), 10))
+	tx.SetVariable((&ast.VariableTimeHour{}).Name(), strconv.Itoa(now.Hour()))
+	tx.SetVariable((&ast.VariableTimeMin{}).Name(), strconv.Itoa(now.Minute()))
+	//Like ModSecurity months start at 0
+	tx.SetVariable((&ast.VariableTimeMon{}).Name(), strconv.Itoa(int(now.Month())-1))
+	tx.SetVariable((&ast.VariableTimeSec{}).Name(), strconv.Itoa(now.Second()))
+	tx.SetVariable((&ast.VariableTimeWDay{}).Name(), strconv.Itoa(int(now.Weekday())))
+	tx.SetVariable((&ast.VariableTimeYear{}).Name(), strconv.Itoa(now.Year()))
+	tx.SetVariable((&ast.VariableDuration{}).Name(), strconv.FormatInt(now.Sub(tx.start).Milliseconds(), 10))
+}
+
+//evaluate evaluates all rules of the chain, the matches of every rule in the chain are returned if all of them matched
+func (tx *Transaction) evaluate(r *rule) ([][]match, bool) {
+	tx.Collection("MATCHED_VARS").Clear()
+	tx.Collection("MATCHED_VARS_NAMES").Clear()
+
+	rc := tx.Collection("RULE")
+	rc.Clear()
+	rc.Set("id", strconv.Itoa(r.id))
+	rc.Set("phase", strconv.Itoa(r.phase))
+	rc.Set("severity", strconv.Itoa(r.severity))
+
+	chainMatches := [][]match{}
+	for link := r; link != nil; link = link.chain {
+		matches := tx.evaluateRule(link, r)
+		if len(matches) == 0 {
+			return nil, false
+		}
+
+		chainMatches = append(chainMatches, matches)
+	}
+
+	return chainMatches, true
+}
+
+//evaluateRule matches the operator of a single rule against all its targets
+func (tx *Transaction) evaluateRule(r *rule, chainStart *rule) []match {
+	//SecAction has no operator and always matches once
+	if r.matcher == nil {
+		return []match{{}}
+	}
+
+	matches := []match{}
+	for _, value := range tx.targetValues(r, chainStart) {
+		inputs := [][]byte{r.pipeline.Apply([]byte(value.Value))}
+		if r.multiMatch {
+			inputs = r.pipeline.ApplyAll([]byte(value.Value))
+		}
+
+		for _, input := range inputs {
+			matched, captures := r.matcher.Match(input, tx)
+			if !matched {
+				continue
+			}
+
+			m := match{
+				MatchedVar: MatchedVar{
+					Name:  value.Name,
+					Value: string(input),
+				},
+			}
+
+			if r.capture {
+				m.captures = captures
+			}
+
+			tx.setMatched(m)
+			tx.Collection("MATCHED_VARS").Add(m.Name, m.Value)
+			tx.Collection("MATCHED_VARS_NAMES").Add(m.Name, m.Name)
+
+			matches = append(matches, m)
+			break
+		}
+	}
+
+	return matches
+}
+
+//targetValues returns the values of all targets of the rule, without the excluded targets
+func (tx *Transaction) targetValues(r *rule, chainStart *rule) []MatchedVar {
+	exclusions := r.exclusions
+	for _, filter := range tx.removedTargets {
+		if filter.rules.selects(chainStart) {
+			exclusions = append(exclusions, filter.target)
+		}
+	}
+
+	excluded := func(name, key string) bool {
+		for _, exclusion := range exclusions {
+			if exclusion.selects(name, key) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	values := []MatchedVar{}
+	for _, t := range r.targets {
+		coll, found := tx.collections[t.name]
+		if !found {
+			coll = collection.New()
+		}
+
+		count := 0
+		for _, entry := range coll.Entries() {
+			if !t.selects(t.name, entry.Key) || excluded(t.name, entry.Key) {
+				continue
+			}
+
+			count++
+			if t.count {
+				continue
+			}
+
+			name := t.name
+			if entry.Key != "" {
+				name += ":" + entry.Key
+			}
+
+			values = append(values, MatchedVar{Name: name, Value: entry.Value})
+		}
+
+		if t.count {
+			name := "&" + t.name
+			if t.key != "" {
+				name += ":" + t.key
+			}
+
+			values = append(values, MatchedVar{Name: name, Value: strconv.Itoa(count)})
+		}
+	}
+
+	return values
+}
+
+//setMatched sets the MATCHED_VAR variables and the captures of the match
+func (tx *Transaction) setMatched(m match) {
+	tx.SetVariable("MATCHED_VAR", m.Value)
+	tx.SetVariable("MATCHED_VAR_NAME", m.Name)
+
+	if m.captures == nil {
+		return
+	}
+
+	txColl := tx.Collection("TX")
+	for i := 0; i < 10; i++ {
+		if i < len(m.captures) {
+			txColl.Set(strconv.Itoa(i), m.captures[i])
+		} else {
+			txColl.Delete(strconv.Itoa(i))
+		}
+	}
+}
+
+//executeActions executes the non-disruptive actions of every rule in the chain, once for every match of the rule
+func (tx *Transaction) executeActions(r *rule, chainMatches [][]match) {
+	link := r
+	for _, matches := range chainMatches {
+		for _, m := range matches {
+			if link.matcher != nil {
+				tx.setMatched(m)
+			}
+
+			for _, action := range link.actions {
+				tx.executeAction(action)
+			}
+		}
+
+		link = link.chain
+	}
+}
+
+func (tx *Transaction) executeAction(action ast.Action) {
+	switch action := action.(type) {
+	case *ast.ActionSetVar:
+		tx.setVar(action)
+
+	case *ast.ActionCTL:
+		tx.ctl(action)
+
+	case *ast.ActionInitcol:
+		tx.initcol(action)
+
+	case *ast.ActionExpireVar:
+		tx.expireVar(action)
+	}
+}
+
+//setVar executes the setvar action, the TX collection is used if the action has no collection
+func (tx *Transaction) setVar(action *ast.ActionSetVar) {
+	collName := "TX"
+	if action.Collection != nil {
+		collName = tx.expand(action.Collection)
+	}
+
+	coll := tx.Collection(collName)
+	key := tx.expand(action.Variable)
+
+	//Changes to persistent collections are written through to the store. Like ModSecurity a failing store doesn't
+	// stop the evaluation of the rules, the transaction continues with its own copy of the collection.
+	store, recordKey, persistent := tx.collectionStore(collName)
+
+	switch action.Op {
+	case ast.SET_VAR_DELETE:
+		coll.Delete(key)
+		if persistent {
+			_ = store.Delete(collName, recordKey, tx.ruleset.collectionTimeout, key)
+		}
+
+	case ast.SET_VAR_ADD, ast.SET_VAR_SUB:
+		modifier := toInt(tx.expand(action.Modifier))
+		if action.Op == ast.SET_VAR_SUB {
+			modifier = -modifier
+		}
+
+		//The store increments atomically, so concurrent transactions don't lose updates of counters
+		if persistent {
+			if value, err := store.Increment(collName, recordKey, tx.ruleset.collectionTimeout, key, modifier); err == nil {
+				coll.Set(key, strconv.Itoa(value))
+				return
+			}
+		}
+
+		current, _ := coll.First(key)
+		coll.Set(key, strconv.Itoa(toInt(current)+modifier))
+
+	case ast.SET_VAR_SET:
+		value := tx.expand(action.Modifier)
+		coll.Set(key, value)
+		if persistent {
+			_ = store.Set(collName, recordKey, tx.ruleset.collectionTimeout, key, value)
+		}
+
+	default:
+		//A variable without value is set to 1, i.e. setvar:tx.flag
+		coll.Set(key, "1")
+		if persistent {
+			_ = store.Set(collName, recordKey, tx.ruleset.collectionTimeout, key, "1")
+		}
+	}
+}
+
+//initcol executes the initcol action, the collection is loaded from the store of the ruleset.
+// Without a store, or if the store fails, the collection only exists during the transaction.
+func (tx *Transaction) initcol(action *ast.ActionInitcol) {
+	collName := strings.ToUpper(tx.expand(action.Collection))
+	recordKey := tx.expand(action.Modifier)
+
+	coll := tx.Collection(collName)
+	coll.Clear()
+
+	isNew := true
+	if tx.ruleset.store != nil {
+		vars, created, err := tx.ruleset.store.Init(collName, recordKey, tx.ruleset.collectionTimeout)
+		if err == nil {
+			tx.persistent[collName] = recordKey
+			isNew = created
+			for _, v := range vars {
+				coll.Add(v.Key, v.Value)
+			}
+		}
+	}
+
+	coll.Set("KEY", recordKey)
+	coll.Set("TIMEOUT", strconv.Itoa(int(tx.ruleset.collectionTimeout/time.Second)))
+	if isNew {
+		coll.Set("IS_NEW", "1")
+	} else {
+		coll.Set("IS_NEW", "0")
+	}
+}
+
+//expireVar executes the expirevar action, it only has effect on persistent collections
+func (tx *Transaction) expireVar(action *ast.ActionExpireVar) {
+	collName := "TX"
+	if action.Collection != nil {
+		collName = tx.expand(action.Collection)
+	}
+
+	store, recordKey, persistent := tx.collectionStore(collName)
+	if !persistent {
+		return
+	}
+
+	ttl := time.Duration(toInt(tx.expand(action.TTL))) * time.Second
+	_ = store.Expire(collName, recordKey, tx.ruleset.collectionTimeout, tx.expand(action.Variable), ttl)
+}
+
+//collectionStore returns the store and record key of a collection which was initialized with initcol
+func (tx *Transaction) collectionStore(collName string) (CollectionStore, string, bool) {
+	recordKey, found := tx.persistent[strings.ToUpper(collName)]
+	if !found {
+		return nil, "", false
+	}
+
+	return tx.ruleset.store, recordKey, true
+}
+
+//ctl changes the configuration of the transaction
+func (tx *Transaction) ctl(action *ast.ActionCTL) {
+	switch option := action.Option.(type) {
+	case *ast.DirectiveSecRuleEngine:
+		tx.engine = option.Value
+
+	case *ast.ActionCTLRequestBodyProcessor:
+		//The body processor reads the processor from the variable, like ModSecurity it can only be changed before phase 2
+		tx.SetVariable((&ast.VariableRequestBodyProcessor{}).Name(), string(option.Processor))
+
+	case *ast.ActionCTLRuleRemoveByID:
+		tx.removedRules = append(tx.removedRules, ruleFilter{startID: option.StartID, endID: option.EndID})
+
+	case *ast.ActionCTLRuleRemoveByTag:
+		if re, err := regexp.Compile(option.Regex); err == nil {
+			tx.removedRules = append(tx.removedRules, ruleFilter{tag: re})
+		}
+
+	case *ast.ActionCTLRuleRemoveTargetById:
+		if t, err := compileTarget(option.Variable, option.CollectionSelector); err == nil {
+			tx.removedTargets = append(tx.removedTargets, targetFilter{
+				rules:  ruleFilter{startID: option.StartID, endID: option.EndID},
+				target: t,
+			})
+		}
+
+	case *ast.ActionCTLRuleRemoveTargetByTag:
+		re, err := regexp.Compile(option.Tag)
+		if err != nil {
+			return
+		}
+
+		if t, err := compileTarget(option.Variable, option.CollectionSelector); err == nil {
+			tx.removedTargets = append(tx.removedTargets, targetFilter{
+				rules:  ruleFilter{tag: re},
+				target: t,
+			})
+		}
+	}
+}
+
+//removed returns true if the rule is removed from the transaction with a ctl action
+func (tx *Transaction) removed(r *rule) bool {
+	for _, filter := range tx.removedRules {
+		if filter.selects(r) {
+			return true
+		}
+	}
+
+	return false
+}
+
+//matchedRule creates the result for a rule after its actions have been executed
+func (tx *Transaction) matchedRule(r *rule, phase int, chainMatches [][]match) *MatchedRule {
+	matched := &MatchedRule{
+		ID:       r.id,
+		Phase:    phase,
+		Rule:     r.directive,
+		Message:  tx.expand(r.message),
+		LogData:  tx.expand(r.logData),
+		Severity: r.severity,
+	}
+
+	for _, matches := range chainMatches {
+		for _, m := range matches {
+			if m.Name != "" {
+				matched.MatchedVars = append(matched.MatchedVars, m.MatchedVar)
+			}
+		}
+	}
+
+	return matched
+}
+
+//disrupt executes the disruptive action of the rule, true is returned if rule processing of the phase has to stop
+func (tx *Transaction) disrupt(r *rule, matched *MatchedRule) bool {
+	switch disruptive := r.disruptive.(type) {
+	case *ast.ActionAllow:
+		if tx.engine != ast.ModsecOn {
+			return false
+		}
+
+		tx.allowed = true
+		return true
+
+	case *ast.ActionDeny, *ast.ActionDrop:
+		if tx.engine != ast.ModsecOn {
+			return false
+		}
+
+		tx.intervention = newIntervention(r, matched, "")
+		return true
+
+	case *ast.ActionRedirect:
+		if tx.engine != ast.ModsecOn {
+			return false
+		}
+
+		tx.intervention = newIntervention(r, matched, tx.expand(disruptive.Value))
+		return true
+
+	case *ast.ActionProxy:
+		if tx.engine != ast.ModsecOn {
+			return false
+		}
+
+		tx.intervention = newIntervention(r, matched, tx.expand(disruptive.Value))
+		return true
+	}
+
+	//pass, or no disruptive action at all
+	return false
+}
+
+//expand expands the macros in the string, macros which can't be resolved are empty like in ModSecurity
+func (tx *Transaction) expand(str *ast.ExpandableString) string {
+	value, _ := macro.Expand(str, macro.Lenient(tx))
+	return value
+}
+
+//toInt converts a variable value to an integer, values which are not a number are 0
+func toInt(value string) int {
+	i, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		return 0
+	}
+
+	return i
+}
+
+func equalFold(a, b string) bool {
+	return strings.EqualFold(a, b)
+}
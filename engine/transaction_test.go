@@ -0,0 +1,280 @@
+package engine
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dylandreimerink/go-modsec-parser/clock"
+	"github.com/dylandreimerink/go-modsec-parser/parser"
+)
+
+func TestTimeVariables(t *testing.T) {
+	doc, err := parser.Parse("time.conf", `SecRule DURATION "@ge 1500" "id:1,phase:2,deny,status:503"`+"\n")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	fc := clock.NewFake(time.Date(2024, time.March, 5, 7, 8, 9, 0, time.UTC))
+	rs, err := NewRuleset(doc, WithClock(fc))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tx := rs.NewTransaction()
+	if iv := tx.ProcessPhase(PhaseRequestHeaders); iv != nil {
+		t.Fatalf("got intervention %+v in phase 1", iv)
+	}
+
+	want := map[string]string{
+		"TIME":       "07:08:09",
+		"TIME_DAY":   "5",
+		"TIME_EPOCH": "1709622489",
+		"TIME_HOUR":  "7",
+		"TIME_MIN":   "8",
+		"TIME_MON":   "2",
+		"TIME_SEC":   "9",
+		"TIME_WDAY":  "2",
+		"TIME_YEAR":  "2024",
+		"DURATION":   "0",
+	}
+
+	for name, value := range want {
+		if got, _ := tx.Variable(name); got != value {
+			t.Errorf("got %s '%s', want '%s'", name, got, value)
+		}
+	}
+
+	//The variables are updated at the start of every phase
+	fc.Advance(2 * time.Second)
+	iv := tx.ProcessPhase(PhaseRequestBody)
+
+	if got, _ := tx.Variable("TIME"); got != "07:08:11" {
+		t.Errorf("got TIME '%s', want '07:08:11'", got)
+	}
+
+	if got, _ := tx.Variable("DURATION"); got != "2000" {
+		t.Errorf("got DURATION '%s', want '2000'", got)
+	}
+
+	if iv == nil || iv.Status != 503 {
+		t.Errorf("got %+v, want status 503 for a slow transaction", iv)
+	}
+}
+
+//absent is the wanted value of a TX variable which must not exist
+const absent = "\x00absent"
+
+func TestRuleEvaluation(t *testing.T) {
+	tests := []struct {
+		name string
+		conf string
+		args [][2]string
+
+		//The last phase which is processed, phase 2 if not set
+		phase int
+
+		wantRuleID  int
+		wantStatus  int
+		wantMatched []int
+		wantTX      map[string]string
+	}{
+		{
+			name: "chain matches",
+			conf: `SecRule ARGS:a "@streq 1" "id:1,phase:1,deny,status:401,chain"
+SecRule ARGS:b "@streq 2" "chain"
+SecRule ARGS:c "@streq 3" "setvar:tx.chained=1,nolog"`,
+			args:        [][2]string{{"a", "1"}, {"b", "2"}, {"c", "3"}},
+			wantRuleID:  1,
+			wantStatus:  401,
+			wantMatched: []int{1},
+			wantTX:      map[string]string{"chained": "1"},
+		},
+		{
+			name: "chain link doesn't match",
+			conf: `SecRule ARGS:a "@streq 1" "id:1,phase:1,deny,setvar:tx.start=1,chain"
+SecRule ARGS:b "@streq 2" "setvar:tx.chained=1,nolog"`,
+			args:   [][2]string{{"a", "1"}, {"b", "3"}},
+			wantTX: map[string]string{"start": absent, "chained": absent},
+		},
+		{
+			name: "skipAfter",
+			conf: `SecAction "id:1,phase:1,pass,nolog,skipAfter:END"
+SecAction "id:2,phase:1,deny"
+SecMarker "END"
+SecAction "id:3,phase:1,setvar:tx.after=1,pass,nolog"`,
+			wantMatched: []int{1, 3},
+			wantTX:      map[string]string{"after": "1"},
+		},
+		{
+			name: "skipAfter only skips rules of its own phase",
+			conf: `SecAction "id:1,phase:1,pass,nolog,skipAfter:END"
+SecAction "id:2,phase:1,setvar:tx.skipped=1,pass,nolog"
+SecAction "id:3,phase:2,setvar:tx.phase2=1,pass,nolog"
+SecMarker "END"
+SecAction "id:4,phase:2,setvar:tx.after=1,pass,nolog"`,
+			wantMatched: []int{1, 3, 4},
+			wantTX:      map[string]string{"skipped": absent, "phase2": "1", "after": "1"},
+		},
+		{
+			name: "setvar",
+			conf: `SecAction "id:1,phase:1,setvar:tx.a=5,setvar:tx.b=+3,setvar:tx.a=-2,setvar:tx.c=1,setvar:!tx.c,setvar:tx.flag,pass,nolog"
+SecAction "id:2,phase:1,setvar:'tx.d=%{tx.a}',setvar:tx.b=+1,pass,nolog"`,
+			wantTX: map[string]string{"a": "3", "b": "4", "c": absent, "flag": "1", "d": "3"},
+		},
+		{
+			name:   "capture",
+			conf:   `SecRule ARGS:q "@rx (a+)(b)(c)" "id:1,phase:1,pass,capture,nolog"`,
+			args:   [][2]string{{"q", "xaabcx"}},
+			wantTX: map[string]string{"0": "aabc", "1": "aa", "2": "b", "3": "c", "4": absent},
+		},
+		{
+			name: "capture is cleared by the next match",
+			conf: `SecRule ARGS:q "@rx (a+)(b)(c)" "id:1,phase:1,pass,capture,nolog"
+SecRule ARGS:r "@rx (x)" "id:2,phase:1,pass,capture,nolog"`,
+			args:   [][2]string{{"q", "aabc"}, {"r", "x"}},
+			wantTX: map[string]string{"0": "x", "1": "x", "2": absent, "3": absent},
+		},
+		{
+			name:   "no capture without the capture action",
+			conf:   `SecRule ARGS:q "@rx (a+)" "id:1,phase:1,pass,nolog"`,
+			args:   [][2]string{{"q", "aa"}},
+			wantTX: map[string]string{"0": absent, "1": absent},
+		},
+		{
+			name:        "transformed value without multiMatch",
+			conf:        `SecRule ARGS:q "@streq ABC" "id:1,phase:1,t:lowercase,deny"`,
+			args:        [][2]string{{"q", "ABC"}},
+			wantMatched: []int{},
+		},
+		{
+			name:        "multiMatch matches the value before the transformation",
+			conf:        `SecRule ARGS:q "@streq ABC" "id:1,phase:1,t:lowercase,multiMatch,deny"`,
+			args:        [][2]string{{"q", "ABC"}},
+			wantRuleID:  1,
+			wantStatus:  403,
+			wantMatched: []int{1},
+		},
+		{
+			name:        "transformation pipeline",
+			conf:        `SecRule ARGS:q "@streq select*from" "id:1,phase:1,t:urlDecode,t:lowercase,t:removeWhitespace,deny"`,
+			args:        [][2]string{{"q", "SELECT%20*%20FROM"}},
+			wantRuleID:  1,
+			wantStatus:  403,
+			wantMatched: []int{1},
+		},
+		{
+			name: "t:none removes the default transformations",
+			conf: `SecDefaultAction "phase:2,pass,t:lowercase"
+SecRule ARGS:q "@streq ABC" "id:1,phase:2,setvar:tx.default=1,pass,nolog"
+SecRule ARGS:q "@streq ABC" "id:2,phase:2,t:none,setvar:tx.none=1,pass,nolog"`,
+			args:   [][2]string{{"q", "ABC"}},
+			wantTX: map[string]string{"default": absent, "none": "1"},
+		},
+		{
+			name: "block uses SecDefaultAction",
+			conf: `SecDefaultAction "phase:2,log,deny,status:406"
+SecRule ARGS:q "@streq attack" "id:1,phase:2,block"`,
+			args:        [][2]string{{"q", "attack"}},
+			wantRuleID:  1,
+			wantStatus:  406,
+			wantMatched: []int{1},
+		},
+		{
+			name:        "block without SecDefaultAction passes",
+			conf:        `SecRule ARGS:q "@streq attack" "id:1,phase:2,block"`,
+			args:        [][2]string{{"q", "attack"}},
+			wantMatched: []int{1},
+		},
+		{
+			name: "block uses the defaults of its own phase",
+			conf: `SecDefaultAction "phase:2,log,deny"
+SecRule ARGS:q "@streq attack" "id:1,phase:1,block"`,
+			args:        [][2]string{{"q", "attack"}},
+			wantMatched: []int{1},
+		},
+		{
+			name: "ctl:ruleRemoveById",
+			conf: `SecAction "id:1,phase:1,ctl:ruleRemoveById=2,pass,nolog"
+SecAction "id:2,phase:1,deny"
+SecAction "id:3,phase:2,ctl:ruleRemoveById=10-20,pass,nolog"
+SecAction "id:15,phase:2,deny"
+SecAction "id:21,phase:2,setvar:tx.kept=1,pass,nolog"`,
+			wantMatched: []int{1, 3, 21},
+			wantTX:      map[string]string{"kept": "1"},
+		},
+		{
+			name:        "DetectionOnly",
+			conf:        "SecRuleEngine DetectionOnly\n" + `SecAction "id:1,phase:1,deny"` + "\n" + `SecAction "id:2,phase:2,deny"`,
+			wantMatched: []int{1, 2},
+		},
+		{
+			name:        "no rules are evaluated after an intervention",
+			conf:        `SecAction "id:1,phase:1,deny,status:418"` + "\n" + `SecAction "id:2,phase:2,setvar:tx.after=1,pass,nolog"`,
+			wantRuleID:  1,
+			wantStatus:  418,
+			wantMatched: []int{1},
+			wantTX:      map[string]string{"after": absent},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			doc, err := parser.Parse("rules.conf", test.conf+"\n")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			rs, err := NewRuleset(doc)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			tx := rs.NewTransaction()
+			for _, arg := range test.args {
+				tx.Collection("ARGS").Add(arg[0], arg[1])
+			}
+
+			lastPhase := test.phase
+			if lastPhase == 0 {
+				lastPhase = PhaseRequestBody
+			}
+
+			var iv *Intervention
+			for phase := PhaseRequestHeaders; phase <= lastPhase; phase++ {
+				iv = tx.ProcessPhase(phase)
+			}
+
+			if test.wantRuleID == 0 && iv != nil {
+				t.Errorf("got intervention %+v, want none", iv)
+			}
+
+			if test.wantRuleID != 0 && (iv == nil || iv.RuleID != test.wantRuleID || iv.Status != test.wantStatus) {
+				t.Errorf("got intervention %+v, want rule %d with status %d", iv, test.wantRuleID, test.wantStatus)
+			}
+
+			if test.wantMatched != nil {
+				matched := []int{}
+				for _, rule := range tx.MatchedRules() {
+					matched = append(matched, rule.ID)
+				}
+
+				if fmt.Sprint(matched) != fmt.Sprint(test.wantMatched) {
+					t.Errorf("got matched rules %v, want %v", matched, test.wantMatched)
+				}
+			}
+
+			txColl := tx.Collection("TX")
+			for key, want := range test.wantTX {
+				got, found := txColl.First(key)
+				if want == absent && found {
+					t.Errorf("got tx.%s '%s', want it to be absent", key, got)
+				}
+
+				if want != absent && got != want {
+					t.Errorf("got tx.%s '%s', want '%s'", key, got, want)
+				}
+			}
+		})
+	}
+}
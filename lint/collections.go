@@ -0,0 +1,195 @@
+package lint
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dylandreimerink/go-modsec-parser/ast"
+)
+
+//CollectionTable is a symbol table of the persistent and custom collections which are initialized in a document
+type CollectionTable struct {
+	//The index of the first directive which initializes a collection, by upper case collection name
+	Initialized map[string]int
+}
+
+//NewCollectionTable builds the collection table of the document, by registering every collection initialized by initcol
+func NewCollectionTable(doc *ast.Document) *CollectionTable {
+	table := &CollectionTable{
+		Initialized: map[string]int{},
+	}
+
+	for i, dir := range doc.Directives() {
+		for _, action := range directiveActions(dir) {
+			//TODO register SESSION, USER and RESOURCE once setsid, setuid and setrsc are supported
+			initcol, ok := action.(*ast.ActionInitcol)
+			if !ok {
+				continue
+			}
+
+			name, ok := staticString(initcol.Collection)
+			if !ok {
+				continue
+			}
+
+			name = strings.ToUpper(name)
+			if _, found := table.Initialized[name]; !found {
+				table.Initialized[name] = i
+			}
+		}
+	}
+
+	return table
+}
+
+//CheckCollections reports rules which use a persistent or custom collection which is never initialized,
+// or which is used before the first directive which initializes it.
+func CheckCollections(doc *ast.Document) []Diagnostic {
+	diagnostics := []Diagnostic{}
+
+	table := NewCollectionTable(doc)
+	names := ruleNames(doc)
+
+	for i, dir := range doc.Directives() {
+		rule, ok := dir.(*ast.DirectiveSecRule)
+		if !ok || rule.Variable == nil {
+			continue
+		}
+
+		for _, selector := range rule.Variable.VariableSelectors {
+			if !needsInitialization(selector.Variable) {
+				continue
+			}
+
+			name := selector.Variable.Name()
+
+			initIndex, found := table.Initialized[name]
+			if !found {
+				//The parser assumes every unknown variable is a custom collection, so a misspelled variable ends up here as well
+				if _, custom := selector.Variable.(*ast.VariableCustomCollection); custom {
+					if suggestion, ok := table.suggest(name); ok {
+						diagnostics = append(diagnostics, Diagnostic{
+							Severity: SeverityError,
+							Node:     selector.Variable,
+							Message:  fmt.Sprintf("%s uses unknown variable %s, did you mean %s?", names[dir], name, suggestion),
+						})
+
+						continue
+					}
+				}
+
+				diagnostics = append(diagnostics, Diagnostic{
+					Severity: SeverityError,
+					Node:     selector.Variable,
+					Message:  fmt.Sprintf("%s uses collection %s which is never initialized", names[dir], name),
+				})
+
+				continue
+			}
+
+			if initIndex > i {
+				diagnostics = append(diagnostics, Diagnostic{
+					Severity: SeverityWarning,
+					Node:     selector.Variable,
+					Message:  fmt.Sprintf("%s uses collection %s before it is initialized", names[dir], name),
+				})
+			}
+		}
+	}
+
+	return diagnostics
+}
+
+//suggest returns the known variable or initialized collection which is most likely meant by a misspelled name
+func (table *CollectionTable) suggest(name string) (string, bool) {
+	candidates := []string{}
+	for _, variable := range ast.KnownVariables() {
+		candidates = append(candidates, variable.Name())
+	}
+	for collection := range table.Initialized {
+		candidates = append(candidates, collection)
+	}
+
+	//Short names are only a typo if they differ by a single character, otherwise most short custom names would be a typo
+	maxDistance := 2
+	if len(name) <= 4 {
+		maxDistance = 1
+	}
+
+	suggestion := ""
+	best := maxDistance + 1
+	for _, candidate := range candidates {
+		distance := editDistance(name, candidate)
+		if distance < best || distance == best && candidate < suggestion {
+			suggestion = candidate
+			best = distance
+		}
+	}
+
+	return suggestion, best <= maxDistance
+}
+
+//editDistance returns the Levenshtein distance between a and b
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			current[j] = previous[j-1] + cost
+			if previous[j]+1 < current[j] {
+				current[j] = previous[j] + 1
+			}
+			if current[j-1]+1 < current[j] {
+				current[j] = current[j-1] + 1
+			}
+		}
+
+		previous, current = current, previous
+	}
+
+	return previous[len(b)]
+}
+
+//needsInitialization returns true if the variable is a collection which has to be initialized before it can be used
+func needsInitialization(variable ast.Variable) bool {
+	switch variable.(type) {
+	case *ast.VariableCustomCollection,
+		*ast.VariableGlobal,
+		*ast.VariableIP,
+		*ast.VariableResource,
+		*ast.VariableSession,
+		*ast.VariableUser:
+		return true
+	}
+
+	return false
+}
+
+//staticString returns the value of the string if it contains no macros
+func staticString(str *ast.ExpandableString) (string, bool) {
+	if str == nil {
+		return "", false
+	}
+
+	var value strings.Builder
+	for _, part := range str.Parts {
+		stringPart, ok := part.(*ast.StringPart)
+		if !ok {
+			return "", false
+		}
+
+		value.WriteString(stringPart.Value)
+	}
+
+	return value.String(), true
+}
@@ -0,0 +1,77 @@
+package lint_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dylandreimerink/go-modsec-parser/lint"
+	"github.com/dylandreimerink/go-modsec-parser/parser"
+)
+
+func TestCheckCollections(t *testing.T) {
+	tests := []struct {
+		name string
+		conf string
+
+		//The severity and a part of the message of every expected diagnostic
+		want []lint.Diagnostic
+	}{
+		{
+			name: "initialized custom collection",
+			conf: `SecAction "id:1,phase:1,pass,nolog,initcol:myapp=%{REMOTE_ADDR}"
+SecRule MYAPP:counter "@gt 10" "id:2,phase:1,deny"`,
+		},
+		{
+			name: "used before initialization",
+			conf: `SecRule IP:counter "@gt 10" "id:1,phase:1,deny"
+SecAction "id:2,phase:1,pass,nolog,initcol:ip=%{REMOTE_ADDR}"`,
+			want: []lint.Diagnostic{{Severity: lint.SeverityWarning, Message: "SecRule 1 uses collection IP before it is initialized"}},
+		},
+		{
+			name: "never initialized",
+			conf: `SecRule SESSION:counter "@gt 10" "id:1,phase:1,deny"`,
+			want: []lint.Diagnostic{{Severity: lint.SeverityError, Message: "SecRule 1 uses collection SESSION which is never initialized"}},
+		},
+		{
+			name: "custom collection never initialized",
+			conf: `SecRule MYAPP:counter "@gt 10" "id:1,phase:1,deny"`,
+			want: []lint.Diagnostic{{Severity: lint.SeverityError, Message: "SecRule 1 uses collection MYAPP which is never initialized"}},
+		},
+		{
+			name: "misspelled variable",
+			conf: `SecRule REQUEST_HEADRES:Host "@rx x" "id:1,phase:1,deny"`,
+			want: []lint.Diagnostic{{Severity: lint.SeverityError, Message: "SecRule 1 uses unknown variable REQUEST_HEADRES, did you mean REQUEST_HEADERS?"}},
+		},
+		{
+			name: "misspelled short variable",
+			conf: `SecRule ARG "@rx x" "id:1,phase:1,deny"`,
+			want: []lint.Diagnostic{{Severity: lint.SeverityError, Message: "SecRule 1 uses unknown variable ARG, did you mean ARGS?"}},
+		},
+		{
+			name: "misspelled custom collection",
+			conf: `SecAction "id:1,phase:1,pass,nolog,initcol:myapp=%{REMOTE_ADDR}"
+SecRule MYAP:counter "@gt 10" "id:2,phase:1,deny"`,
+			want: []lint.Diagnostic{{Severity: lint.SeverityError, Message: "SecRule 2 uses unknown variable MYAP, did you mean MYAPP?"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			doc, err := parser.Parse("collections.conf", test.conf)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			diagnostics := lint.CheckCollections(doc)
+			if len(diagnostics) != len(test.want) {
+				t.Fatalf("got diagnostics %v, want %v", diagnostics, test.want)
+			}
+
+			for i, want := range test.want {
+				if diagnostics[i].Severity != want.Severity || !strings.Contains(diagnostics[i].Message, want.Message) {
+					t.Errorf("got diagnostic '%s', want '%s'", diagnostics[i], want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package lint
+
+import (
+	"fmt"
+
+	"github.com/dylandreimerink/go-modsec-parser/ast"
+)
+
+//Dialect is the ModSecurity version and server a config is written for
+type Dialect int
+
+const (
+	//DialectV2Apache is ModSecurity v2 running as Apache module, it supports all v2 features
+	DialectV2Apache Dialect = iota + 1
+
+	//DialectV2Standalone is ModSecurity v2 running in standalone mode, which is used for Nginx and IIS
+	DialectV2Standalone
+
+	//DialectV3 is ModSecurity v3 (libmodsecurity) with any connector
+	DialectV3
+)
+
+func (d Dialect) String() string {
+	switch d {
+	case DialectV2Apache:
+		return "ModSecurity v2 (Apache)"
+	case DialectV2Standalone:
+		return "ModSecurity v2 (standalone)"
+	case DialectV3:
+		return "ModSecurity v3"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+//CheckDialect returns a check which reports the variables used by rules which are not available in the given dialect
+func CheckDialect(dialect Dialect) Check {
+	return func(doc *ast.Document) []Diagnostic {
+		diagnostics := []Diagnostic{}
+
+		names := ruleNames(doc)
+		for _, dir := range doc.Directives() {
+			rule, ok := dir.(*ast.DirectiveSecRule)
+			if !ok || rule.Variable == nil {
+				continue
+			}
+
+			for _, selector := range rule.Variable.VariableSelectors {
+				variable, ok := selector.Variable.(ast.RestrictedVariable)
+				if !ok {
+					continue
+				}
+
+				availability := variable.Availability()
+				if (dialect == DialectV3 && availability.UnsupportedV3) ||
+					(dialect != DialectV2Apache && availability.ApacheOnly) {
+					diagnostics = append(diagnostics, Diagnostic{
+						Severity: SeverityWarning,
+						Node:     variable,
+						Message:  fmt.Sprintf("%s uses %s which is not available in %s", names[dir], variable.Name(), dialect),
+					})
+				}
+			}
+		}
+
+		return diagnostics
+	}
+}
@@ -0,0 +1,47 @@
+package lint
+
+import (
+	"fmt"
+
+	"github.com/dylandreimerink/go-modsec-parser/ast"
+)
+
+//CheckRequiredDirectories reports rules which depend on a directory directive which is missing from the document.
+// The initcol action requires SecDataDir to store persistent collections and the @inspectFile operator requires
+// SecTmpDir or SecUploadDir as location for the uploaded files which it inspects.
+func CheckRequiredDirectories(doc *ast.Document) []Diagnostic {
+	diagnostics := []Diagnostic{}
+
+	hasDataDir := hasDirective(doc, (&ast.DirectiveSecDataDir{}).Name())
+	hasUploadDir := hasDirective(doc, (&ast.DirectiveSecTmpDir{}).Name()) ||
+		hasDirective(doc, (&ast.DirectiveSecUploadDir{}).Name())
+
+	names := ruleNames(doc)
+	for _, dir := range doc.Directives() {
+		if rule, ok := dir.(*ast.DirectiveSecRule); ok && !hasUploadDir {
+			if op, ok := rule.Operator.(*ast.OperatorInspectFile); ok {
+				diagnostics = append(diagnostics, Diagnostic{
+					Severity: SeverityError,
+					Node:     op,
+					Message:  fmt.Sprintf("%s uses @inspectFile but neither SecTmpDir nor SecUploadDir is configured", names[dir]),
+				})
+			}
+		}
+
+		if hasDataDir {
+			continue
+		}
+
+		for _, action := range directiveActions(dir) {
+			if initcol, ok := action.(*ast.ActionInitcol); ok {
+				diagnostics = append(diagnostics, Diagnostic{
+					Severity: SeverityError,
+					Node:     initcol,
+					Message:  fmt.Sprintf("%s uses initcol but SecDataDir is not configured", names[dir]),
+				})
+			}
+		}
+	}
+
+	return diagnostics
+}
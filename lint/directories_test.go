@@ -0,0 +1,67 @@
+package lint_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dylandreimerink/go-modsec-parser/lint"
+	"github.com/dylandreimerink/go-modsec-parser/parser"
+)
+
+func TestCheckRequiredDirectories(t *testing.T) {
+	tests := []struct {
+		name string
+		conf string
+
+		//The severity and a part of the message of every expected diagnostic
+		want []lint.Diagnostic
+	}{
+		{
+			name: "inspectFile with upload dir",
+			conf: `SecUploadDir /tmp
+SecRule FILES_TMPNAMES "@inspectFile /usr/bin/scan" "id:1,phase:2,deny"`,
+		},
+		{
+			name: "inspectFile without upload dir",
+			conf: `SecRule FILES_TMPNAMES "@inspectFile /usr/bin/scan" "id:1,phase:2,deny"`,
+			want: []lint.Diagnostic{{Severity: lint.SeverityError, Message: "SecRule 1 uses @inspectFile but neither SecTmpDir nor SecUploadDir is configured"}},
+		},
+		{
+			//Chained rules have no id, they are reported with the id of the first rule of the chain
+			name: "inspectFile in a chain",
+			conf: `SecRule REQUEST_METHOD "@streq POST" "id:1,phase:2,deny,chain"
+	SecRule FILES_NAMES "@rx ." "chain"
+	SecRule FILES_TMPNAMES "@inspectFile /usr/bin/scan" "t:none"
+SecRule FILES_TMPNAMES "@inspectFile /usr/bin/scan" "id:2,phase:2,deny"`,
+			want: []lint.Diagnostic{
+				{Severity: lint.SeverityError, Message: "SecRule 1 uses @inspectFile"},
+				{Severity: lint.SeverityError, Message: "SecRule 2 uses @inspectFile"},
+			},
+		},
+		{
+			name: "initcol without data dir",
+			conf: `SecAction "id:1,phase:1,pass,nolog,initcol:ip=%{REMOTE_ADDR}"`,
+			want: []lint.Diagnostic{{Severity: lint.SeverityError, Message: "SecAction 1 uses initcol but SecDataDir is not configured"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			doc, err := parser.Parse("directories.conf", test.conf)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			diagnostics := lint.CheckRequiredDirectories(doc)
+			if len(diagnostics) != len(test.want) {
+				t.Fatalf("got diagnostics %v, want %v", diagnostics, test.want)
+			}
+
+			for i, want := range test.want {
+				if diagnostics[i].Severity != want.Severity || !strings.Contains(diagnostics[i].Message, want.Message) {
+					t.Errorf("got diagnostic '%s', want '%s'", diagnostics[i], want)
+				}
+			}
+		})
+	}
+}
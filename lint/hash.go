@@ -0,0 +1,23 @@
+package lint
+
+import (
+	"github.com/dylandreimerink/go-modsec-parser/ast"
+)
+
+//CheckHashKey reports SecHashKey directives which use a hardcoded key instead of a random one.
+// A key in the config ends up in version control and backups, anyone who obtains it can forge the signatures of the hash engine.
+func CheckHashKey(doc *ast.Document) []Diagnostic {
+	diagnostics := []Diagnostic{}
+
+	for _, dir := range doc.Directives() {
+		if hashKey, ok := dir.(*ast.DirectiveSecHashKey); ok && !hashKey.Random() {
+			diagnostics = append(diagnostics, Diagnostic{
+				Severity: SeverityWarning,
+				Node:     hashKey,
+				Message:  "SecHashKey uses a hardcoded key, use 'Rand' to generate a random key at startup",
+			})
+		}
+	}
+
+	return diagnostics
+}
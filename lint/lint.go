@@ -0,0 +1,146 @@
+package lint
+
+import (
+	"fmt"
+
+	"github.com/dylandreimerink/go-modsec-parser/ast"
+)
+
+//Severity indicates how serious a issue found by a check is
+type Severity int
+
+const (
+	//SeverityInfo is used for issues which are not a problem but might be of interest
+	SeverityInfo Severity = iota + 1
+
+	//SeverityWarning is used for issues which will most likely cause unexpected behavior
+	SeverityWarning
+
+	//SeverityError is used for issues which will cause a config to be rejected or to not work at all
+	SeverityError
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityInfo:
+		return "info"
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+//Diagnostic describes a single issue found in a config document
+type Diagnostic struct {
+	Severity Severity
+
+	//The node which caused the issue
+	Node ast.Node
+
+	//A human readable description of the issue
+	Message string
+}
+
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%s: %s", d.Severity, d.Message)
+}
+
+//Check inspects a document and returns all issues it has found.
+// Checks assume the document contains the complete configuration, not just a part of it
+type Check func(doc *ast.Document) []Diagnostic
+
+//DefaultChecks are the checks which are executed by Lint if no checks are specified
+var DefaultChecks = []Check{
+	CheckCollections,
+	CheckHashKey,
+	CheckRegexSupport,
+	CheckRequiredDirectories,
+}
+
+//Lint runs the given checks against the document, if no checks are given the DefaultChecks are used.
+func Lint(doc *ast.Document, checks ...Check) []Diagnostic {
+	if len(checks) == 0 {
+		checks = DefaultChecks
+	}
+
+	diagnostics := []Diagnostic{}
+	for _, check := range checks {
+		diagnostics = append(diagnostics, check(doc)...)
+	}
+
+	return diagnostics
+}
+
+//hasDirective returns true if the document contains at least one directive with the given name
+func hasDirective(doc *ast.Document, name string) bool {
+	for _, dir := range doc.Directives() {
+		if dir.Name() == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+//directiveActions returns the actions of a SecRule or SecAction directive, or nil for any other directive
+func directiveActions(dir ast.Directive) []ast.Action {
+	switch dir := dir.(type) {
+	case *ast.DirectiveSecRule:
+		return dir.Actions()
+	case *ast.DirectiveSecAction:
+		return dir.Actions()
+	}
+
+	return nil
+}
+
+//ruleNames returns the description of every directive in the document which can be used in messages.
+// Rules which are chained to a previous rule are described by the id of the first rule of the chain, since only
+// the first rule of a chain has an id.
+func ruleNames(doc *ast.Document) map[ast.Directive]string {
+	names := map[ast.Directive]string{}
+
+	var chainStart ast.Directive
+	for _, dir := range doc.Directives() {
+		actions := directiveActions(dir)
+		if actions == nil {
+			names[dir] = ruleName(dir)
+			continue
+		}
+
+		if chainStart != nil {
+			names[dir] = ruleName(chainStart)
+		} else {
+			names[dir] = ruleName(dir)
+		}
+
+		chained := false
+		for _, action := range actions {
+			if _, ok := action.(*ast.ActionChain); ok {
+				chained = true
+			}
+		}
+
+		if !chained {
+			chainStart = nil
+		} else if chainStart == nil {
+			chainStart = dir
+		}
+	}
+
+	return names
+}
+
+//ruleName returns a description of the directive which can be used in messages, i.e. "SecRule 920100"
+func ruleName(dir ast.Directive) string {
+	for _, action := range directiveActions(dir) {
+		if id, ok := action.(*ast.ActionID); ok {
+			return fmt.Sprintf("%s %d", dir.Name(), id.Value)
+		}
+	}
+
+	return dir.Name() + " without id"
+}
@@ -0,0 +1,37 @@
+package lint
+
+import (
+	"errors"
+
+	"github.com/dylandreimerink/go-modsec-parser/ast"
+	"github.com/dylandreimerink/go-modsec-parser/operator"
+)
+
+//CheckRegexSupport reports rx operators with a valid PCRE pattern which the Go regexp package doesn't support, like
+// lookarounds and backreferences. The engine skips these rules unless a fallback regex engine is configured.
+func CheckRegexSupport(doc *ast.Document) []Diagnostic {
+	diagnostics := []Diagnostic{}
+
+	for _, dir := range doc.Directives() {
+		rule, ok := dir.(*ast.DirectiveSecRule)
+		if !ok {
+			continue
+		}
+
+		rx, ok := rule.Operator.(*ast.OperatorRegex)
+		if !ok {
+			continue
+		}
+
+		var unsupported *operator.UnsupportedPatternError
+		if _, err := operator.Compile(rx); errors.As(err, &unsupported) {
+			diagnostics = append(diagnostics, Diagnostic{
+				Severity: SeverityWarning,
+				Node:     rule,
+				Message:  unsupported.Error() + ", the rule is skipped unless a fallback regex engine is configured",
+			})
+		}
+	}
+
+	return diagnostics
+}
@@ -0,0 +1,32 @@
+package lint_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dylandreimerink/go-modsec-parser/lint"
+	"github.com/dylandreimerink/go-modsec-parser/parser"
+)
+
+func TestCheckRegexSupport(t *testing.T) {
+	doc, err := parser.Parse("regex.conf", `
+SecRule ARGS "@rx foo(?=bar)" "id:1,phase:1,deny"
+SecRule ARGS "@rx (a)\1" "id:2,phase:1,deny"
+SecRule ARGS "@rx ^admin$" "id:3,phase:1,deny"
+SecRule ARGS "@streq foo(?=bar)" "id:4,phase:1,deny"
+`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	diagnostics := lint.CheckRegexSupport(doc)
+	if len(diagnostics) != 2 {
+		t.Fatalf("got diagnostics %v, want 2", diagnostics)
+	}
+
+	for i, pattern := range []string{`foo(?=bar)`, `(a)\1`} {
+		if diagnostics[i].Severity != lint.SeverityWarning || !strings.Contains(diagnostics[i].Message, "'"+pattern+"'") {
+			t.Errorf("unexpected diagnostic %v for '%s'", diagnostics[i], pattern)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package lookup
+
+import (
+	"net"
+	"strconv"
+)
+
+//The keys of the GEO collection which are filled by a lookup
+// https://github.com/SpiderLabs/ModSecurity/wiki/Reference-Manual-(v2.x)#GEO
+const (
+	GeoCountryCode      = "COUNTRY_CODE"
+	GeoCountryName      = "COUNTRY_NAME"
+	GeoCountryContinent = "COUNTRY_CONTINENT"
+	GeoRegion           = "REGION"
+	GeoCity             = "CITY"
+	GeoPostalCode       = "POSTAL_CODE"
+	GeoLatitude         = "LATITUDE"
+	GeoLongitude        = "LONGITUDE"
+	GeoDMACode          = "DMA_CODE"
+)
+
+//GeoDB resolves IP addresses to the fields of the GEO collection using a MaxMind GeoIP2 or GeoLite2 database.
+// The legacy COUNTRY_CODE3 and AREA_CODE fields don't exist in these databases and are never set.
+type GeoDB struct {
+	db *MMDB
+}
+
+//OpenGeoDB opens the .mmdb file at path, usually the value of the SecGeoLookupDb directive
+func OpenGeoDB(path string) (*GeoDB, error) {
+	db, err := OpenMMDB(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewGeoDB(db), nil
+}
+
+//NewGeoDB creates a GeoDB from an already opened database
+func NewGeoDB(db *MMDB) *GeoDB {
+	return &GeoDB{db: db}
+}
+
+//Lookup returns the GEO collection for the IP address. false is returned if the database has no information about the address
+func (g *GeoDB) Lookup(ip net.IP) (map[string]string, bool, error) {
+	record, found, err := g.db.Lookup(ip)
+	if err != nil || !found {
+		return nil, false, err
+	}
+
+	geo := map[string]string{}
+
+	setGeoString(geo, GeoCountryCode, record, "country", "iso_code")
+	setGeoString(geo, GeoCountryName, record, "country", "names", "en")
+	setGeoString(geo, GeoCountryContinent, record, "continent", "code")
+	setGeoString(geo, GeoRegion, record, "subdivisions", 0, "iso_code")
+	setGeoString(geo, GeoCity, record, "city", "names", "en")
+	setGeoString(geo, GeoPostalCode, record, "postal", "code")
+	setGeoString(geo, GeoLatitude, record, "location", "latitude")
+	setGeoString(geo, GeoLongitude, record, "location", "longitude")
+	setGeoString(geo, GeoDMACode, record, "location", "metro_code")
+
+	//Databases like GeoLite2-ASN have none of the fields we are looking for
+	if len(geo) == 0 {
+		return nil, false, nil
+	}
+
+	return geo, true, nil
+}
+
+//setGeoString sets geo[key] to the value found by following the path of map keys and array indexes in the record
+func setGeoString(geo map[string]string, key string, record interface{}, path ...interface{}) {
+	value := record
+	for _, step := range path {
+		switch step := step.(type) {
+		case string:
+			m, ok := value.(map[string]interface{})
+			if !ok {
+				return
+			}
+
+			value = m[step]
+
+		case int:
+			a, ok := value.([]interface{})
+			if !ok || step >= len(a) {
+				return
+			}
+
+			value = a[step]
+		}
+	}
+
+	switch value := value.(type) {
+	case string:
+		geo[key] = value
+	case float64:
+		geo[key] = strconv.FormatFloat(value, 'f', -1, 64)
+	case float32:
+		geo[key] = strconv.FormatFloat(float64(value), 'f', -1, 32)
+	case uint64:
+		geo[key] = strconv.FormatUint(value, 10)
+	case int32:
+		geo[key] = strconv.FormatInt(int64(value), 10)
+	}
+}
@@ -0,0 +1,92 @@
+package lookup
+
+import (
+	"errors"
+	"net"
+	"testing"
+)
+
+func TestGeoDBLookup(t *testing.T) {
+	geo, err := OpenGeoDB("../testdata/geo.mmdb")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		ip        string
+		wantFound bool
+		want      map[string]string
+	}{
+		{
+			ip:        "81.2.69.142",
+			wantFound: true,
+			want: map[string]string{
+				GeoCountryCode:      "GB",
+				GeoCountryName:      "United Kingdom",
+				GeoCountryContinent: "EU",
+				GeoRegion:           "ENG",
+				GeoCity:             "London",
+				GeoPostalCode:       "EC1A",
+				GeoLatitude:         "51.5142",
+				GeoLongitude:        "-0.0931",
+			},
+		},
+		{
+			ip:        "89.160.20.1",
+			wantFound: true,
+			want: map[string]string{
+				GeoCountryCode:      "SE",
+				GeoCountryName:      "Sweden",
+				GeoCountryContinent: "EU",
+			},
+		},
+		{ip: "81.2.70.1"},
+		{ip: "127.0.0.1"},
+		{ip: "2001:db8::1"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.ip, func(t *testing.T) {
+			fields, found, err := geo.Lookup(net.ParseIP(test.ip))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if found != test.wantFound {
+				t.Fatalf("got found %t, want %t", found, test.wantFound)
+			}
+
+			if len(fields) != len(test.want) {
+				t.Errorf("got %v, want %v", fields, test.want)
+			}
+
+			for key, want := range test.want {
+				if fields[key] != want {
+					t.Errorf("got %s '%s', want '%s'", key, fields[key], want)
+				}
+			}
+		})
+	}
+}
+
+func TestMMDBMetadata(t *testing.T) {
+	db, err := OpenMMDB("../testdata/geo.mmdb")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if db.Metadata.DatabaseType != "GeoIP2-City-Test" || db.Metadata.IPVersion != 4 || db.Metadata.RecordSize != 24 {
+		t.Errorf("unexpected metadata %+v", db.Metadata)
+	}
+
+	if len(db.Metadata.Languages) != 1 || db.Metadata.Languages[0] != "en" {
+		t.Errorf("unexpected languages %v", db.Metadata.Languages)
+	}
+}
+
+func TestNewMMDBInvalid(t *testing.T) {
+	_, err := NewMMDB([]byte("not a database"))
+	if !errors.Is(err, ErrInvalidDatabase) {
+		t.Errorf("got error %v, want ErrInvalidDatabase", err)
+	}
+}
@@ -0,0 +1,412 @@
+package lookup
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"math/big"
+	"net"
+)
+
+//mmdbMetadataMarker marks the start of the metadata section at the end of the database
+// https://maxmind.github.io/MaxMind-DB/#database-metadata
+var mmdbMetadataMarker = []byte("\xAB\xCD\xEFMaxMind.com")
+
+//The data section is separated from the search tree by 16 zero bytes
+const mmdbDataSectionSeparatorSize = 16
+
+const (
+	mmdbTypeExtended  = 0
+	mmdbTypePointer   = 1
+	mmdbTypeString    = 2
+	mmdbTypeDouble    = 3
+	mmdbTypeBytes     = 4
+	mmdbTypeUint16    = 5
+	mmdbTypeUint32    = 6
+	mmdbTypeMap       = 7
+	mmdbTypeInt32     = 8
+	mmdbTypeUint64    = 9
+	mmdbTypeUint128   = 10
+	mmdbTypeArray     = 11
+	mmdbTypeContainer = 12
+	mmdbTypeEnd       = 13
+	mmdbTypeBool      = 14
+	mmdbTypeFloat     = 15
+)
+
+//ErrInvalidDatabase is returned when a database file is not a valid MaxMind DB file
+var ErrInvalidDatabase = errors.New("invalid MaxMind DB file")
+
+//MMDBMetadata contains the information from the metadata section of a MaxMind DB file
+type MMDBMetadata struct {
+	DatabaseType string
+	IPVersion    int
+	NodeCount    int
+	RecordSize   int
+	BuildEpoch   uint64
+	Languages    []string
+}
+
+//MMDB is a MaxMind DB file (.mmdb) which is fully loaded in memory.
+// The file format is described at https://maxmind.github.io/MaxMind-DB/
+type MMDB struct {
+	Metadata MMDBMetadata
+
+	tree []byte
+	data []byte
+
+	//The node at which IPv4 lookups in a IPv6 tree start
+	ipv4Start int
+}
+
+//OpenMMDB reads the MaxMind DB file at the given path
+func OpenMMDB(path string) (*MMDB, error) {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewMMDB(contents)
+}
+
+//NewMMDB parses the contents of a MaxMind DB file
+func NewMMDB(contents []byte) (*MMDB, error) {
+	markerIndex := bytes.LastIndex(contents, mmdbMetadataMarker)
+	if markerIndex == -1 {
+		return nil, fmt.Errorf("%w: metadata marker not found", ErrInvalidDatabase)
+	}
+
+	metadataDecoder := &mmdbDecoder{buf: contents[markerIndex+len(mmdbMetadataMarker):]}
+	rawMetadata, _, err := metadataDecoder.decode(0)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidDatabase, err)
+	}
+
+	metadataMap, ok := rawMetadata.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%w: metadata is not a map", ErrInvalidDatabase)
+	}
+
+	db := &MMDB{}
+	db.Metadata.DatabaseType, _ = metadataMap["database_type"].(string)
+	db.Metadata.IPVersion = int(toUint64(metadataMap["ip_version"]))
+	db.Metadata.NodeCount = int(toUint64(metadataMap["node_count"]))
+	db.Metadata.RecordSize = int(toUint64(metadataMap["record_size"]))
+	db.Metadata.BuildEpoch = toUint64(metadataMap["build_epoch"])
+	if languages, ok := metadataMap["languages"].([]interface{}); ok {
+		for _, language := range languages {
+			if language, ok := language.(string); ok {
+				db.Metadata.Languages = append(db.Metadata.Languages, language)
+			}
+		}
+	}
+
+	switch db.Metadata.RecordSize {
+	case 24, 28, 32:
+	default:
+		return nil, fmt.Errorf("%w: unsupported record size %d", ErrInvalidDatabase, db.Metadata.RecordSize)
+	}
+
+	if db.Metadata.IPVersion != 4 && db.Metadata.IPVersion != 6 {
+		return nil, fmt.Errorf("%w: unsupported IP version %d", ErrInvalidDatabase, db.Metadata.IPVersion)
+	}
+
+	treeSize := db.Metadata.RecordSize * 2 / 8 * db.Metadata.NodeCount
+	if treeSize+mmdbDataSectionSeparatorSize > markerIndex {
+		return nil, fmt.Errorf("%w: search tree is larger than the file", ErrInvalidDatabase)
+	}
+
+	db.tree = contents[:treeSize]
+	db.data = contents[treeSize+mmdbDataSectionSeparatorSize : markerIndex]
+
+	//IPv4 addresses are stored as ::a.b.c.d in IPv6 databases, so the first 96 bits are zero
+	if db.Metadata.IPVersion == 6 {
+		for i := 0; i < 96 && db.ipv4Start < db.Metadata.NodeCount; i++ {
+			db.ipv4Start = db.record(db.ipv4Start, 0)
+		}
+	}
+
+	return db, nil
+}
+
+//Lookup returns the data record for the IP address. The record is decoded into maps, slices and scalar values.
+// false is returned if the database contains no record for the IP address
+func (db *MMDB) Lookup(ip net.IP) (interface{}, bool, error) {
+	node := 0
+
+	ipv4 := ip.To4()
+	if ipv4 != nil {
+		ip = ipv4
+		node = db.ipv4Start
+	} else if db.Metadata.IPVersion == 4 {
+		//IPv6 addresses can't be looked up in a IPv4 only database
+		return nil, false, nil
+	}
+
+	bitCount := len(ip) * 8
+	for i := 0; i < bitCount && node < db.Metadata.NodeCount; i++ {
+		bit := (ip[i/8] >> (7 - uint(i%8))) & 1
+		node = db.record(node, int(bit))
+	}
+
+	if node == db.Metadata.NodeCount {
+		return nil, false, nil
+	}
+
+	if node < db.Metadata.NodeCount {
+		return nil, false, fmt.Errorf("%w: search tree is deeper than the address", ErrInvalidDatabase)
+	}
+
+	offset := node - db.Metadata.NodeCount - mmdbDataSectionSeparatorSize
+
+	decoder := &mmdbDecoder{buf: db.data}
+	value, _, err := decoder.decode(offset)
+	if err != nil {
+		return nil, false, fmt.Errorf("%w: %s", ErrInvalidDatabase, err)
+	}
+
+	return value, true, nil
+}
+
+//record returns the left (0) or right (1) record of the node
+func (db *MMDB) record(node, side int) int {
+	switch db.Metadata.RecordSize {
+	case 24:
+		b := db.tree[node*6+side*3:]
+		return int(b[0])<<16 | int(b[1])<<8 | int(b[2])
+
+	case 28:
+		b := db.tree[node*7:]
+		if side == 0 {
+			return int(b[3]&0xF0)<<20 | int(b[0])<<16 | int(b[1])<<8 | int(b[2])
+		}
+
+		return int(b[3]&0x0F)<<24 | int(b[4])<<16 | int(b[5])<<8 | int(b[6])
+
+	default:
+		return int(binary.BigEndian.Uint32(db.tree[node*8+side*4:]))
+	}
+}
+
+//mmdbDecoder decodes values from the data section (or the metadata section)
+// https://maxmind.github.io/MaxMind-DB/#output-data-section
+type mmdbDecoder struct {
+	buf []byte
+}
+
+//decode decodes the value at the offset and returns the value and the offset of the next value
+func (d *mmdbDecoder) decode(offset int) (interface{}, int, error) {
+	typ, size, offset, err := d.decodeControl(offset)
+	if err != nil {
+		return nil, offset, err
+	}
+
+	if typ == mmdbTypePointer {
+		pointer, next, err := d.decodePointer(size, offset)
+		if err != nil {
+			return nil, offset, err
+		}
+
+		value, _, err := d.decode(pointer)
+		return value, next, err
+	}
+
+	if offset+size > len(d.buf) && typ != mmdbTypeMap && typ != mmdbTypeArray && typ != mmdbTypeBool {
+		return nil, offset, fmt.Errorf("value at %d exceeds the data section", offset)
+	}
+
+	switch typ {
+	case mmdbTypeString:
+		return string(d.buf[offset : offset+size]), offset + size, nil
+
+	case mmdbTypeDouble:
+		if size != 8 {
+			return nil, offset, fmt.Errorf("invalid double size %d", size)
+		}
+
+		return math.Float64frombits(binary.BigEndian.Uint64(d.buf[offset:])), offset + size, nil
+
+	case mmdbTypeFloat:
+		if size != 4 {
+			return nil, offset, fmt.Errorf("invalid float size %d", size)
+		}
+
+		return math.Float32frombits(binary.BigEndian.Uint32(d.buf[offset:])), offset + size, nil
+
+	case mmdbTypeBytes:
+		value := make([]byte, size)
+		copy(value, d.buf[offset:offset+size])
+		return value, offset + size, nil
+
+	case mmdbTypeUint16, mmdbTypeUint32, mmdbTypeUint64:
+		if size > 8 {
+			return nil, offset, fmt.Errorf("invalid unsigned integer size %d", size)
+		}
+
+		var value uint64
+		for _, b := range d.buf[offset : offset+size] {
+			value = value<<8 | uint64(b)
+		}
+
+		return value, offset + size, nil
+
+	case mmdbTypeUint128:
+		if size > 16 {
+			return nil, offset, fmt.Errorf("invalid unsigned integer size %d", size)
+		}
+
+		return new(big.Int).SetBytes(d.buf[offset : offset+size]), offset + size, nil
+
+	case mmdbTypeInt32:
+		if size > 4 {
+			return nil, offset, fmt.Errorf("invalid signed integer size %d", size)
+		}
+
+		var value uint32
+		for _, b := range d.buf[offset : offset+size] {
+			value = value<<8 | uint32(b)
+		}
+
+		return int32(value), offset + size, nil
+
+	case mmdbTypeBool:
+		if size > 1 {
+			return nil, offset, fmt.Errorf("invalid boolean value %d", size)
+		}
+
+		return size == 1, offset, nil
+
+	case mmdbTypeMap:
+		value := make(map[string]interface{}, size)
+		for i := 0; i < size; i++ {
+			var key, val interface{}
+			key, offset, err = d.decode(offset)
+			if err != nil {
+				return nil, offset, err
+			}
+
+			keyString, ok := key.(string)
+			if !ok {
+				return nil, offset, fmt.Errorf("map key at %d is not a string", offset)
+			}
+
+			val, offset, err = d.decode(offset)
+			if err != nil {
+				return nil, offset, err
+			}
+
+			value[keyString] = val
+		}
+
+		return value, offset, nil
+
+	case mmdbTypeArray:
+		value := make([]interface{}, 0, size)
+		for i := 0; i < size; i++ {
+			var val interface{}
+			val, offset, err = d.decode(offset)
+			if err != nil {
+				return nil, offset, err
+			}
+
+			value = append(value, val)
+		}
+
+		return value, offset, nil
+
+	default:
+		return nil, offset, fmt.Errorf("unsupported data type %d at %d", typ, offset)
+	}
+}
+
+//decodeControl decodes the control byte(s) at the offset and returns the type, the payload size and the offset of the payload
+func (d *mmdbDecoder) decodeControl(offset int) (int, int, int, error) {
+	if offset < 0 || offset >= len(d.buf) {
+		return 0, 0, offset, fmt.Errorf("offset %d is outside of the data section", offset)
+	}
+
+	control := d.buf[offset]
+	offset++
+
+	typ := int(control >> 5)
+
+	//Pointers use the size bits in their own way
+	if typ == mmdbTypePointer {
+		return typ, int(control & 0x1F), offset, nil
+	}
+
+	if typ == mmdbTypeExtended {
+		if offset >= len(d.buf) {
+			return 0, 0, offset, fmt.Errorf("unexpected end of data at %d", offset)
+		}
+
+		typ = 7 + int(d.buf[offset])
+		offset++
+	}
+
+	size := int(control & 0x1F)
+	if size < 29 {
+		return typ, size, offset, nil
+	}
+
+	extraBytes := size - 28
+	if offset+extraBytes > len(d.buf) {
+		return 0, 0, offset, fmt.Errorf("unexpected end of data at %d", offset)
+	}
+
+	extra := 0
+	for _, b := range d.buf[offset : offset+extraBytes] {
+		extra = extra<<8 | int(b)
+	}
+
+	switch size {
+	case 29:
+		size = 29 + extra
+	case 30:
+		size = 285 + extra
+	default:
+		size = 65821 + extra
+	}
+
+	return typ, size, offset + extraBytes, nil
+}
+
+//decodePointer decodes a pointer and returns the offset it points to and the offset of the next value
+func (d *mmdbDecoder) decodePointer(sizeBits, offset int) (int, int, error) {
+	pointerSize := ((sizeBits >> 3) & 0x3) + 1
+	if offset+pointerSize > len(d.buf) {
+		return 0, offset, fmt.Errorf("unexpected end of data at %d", offset)
+	}
+
+	pointer := 0
+	if pointerSize != 4 {
+		pointer = sizeBits & 0x7
+	}
+
+	for _, b := range d.buf[offset : offset+pointerSize] {
+		pointer = pointer<<8 | int(b)
+	}
+
+	switch pointerSize {
+	case 2:
+		pointer += 2048
+	case 3:
+		pointer += 526336
+	}
+
+	return pointer, offset + pointerSize, nil
+}
+
+func toUint64(value interface{}) uint64 {
+	switch value := value.(type) {
+	case uint64:
+		return value
+	case int32:
+		return uint64(value)
+	}
+
+	return 0
+}
@@ -0,0 +1,121 @@
+//Package macro expands the macros in expandable strings, like %{tx.anomaly_score} or %{MATCHED_VAR}
+package macro
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/dylandreimerink/go-modsec-parser/ast"
+)
+
+//ErrUnresolved is returned by Expand if a macro can't be resolved
+var ErrUnresolved = errors.New("Unable to resolve macro")
+
+//Resolver supplies the values of the variables and collection members which are referenced by macros
+type Resolver interface {
+	//ResolveMacro returns the value of the macro, false is returned if the variable doesn't exist
+	ResolveMacro(macro *ast.StringMacro) (string, bool)
+}
+
+//ResolverFunc is a function which implements the Resolver interface
+type ResolverFunc func(macro *ast.StringMacro) (string, bool)
+
+//ResolveMacro calls f(macro)
+func (f ResolverFunc) ResolveMacro(macro *ast.StringMacro) (string, bool) {
+	return f(macro)
+}
+
+//Lenient returns a resolver which resolves macros which can't be resolved by r to an empty string, like ModSecurity does.
+// Expanding with a lenient resolver never returns an error. r may be nil, in which case all macros are empty.
+func Lenient(r Resolver) Resolver {
+	return ResolverFunc(func(macro *ast.StringMacro) (string, bool) {
+		if r == nil {
+			return "", true
+		}
+
+		value, _ := r.ResolveMacro(macro)
+		return value, true
+	})
+}
+
+//Expand returns the string with all macros replaced by their values. Expand is strict, if a macro can't be resolved
+// an error wrapping ErrUnresolved is returned. Use a Lenient resolver to expand like ModSecurity does.
+func Expand(es *ast.ExpandableString, r Resolver) (string, error) {
+	if es == nil {
+		return "", nil
+	}
+
+	var value strings.Builder
+	for _, part := range es.Parts {
+		switch part := part.(type) {
+		case *ast.StringPart:
+			value.WriteString(part.Value)
+
+		case *ast.StringMacro:
+			var (
+				resolved string
+				ok       bool
+			)
+
+			if r != nil {
+				resolved, ok = r.ResolveMacro(part)
+			}
+
+			if !ok {
+				return "", fmt.Errorf("%w '%s'", ErrUnresolved, Name(part))
+			}
+
+			value.WriteString(resolved)
+		}
+	}
+
+	return value.String(), nil
+}
+
+//Name returns the name of the macro as it is written in the rule without %{}, i.e. "tx.anomaly_score" or "MATCHED_VAR"
+func Name(macro *ast.StringMacro) string {
+	if macro.Collection == "" {
+		return macro.Variable
+	}
+
+	return macro.Collection + "." + macro.Variable
+}
+
+//Dependencies returns every macro used in the node and its children, for example all macros a rule depends on.
+// Macros with the same case insensitive name are only returned once, in the order they are first used.
+func Dependencies(node ast.Node) []*ast.StringMacro {
+	macros := []*ast.StringMacro{}
+	seen := map[string]bool{}
+
+	var walk func(node ast.Node)
+	walk = func(node ast.Node) {
+		//Optional child nodes are nil pointers
+		if node == nil {
+			return
+		}
+
+		if value := reflect.ValueOf(node); value.Kind() == reflect.Ptr && value.IsNil() {
+			return
+		}
+
+		if macro, ok := node.(*ast.StringMacro); ok {
+			name := strings.ToLower(Name(macro))
+			if !seen[name] {
+				seen[name] = true
+				macros = append(macros, macro)
+			}
+
+			return
+		}
+
+		for _, child := range node.Children() {
+			walk(child)
+		}
+	}
+
+	walk(node)
+
+	return macros
+}
@@ -0,0 +1,110 @@
+package macro_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/dylandreimerink/go-modsec-parser/ast"
+	"github.com/dylandreimerink/go-modsec-parser/macro"
+	"github.com/dylandreimerink/go-modsec-parser/parser"
+)
+
+//testResolver resolves the macros from a map with case insensitive names
+type testResolver map[string]string
+
+func (r testResolver) ResolveMacro(m *ast.StringMacro) (string, bool) {
+	value, ok := r[strings.ToLower(macro.Name(m))]
+	return value, ok
+}
+
+func expandable(parts ...ast.ExpandableStringPart) *ast.ExpandableString {
+	return &ast.ExpandableString{Parts: parts}
+}
+
+func TestExpand(t *testing.T) {
+	resolver := testResolver{
+		"tx.anomaly_score": "5",
+		"matched_var":      "<script>",
+		"tx.empty":         "",
+	}
+
+	tests := []struct {
+		name     string
+		es       *ast.ExpandableString
+		resolver macro.Resolver
+
+		want    string
+		wantErr bool
+	}{
+		{name: "nil string", es: nil, resolver: resolver, want: ""},
+		{name: "no macros", es: expandable(&ast.StringPart{Value: "plain"}), resolver: resolver, want: "plain"},
+		{
+			name:     "collection and variable",
+			es:       expandable(&ast.StringPart{Value: "score "}, &ast.StringMacro{Collection: "TX", Variable: "anomaly_score"}, &ast.StringPart{Value: " on "}, &ast.StringMacro{Variable: "MATCHED_VAR"}),
+			resolver: resolver,
+			want:     "score 5 on <script>",
+		},
+		{name: "empty value", es: expandable(&ast.StringMacro{Collection: "tx", Variable: "empty"}), resolver: resolver, want: ""},
+		{name: "unresolved", es: expandable(&ast.StringPart{Value: "a"}, &ast.StringMacro{Collection: "tx", Variable: "missing"}), resolver: resolver, wantErr: true},
+		{name: "nil resolver", es: expandable(&ast.StringMacro{Variable: "MATCHED_VAR"}), resolver: nil, wantErr: true},
+		{name: "lenient", es: expandable(&ast.StringPart{Value: "a"}, &ast.StringMacro{Collection: "tx", Variable: "missing"}, &ast.StringMacro{Variable: "MATCHED_VAR"}), resolver: macro.Lenient(resolver), want: "a<script>"},
+		{name: "lenient nil resolver", es: expandable(&ast.StringPart{Value: "a"}, &ast.StringMacro{Variable: "MATCHED_VAR"}), resolver: macro.Lenient(nil), want: "a"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := macro.Expand(test.es, test.resolver)
+			if test.wantErr {
+				if !errors.Is(err, macro.ErrUnresolved) {
+					t.Fatalf("got error %v, want ErrUnresolved", err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got != test.want {
+				t.Errorf("got '%s', want '%s'", got, test.want)
+			}
+		})
+	}
+}
+
+func TestDependencies(t *testing.T) {
+	tests := []struct {
+		name string
+		rule string
+		want []string
+	}{
+		{name: "none", rule: `SecRule ARGS "@rx attack" "id:1,deny"`, want: []string{}},
+		{name: "operator", rule: `SecRule REQUEST_HEADERS:Host "@streq %{tx.allowed_host}" "id:1,pass"`, want: []string{"tx.allowed_host"}},
+		{
+			name: "actions in order",
+			rule: `SecRule ARGS "@rx attack" "id:1,msg:'%{MATCHED_VAR_NAME} matched',logdata:'%{MATCHED_VAR}',setvar:'tx.score=+%{tx.critical_score}',pass"`,
+			want: []string{"MATCHED_VAR_NAME", "MATCHED_VAR", "tx.critical_score"},
+		},
+		{name: "case insensitive duplicates", rule: `SecRule ARGS "@streq %{TX.a}" "id:1,msg:'%{tx.A}',pass"`, want: []string{"TX.a"}},
+		{name: "expirevar ttl", rule: `SecAction "id:1,expirevar:'ip.block=%{tx.block_timeout}',pass"`, want: []string{"tx.block_timeout"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			doc, err := parser.Parse("macro.conf", test.rule)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			got := []string{}
+			for _, m := range macro.Dependencies(doc.Directives()[0]) {
+				got = append(got, macro.Name(m))
+			}
+
+			if strings.Join(got, ",") != strings.Join(test.want, ",") {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 
 	"github.com/davecgh/go-spew/spew"
+	"github.com/dylandreimerink/go-modsec-parser/parser"
 )
 
 func main() {
@@ -24,9 +25,8 @@ func main() {
 		panic(err)
 	}
 
-	lexer := lex(filename, string(input))
-	doc, err := parseDocument(lexer)
-	// _, err = parseDocument(lexer)
+	doc, err := parser.Parse(filename, string(input))
+	// _, err = parser.Parse(filename, string(input))
 	// if err == nil {
 	spew.Dump(doc)
 	// }
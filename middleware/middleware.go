@@ -0,0 +1,314 @@
+//Package middleware provides net/http middleware which enforces a ModSecurity ruleset without a web server module.
+// Phases 1 and 2 are evaluated before the handler is called, phases 3 and 4 on the response of the handler before
+// it is sent and phase 5 after the response has been sent. The response body is only buffered if it is inspected.
+package middleware
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dylandreimerink/go-modsec-parser/ast"
+	"github.com/dylandreimerink/go-modsec-parser/engine"
+	"github.com/dylandreimerink/go-modsec-parser/request"
+)
+
+//AuditEntry is the result of a transaction which is passed to the audit log hook
+type AuditEntry struct {
+	Request *http.Request
+
+	//The status of the response which was sent to the client, 0 if the connection was dropped
+	Status int
+
+	MatchedRules []*engine.MatchedRule
+
+	//The intervention which was applied, nil if the request was passed to the handler
+	Intervention *engine.Intervention
+
+	//The error which occurred during inspection, if any
+	Err error
+}
+
+//Middleware is a http.Handler which inspects requests and responses of the next handler
+type Middleware struct {
+	next    http.Handler
+	ruleset *engine.Ruleset
+
+	rulesetOptions []engine.Option
+	requestOptions []request.Option
+
+	requestBodyAccess       bool
+	responseBodyAccess      bool
+	responseBodyLimit       ast.BodyLimit
+	responseBodyLimitAction ast.BodyLimitAction
+	responseBodyMimeTypes   []string
+
+	failOpen bool
+	auditLog func(AuditEntry)
+}
+
+//Option changes the behaviour of the middleware
+type Option func(*Middleware)
+
+//WithFailOpen makes the middleware pass requests to the handler without inspection if an error occurs during inspection,
+// for example if the request body can't be read. By default the middleware fails closed and responds with status 500.
+func WithFailOpen() Option {
+	return func(m *Middleware) {
+		m.failOpen = true
+	}
+}
+
+//WithAuditLog sets a function which is called after every transaction, after phase 5 has been evaluated
+func WithAuditLog(auditLog func(AuditEntry)) Option {
+	return func(m *Middleware) {
+		m.auditLog = auditLog
+	}
+}
+
+//WithRulesetOptions sets the options which are used to compile the ruleset
+func WithRulesetOptions(opts ...engine.Option) Option {
+	return func(m *Middleware) {
+		m.rulesetOptions = append(m.rulesetOptions, opts...)
+	}
+}
+
+//WithRequestOptions sets additional options for the request mapping and body processing,
+// they are applied after the options derived from the directives of the document
+func WithRequestOptions(opts ...request.Option) Option {
+	return func(m *Middleware) {
+		m.requestOptions = append(m.requestOptions, opts...)
+	}
+}
+
+//New compiles the rules of the document and returns middleware which enforces them on the next handler.
+// SecRequestBodyAccess, SecResponseBodyAccess, SecResponseBodyLimit, SecResponseBodyLimitAction and
+// SecResponseBodyMimeType are taken from the document, with the same defaults as ModSecurity.
+func New(doc *ast.Document, next http.Handler, opts ...Option) (*Middleware, error) {
+	m := &Middleware{
+		next:                    next,
+		requestOptions:          []request.Option{request.WithDocument(doc)},
+		responseBodyLimit:       524288,
+		responseBodyLimitAction: ast.BodyLimitActionReject,
+		responseBodyMimeTypes:   []string{"text/plain", "text/html"},
+	}
+
+	for _, dir := range doc.Directives() {
+		switch dir := dir.(type) {
+		case *ast.DirectiveSecRequestBodyAccess:
+			m.requestBodyAccess = dir.Value == ast.SecRequestBodyAccessOn
+		case *ast.DirectiveSecResponseBodyAccess:
+			m.responseBodyAccess = dir.Value == ast.SecResponseBodyAccessOn
+		case *ast.DirectiveSecResponseBodyLimit:
+			m.responseBodyLimit = dir.Value
+		case *ast.DirectiveSecResponseBodyLimitAction:
+			m.responseBodyLimitAction = dir.Value
+		case *ast.DirectiveSecResponseBodyMimeType:
+			m.responseBodyMimeTypes = append(m.responseBodyMimeTypes, dir.Value...)
+		case *ast.DirectiveSecResponseBodyMimeTypesClear:
+			m.responseBodyMimeTypes = nil
+		}
+	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	var err error
+	m.ruleset, err = engine.NewRuleset(doc, m.rulesetOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to compile ruleset: %w", err)
+	}
+
+	return m, nil
+}
+
+func (m *Middleware) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	tx := m.ruleset.NewTransaction()
+	entry := AuditEntry{Request: r}
+
+	defer func() {
+		tx.ProcessPhase(engine.PhaseLogging)
+
+		if m.auditLog != nil {
+			entry.MatchedRules = tx.MatchedRules()
+			m.auditLog(entry)
+		}
+	}()
+
+	entry.Status, entry.Intervention, entry.Err = m.serve(w, r, tx)
+	if entry.Err != nil {
+		if m.failOpen {
+			sr := &statusRecorder{ResponseWriter: w}
+			m.next.ServeHTTP(sr, r)
+			entry.Status = sr.status
+			return
+		}
+
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		entry.Status = http.StatusInternalServerError
+	}
+}
+
+//serve evaluates phases 1 to 4 and sends the response of the handler or the intervention to the client.
+// If an error is returned nothing has been sent to the client yet.
+func (m *Middleware) serve(w http.ResponseWriter, r *http.Request, tx *engine.Transaction) (int, *engine.Intervention, error) {
+	request.Populate(tx, r, m.requestOptions...)
+
+	if iv := tx.ProcessPhase(engine.PhaseRequestHeaders); iv != nil {
+		return m.intervene(w, r, iv), iv, nil
+	}
+
+	if m.requestBodyAccess {
+		err := m.processRequestBody(r, tx)
+		if errors.Is(err, request.ErrBodyLimit) && tx.RuleEngine() == ast.ModsecOn {
+			iv := &engine.Intervention{
+				Status: http.StatusRequestEntityTooLarge,
+				Action: "deny",
+				Log:    err.Error(),
+			}
+
+			return m.intervene(w, r, iv), iv, nil
+		}
+
+		if err != nil && !errors.Is(err, request.ErrBodyLimit) {
+			return 0, nil, err
+		}
+	}
+
+	if iv := tx.ProcessPhase(engine.PhaseRequestBody); iv != nil {
+		return m.intervene(w, r, iv), iv, nil
+	}
+
+	iw := newInspectingWriter(m, tx, w, r)
+	m.next.ServeHTTP(iw, r)
+	iw.finish()
+
+	return iw.sentStatus, iw.intervention, nil
+}
+
+//processRequestBody inspects the request body, the body is restored so it can be read by the handler
+func (m *Middleware) processRequestBody(r *http.Request, tx *engine.Transaction) error {
+	if r.Body == nil || r.Body == http.NoBody {
+		return request.ProcessBody(tx, r.Header.Get("Content-Type"), strings.NewReader(""), m.requestOptions...)
+	}
+
+	var buf bytes.Buffer
+	err := request.ProcessBody(tx, r.Header.Get("Content-Type"), io.TeeReader(r.Body, &buf), m.requestOptions...)
+
+	r.Body = &restoredBody{
+		Reader: io.MultiReader(&buf, r.Body),
+		Closer: r.Body,
+	}
+
+	return err
+}
+
+//restoredBody is a request body of which the inspected part is read from a buffer
+type restoredBody struct {
+	io.Reader
+	io.Closer
+}
+
+//populateResponse fills the response variables with the headers of the handler, before the body is written.
+// RESPONSE_CONTENT_LENGTH is set from the Content-Length header, or in phase 4 from the length of an inspected body
+// if the handler doesn't set the header. RESPONSE_PROTOCOL is the protocol
+// of the request, since net/http responds with the protocol version of the request.
+// RESPONSE_HEADERS is filled in alphabetical order like REQUEST_HEADERS since the order of the header map isn't stable.
+func (m *Middleware) populateResponse(tx *engine.Transaction, r *http.Request, header http.Header, status int) {
+	tx.SetVariable((&ast.VariableResponseStatus{}).Name(), strconv.Itoa(status))
+	tx.SetVariable((&ast.VariableResponseProtocol{}).Name(), r.Proto)
+	tx.SetVariable((&ast.VariableResponseContentType{}).Name(), header.Get("Content-Type"))
+
+	if contentLength := header.Get("Content-Length"); contentLength != "" {
+		tx.SetVariable((&ast.VariableResponseContentLength{}).Name(), contentLength)
+	}
+
+	keys := make([]string, 0, len(header))
+	for key := range header {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	headers := tx.Collection((&ast.VariableResponseHeaders{}).Name())
+	names := tx.Collection((&ast.VariableResponseHeadersNames{}).Name())
+	for _, key := range keys {
+		for _, value := range header[key] {
+			headers.Add(key, value)
+			names.Add(key, key)
+		}
+	}
+}
+
+//inspectResponseBody returns true if response body access is on and the content type of the response is inspected
+func (m *Middleware) inspectResponseBody(header http.Header) bool {
+	if !m.responseBodyAccess {
+		return false
+	}
+
+	mediaType := strings.ToLower(strings.TrimSpace(strings.SplitN(header.Get("Content-Type"), ";", 2)[0]))
+	for _, mimeType := range m.responseBodyMimeTypes {
+		if strings.EqualFold(mimeType, mediaType) {
+			return true
+		}
+	}
+
+	return false
+}
+
+//intervene applies the intervention and returns the status which was sent to the client
+func (m *Middleware) intervene(w http.ResponseWriter, r *http.Request, iv *engine.Intervention) int {
+	if iv.Pause > 0 {
+		timer := time.NewTimer(iv.Pause)
+		select {
+		case <-timer.C:
+		case <-r.Context().Done():
+			timer.Stop()
+		}
+	}
+
+	switch iv.Action {
+	case "redirect":
+		http.Redirect(w, r, iv.URL, iv.Status)
+		return iv.Status
+
+	case "proxy":
+		target, err := url.Parse(iv.URL)
+		if err != nil {
+			http.Error(w, http.StatusText(http.StatusBadGateway), http.StatusBadGateway)
+			return http.StatusBadGateway
+		}
+
+		//Like ModSecurity the request is sent to the URL of the proxy action, not to the path of the request.
+		// The URL is copied since the transport may modify the URL of the outgoing request.
+		proxy := &httputil.ReverseProxy{
+			Director: func(req *http.Request) {
+				u := *target
+				req.URL = &u
+				req.Host = target.Host
+			},
+		}
+
+		sr := &statusRecorder{ResponseWriter: w}
+		proxy.ServeHTTP(sr, r)
+		return sr.status
+
+	case "drop":
+		if hijacker, ok := w.(http.Hijacker); ok {
+			if conn, _, err := hijacker.Hijack(); err == nil {
+				conn.Close()
+				return 0
+			}
+		}
+	}
+
+	http.Error(w, http.StatusText(iv.Status), iv.Status)
+	return iv.Status
+}
@@ -0,0 +1,349 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dylandreimerink/go-modsec-parser/engine"
+	"github.com/dylandreimerink/go-modsec-parser/parser"
+)
+
+const testRules = `
+SecRequestBodyAccess On
+SecResponseBodyAccess On
+SecRule ARGS_GET:q "@contains attack" "id:1,phase:1,deny,status:403"
+SecRule ARGS_POST:p "@contains evil" "id:2,phase:2,deny,status:406"
+SecRule RESPONSE_STATUS "@eq 500" "id:3,phase:3,deny,status:502"
+SecRule RESPONSE_BODY "@contains secret" "id:4,phase:4,deny,status:451"
+`
+
+//echoHandler responds with the request body followed by the say query argument, or with status 500 if fail is set
+var echoHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	if r.URL.Query().Get("fail") != "" {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("internal error"))
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain")
+	w.Write([]byte("echo:" + string(body) + r.URL.Query().Get("say")))
+})
+
+func newTestMiddleware(t *testing.T, conf string, next http.Handler) (*Middleware, *[]AuditEntry) {
+	t.Helper()
+
+	doc, err := parser.Parse("middleware.conf", conf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	entries := &[]AuditEntry{}
+	m, err := New(doc, next, WithAuditLog(func(entry AuditEntry) {
+		*entries = append(*entries, entry)
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return m, entries
+}
+
+func serve(m *Middleware, method, target, body string) *httptest.ResponseRecorder {
+	var r *http.Request
+	if body != "" {
+		r = httptest.NewRequest(method, target, strings.NewReader(body))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	} else {
+		r = httptest.NewRequest(method, target, nil)
+	}
+
+	w := httptest.NewRecorder()
+	m.ServeHTTP(w, r)
+
+	return w
+}
+
+func TestMiddlewareBlocking(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		target string
+		body   string
+
+		wantStatus int
+		wantBody   string
+		wantRuleID int
+	}{
+		{name: "pass", method: "GET", target: "/?q=hello", wantStatus: 200, wantBody: "echo:"},
+		{name: "request headers", method: "GET", target: "/?q=an+attack", wantStatus: 403, wantRuleID: 1},
+		{name: "request body", method: "POST", target: "/", body: "p=evil", wantStatus: 406, wantRuleID: 2},
+		{name: "request body restored", method: "POST", target: "/", body: "p=fine", wantStatus: 200, wantBody: "echo:p=fine"},
+		{name: "response headers", method: "GET", target: "/?fail=1", wantStatus: 502, wantRuleID: 3},
+		{name: "response body", method: "GET", target: "/?say=secret", wantStatus: 451, wantRuleID: 4},
+	}
+
+	m, entries := newTestMiddleware(t, testRules, echoHandler)
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			*entries = nil
+
+			w := serve(m, test.method, test.target, test.body)
+			if w.Code != test.wantStatus {
+				t.Errorf("got status %d, want %d", w.Code, test.wantStatus)
+			}
+
+			if len(*entries) != 1 {
+				t.Fatalf("got %d audit entries, want 1", len(*entries))
+			}
+			entry := (*entries)[0]
+
+			if entry.Status != test.wantStatus {
+				t.Errorf("got audit status %d, want %d", entry.Status, test.wantStatus)
+			}
+
+			if test.wantRuleID == 0 {
+				if entry.Intervention != nil {
+					t.Errorf("got intervention %+v, want none", entry.Intervention)
+				}
+
+				if w.Body.String() != test.wantBody {
+					t.Errorf("got body '%s', want '%s'", w.Body.String(), test.wantBody)
+				}
+				return
+			}
+
+			if entry.Intervention == nil || entry.Intervention.RuleID != test.wantRuleID {
+				t.Fatalf("got intervention %+v, want rule %d", entry.Intervention, test.wantRuleID)
+			}
+
+			//The buffered response of the handler is replaced by the intervention
+			if strings.Contains(w.Body.String(), "echo:") || strings.Contains(w.Body.String(), "internal error") {
+				t.Errorf("the response of the handler was sent: '%s'", w.Body.String())
+			}
+		})
+	}
+}
+
+func TestMiddlewareDetectionOnly(t *testing.T) {
+	m, entries := newTestMiddleware(t, "SecRuleEngine DetectionOnly\n"+testRules, echoHandler)
+
+	tests := []struct {
+		name   string
+		method string
+		target string
+		body   string
+
+		wantStatus int
+		wantRuleID int
+	}{
+		{name: "request headers", method: "GET", target: "/?q=an+attack", wantStatus: 200, wantRuleID: 1},
+		{name: "request body", method: "POST", target: "/", body: "p=evil", wantStatus: 200, wantRuleID: 2},
+		{name: "response headers", method: "GET", target: "/?fail=1", wantStatus: 500, wantRuleID: 3},
+		{name: "response body", method: "GET", target: "/?say=secret", wantStatus: 200, wantRuleID: 4},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			*entries = nil
+
+			w := serve(m, test.method, test.target, test.body)
+			if w.Code != test.wantStatus {
+				t.Errorf("got status %d, want %d", w.Code, test.wantStatus)
+			}
+
+			if len(*entries) != 1 {
+				t.Fatalf("got %d audit entries, want 1", len(*entries))
+			}
+			entry := (*entries)[0]
+
+			if entry.Intervention != nil {
+				t.Errorf("got intervention %+v, want none", entry.Intervention)
+			}
+
+			matched := false
+			for _, rule := range entry.MatchedRules {
+				matched = matched || rule.ID == test.wantRuleID
+			}
+
+			if !matched {
+				t.Errorf("rule %d didn't match", test.wantRuleID)
+			}
+		})
+	}
+}
+
+func TestMiddlewareResponseBuffering(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Header().Set("X-C", "3")
+		w.Header().Set("X-A", "1")
+		w.Header().Add("X-B", "2")
+		w.Header().Add("X-B", "two")
+		w.WriteHeader(http.StatusCreated)
+
+		//The second status is ignored like it is by net/http
+		w.WriteHeader(http.StatusAccepted)
+
+		for _, chunk := range []string{"<html>", "<body>", r.URL.Query().Get("say"), "</body>", "</html>"} {
+			w.Write([]byte(chunk))
+		}
+	})
+
+	m, entries := newTestMiddleware(t, testRules+`
+SecRule RESPONSE_HEADERS_NAMES "@beginsWith X-" "id:5,phase:3,pass"
+SecRule RESPONSE_BODY "@contains <body>hello" "id:6,phase:4,pass"
+`, handler)
+
+	w := serve(m, "GET", "/?say=hello", "")
+	if w.Code != http.StatusCreated {
+		t.Errorf("got status %d, want %d", w.Code, http.StatusCreated)
+	}
+
+	if w.Body.String() != "<html><body>hello</body></html>" {
+		t.Errorf("got body '%s'", w.Body.String())
+	}
+
+	if w.Header().Get("X-A") != "1" || strings.Join(w.Header()["X-B"], ",") != "2,two" || w.Header().Get("Content-Type") != "text/html" {
+		t.Errorf("unexpected headers %v", w.Header())
+	}
+
+	matchedRules := map[int]*engine.MatchedRule{}
+	for _, rule := range (*entries)[0].MatchedRules {
+		matchedRules[rule.ID] = rule
+	}
+
+	if matchedRules[6] == nil {
+		t.Error("the response body wasn't inspected")
+	}
+
+	if matchedRules[5] == nil {
+		t.Fatal("the response headers weren't inspected")
+	}
+
+	//The header collections are filled in alphabetical order
+	names := []string{}
+	for _, matchedVar := range matchedRules[5].MatchedVars {
+		names = append(names, matchedVar.Value)
+	}
+
+	if strings.Join(names, ",") != "X-A,X-B,X-B,X-C" {
+		t.Errorf("got RESPONSE_HEADERS_NAMES %v", names)
+	}
+
+	//An intervention in phase 4 replaces the complete buffered response, including its headers
+	w = serve(m, "GET", "/?say=secret", "")
+	if w.Code != 451 || w.Header().Get("X-A") != "" || strings.Contains(w.Body.String(), "<html>") {
+		t.Errorf("got status %d, headers %v and body '%s'", w.Code, w.Header(), w.Body.String())
+	}
+}
+
+func TestMiddlewareResponseStreaming(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", r.URL.Query().Get("type"))
+		w.Write([]byte("first,"))
+		w.(http.Flusher).Flush()
+		w.Write([]byte("a secret,"))
+		w.Write([]byte("last"))
+	})
+
+	tests := []struct {
+		name        string
+		limitAction string
+		contentType string
+
+		wantStatus  int
+		wantBody    string
+		wantFlushed bool
+	}{
+		//A body which isn't inspected is streamed to the client and flushes are forwarded
+		{name: "not inspected", limitAction: "Reject", contentType: "application/json", wantStatus: 200, wantBody: "first,a secret,last", wantFlushed: true},
+		//Only the part within the limit is inspected, the rest is streamed
+		{name: "process partial", limitAction: "ProcessPartial", contentType: "text/plain", wantStatus: 200, wantBody: "first,a secret,last", wantFlushed: false},
+		{name: "reject", limitAction: "Reject", contentType: "text/plain", wantStatus: 500},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m, entries := newTestMiddleware(t, testRules+`
+SecResponseBodyLimit 10
+SecResponseBodyLimitAction `+test.limitAction+`
+`, handler)
+
+			w := serve(m, "GET", "/?type="+test.contentType, "")
+			if w.Code != test.wantStatus || (*entries)[0].Status != test.wantStatus {
+				t.Errorf("got status %d and audit status %d, want %d", w.Code, (*entries)[0].Status, test.wantStatus)
+			}
+
+			if test.wantBody != "" && w.Body.String() != test.wantBody {
+				t.Errorf("got body '%s', want '%s'", w.Body.String(), test.wantBody)
+			}
+
+			if test.wantBody == "" && strings.Contains(w.Body.String(), "first") {
+				t.Errorf("the response of the handler was sent: '%s'", w.Body.String())
+			}
+
+			if w.Flushed != test.wantFlushed {
+				t.Errorf("got flushed %v, want %v", w.Flushed, test.wantFlushed)
+			}
+		})
+	}
+}
+
+func TestMiddlewareRedirectAndProxy(t *testing.T) {
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+		w.Write([]byte("backend:" + r.Host + r.URL.Path))
+	}))
+	defer backend.Close()
+
+	backendHost := strings.TrimPrefix(backend.URL, "http://")
+
+	m, entries := newTestMiddleware(t, testRules+`
+SecRule ARGS_GET:to "@streq redirect" "id:10,phase:1,redirect:'http://example.com/blocked'"
+SecRule ARGS_GET:to "@streq proxy" "id:11,phase:1,proxy:'`+backend.URL+`/honeypot'"
+`, echoHandler)
+
+	tests := []struct {
+		name   string
+		target string
+
+		wantStatus   int
+		wantLocation string
+		wantBody     string
+	}{
+		{name: "redirect", target: "/?to=redirect", wantStatus: http.StatusFound, wantLocation: "http://example.com/blocked"},
+		//The request is sent to the path of the proxy action, not to the path of the request
+		{name: "proxy", target: "/app?to=proxy", wantStatus: http.StatusTeapot, wantBody: "backend:" + backendHost + "/honeypot"},
+		//Every request is proxied to the same URL
+		{name: "proxy again", target: "/other?to=proxy", wantStatus: http.StatusTeapot, wantBody: "backend:" + backendHost + "/honeypot"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			*entries = nil
+
+			w := serve(m, "GET", test.target, "")
+			if w.Code != test.wantStatus || (*entries)[0].Status != test.wantStatus {
+				t.Errorf("got status %d and audit status %d, want %d", w.Code, (*entries)[0].Status, test.wantStatus)
+			}
+
+			if location := w.Header().Get("Location"); location != test.wantLocation {
+				t.Errorf("got location '%s', want '%s'", location, test.wantLocation)
+			}
+
+			if test.wantBody != "" && w.Body.String() != test.wantBody {
+				t.Errorf("got body '%s', want '%s'", w.Body.String(), test.wantBody)
+			}
+
+			if (*entries)[0].Intervention == nil {
+				t.Error("got no intervention")
+			}
+		})
+	}
+}
@@ -0,0 +1,219 @@
+package middleware
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/dylandreimerink/go-modsec-parser/ast"
+	"github.com/dylandreimerink/go-modsec-parser/engine"
+)
+
+//errResponseReplaced is returned to the handler when it writes to a response which was replaced by an intervention
+var errResponseReplaced = errors.New("The response was replaced by an intervention")
+
+//The states of an inspectingWriter
+const (
+	//The handler hasn't written the headers yet
+	stateHeaders = iota
+
+	//The body is buffered so it can be inspected in phase 4
+	stateBuffering
+
+	//The headers have been sent and the body is written to the client directly
+	stateStreaming
+
+	//The response was replaced by an intervention, the rest of the response of the handler is discarded
+	stateIntervened
+)
+
+//inspectingWriter evaluates phases 3 and 4 on the response of the handler before it is sent to the client.
+// The headers are held back until the handler writes the body, flushes or returns, so phase 3 can replace them.
+// The body is only buffered if it is inspected and never beyond SecResponseBodyLimit, otherwise it is streamed
+// to the client. While the body is buffered Flush has no effect.
+type inspectingWriter struct {
+	m  *Middleware
+	tx *engine.Transaction
+	r  *http.Request
+	w  http.ResponseWriter
+
+	header http.Header
+	status int
+	state  int
+	body   bytes.Buffer
+
+	//The status which was sent to the client, 0 if the connection was dropped
+	sentStatus int
+
+	//The intervention which replaced the response, if any
+	intervention *engine.Intervention
+}
+
+func newInspectingWriter(m *Middleware, tx *engine.Transaction, w http.ResponseWriter, r *http.Request) *inspectingWriter {
+	return &inspectingWriter{
+		m:      m,
+		tx:     tx,
+		r:      r,
+		w:      w,
+		header: http.Header{},
+	}
+}
+
+func (iw *inspectingWriter) Header() http.Header {
+	return iw.header
+}
+
+func (iw *inspectingWriter) WriteHeader(status int) {
+	//Like net/http only the first status is used
+	if iw.state != stateHeaders {
+		return
+	}
+
+	iw.status = status
+	iw.inspectHeaders()
+}
+
+func (iw *inspectingWriter) Write(data []byte) (int, error) {
+	if iw.state == stateHeaders {
+		iw.WriteHeader(http.StatusOK)
+	}
+
+	switch iw.state {
+	case stateBuffering:
+		iw.body.Write(data)
+		if int64(iw.body.Len()) > int64(iw.m.responseBodyLimit) {
+			iw.exceedLimit()
+		}
+
+		return len(data), nil
+
+	case stateStreaming:
+		return iw.w.Write(data)
+	}
+
+	return 0, errResponseReplaced
+}
+
+//Flush sends the headers and forwards the flush to the client, unless the body is buffered for inspection
+func (iw *inspectingWriter) Flush() {
+	if iw.state == stateHeaders {
+		iw.WriteHeader(http.StatusOK)
+	}
+
+	if flusher, ok := iw.w.(http.Flusher); ok && iw.state == stateStreaming {
+		flusher.Flush()
+	}
+}
+
+//finish completes the response after the handler has returned
+func (iw *inspectingWriter) finish() {
+	if iw.state == stateHeaders {
+		iw.WriteHeader(http.StatusOK)
+	}
+
+	if iw.state == stateBuffering {
+		if iw.header.Get("Content-Length") == "" {
+			iw.tx.SetVariable((&ast.VariableResponseContentLength{}).Name(), strconv.Itoa(iw.body.Len()))
+		}
+
+		iw.inspectBody(iw.body.Bytes())
+	}
+}
+
+//inspectHeaders evaluates phase 3 and decides whether the body is buffered for inspection or streamed
+func (iw *inspectingWriter) inspectHeaders() {
+	iw.m.populateResponse(iw.tx, iw.r, iw.header, iw.status)
+
+	if iv := iw.tx.ProcessPhase(engine.PhaseResponseHeaders); iv != nil {
+		iw.intervene(iv)
+		return
+	}
+
+	if iw.m.inspectResponseBody(iw.header) {
+		iw.state = stateBuffering
+		return
+	}
+
+	//The body isn't inspected, so phase 4 is evaluated before anything is sent and an intervention can still replace
+	// the response
+	if iv := iw.tx.ProcessPhase(engine.PhaseResponseBody); iv != nil {
+		iw.intervene(iv)
+		return
+	}
+
+	iw.sendHeaders()
+}
+
+//exceedLimit handles a buffered body which exceeds SecResponseBodyLimit. With Reject the response is replaced
+// by status 500, with ProcessPartial or in DetectionOnly mode the part within the limit is inspected and the rest is streamed.
+func (iw *inspectingWriter) exceedLimit() {
+	if iw.m.responseBodyLimitAction != ast.BodyLimitActionProcessPartial && iw.tx.RuleEngine() == ast.ModsecOn {
+		iw.intervene(&engine.Intervention{
+			Status: http.StatusInternalServerError,
+			Action: "deny",
+			Log:    fmt.Sprintf("Response body is larger than the configured limit of %d bytes", iw.m.responseBodyLimit),
+		})
+		return
+	}
+
+	iw.inspectBody(iw.body.Bytes()[:iw.m.responseBodyLimit])
+}
+
+//inspectBody evaluates phase 4 on the body and sends the buffered response if there is no intervention
+func (iw *inspectingWriter) inspectBody(body []byte) {
+	iw.tx.SetVariable((&ast.VariableResponseBody{}).Name(), string(body))
+
+	if iv := iw.tx.ProcessPhase(engine.PhaseResponseBody); iv != nil {
+		iw.intervene(iv)
+		return
+	}
+
+	iw.sendHeaders()
+
+	//An error while writing the response can't be handled anymore, the client most likely disconnected
+	_, _ = iw.w.Write(iw.body.Bytes())
+	iw.body = bytes.Buffer{}
+}
+
+//sendHeaders sends the headers of the handler to the client, after which the body is streamed
+func (iw *inspectingWriter) sendHeaders() {
+	for key, values := range iw.header {
+		iw.w.Header()[key] = values
+	}
+
+	iw.w.WriteHeader(iw.status)
+	iw.sentStatus = iw.status
+	iw.state = stateStreaming
+}
+
+//intervene replaces the response of the handler by the intervention
+func (iw *inspectingWriter) intervene(iv *engine.Intervention) {
+	iw.sentStatus = iw.m.intervene(iw.w, iw.r, iv)
+	iw.intervention = iv
+	iw.state = stateIntervened
+	iw.body = bytes.Buffer{}
+}
+
+//statusRecorder records the status of a response which is written directly to the client
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (sr *statusRecorder) WriteHeader(status int) {
+	if sr.status == 0 {
+		sr.status = status
+	}
+
+	sr.ResponseWriter.WriteHeader(status)
+}
+
+func (sr *statusRecorder) Write(data []byte) (int, error) {
+	if sr.status == 0 {
+		sr.status = http.StatusOK
+	}
+
+	return sr.ResponseWriter.Write(data)
+}
@@ -0,0 +1,64 @@
+package operator
+
+import (
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/dylandreimerink/go-modsec-parser/collection"
+)
+
+//GeoLookup resolves an IP address to the fields of the GEO collection, it is implemented by lookup.GeoDB
+type GeoLookup interface {
+	//Lookup returns the GEO fields of the IP address, false is returned if the database has no information about it
+	Lookup(ip net.IP) (map[string]string, bool, error)
+}
+
+//Collections gives operators access to the collections of a transaction, the geoLookup operator fills the GEO
+// collection through it. The MacroResolver passed to Match is used if it implements this interface.
+type Collections interface {
+	Collection(name string) *collection.Collection
+}
+
+//WithGeoLookup sets the database which is used by the geoLookup operator.
+// Without this option geoLookup can't be compiled.
+func WithGeoLookup(geo GeoLookup) Option {
+	return func(c *compiler) {
+		c.geo = geo
+	}
+}
+
+//geoLookup matches if the value is an IP address which is found in the database, like ModSecurity the
+// GEO collection is replaced by the fields of the address on a match
+type geoLookup struct {
+	db GeoLookup
+}
+
+func (m *geoLookup) Match(value []byte, env MacroResolver) (bool, []string) {
+	ip := net.ParseIP(strings.TrimSpace(string(value)))
+	if ip == nil {
+		return false, nil
+	}
+
+	//A database which can't be read is treated like an address which isn't found, like ModSecurity does
+	fields, found, err := m.db.Lookup(ip)
+	if err != nil || !found {
+		return false, nil
+	}
+
+	if colls, ok := env.(Collections); ok {
+		keys := make([]string, 0, len(fields))
+		for key := range fields {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		geo := colls.Collection("GEO")
+		geo.Clear()
+		for _, key := range keys {
+			geo.Set(key, fields[key])
+		}
+	}
+
+	return true, nil
+}
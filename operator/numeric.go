@@ -0,0 +1,55 @@
+package operator
+
+import (
+	"math"
+
+	"github.com/dylandreimerink/go-modsec-parser/ast"
+)
+
+//numeric compares the value and the expanded parameter as integers
+type numeric struct {
+	param   *ast.ExpandableString
+	compare func(value, param int64) bool
+}
+
+func (m *numeric) Match(value []byte, env MacroResolver) (bool, []string) {
+	return m.compare(atoi(value), atoi([]byte(expand(m.param, env)))), nil
+}
+
+//atoi converts the input to an integer like the C atoi function, which ModSecurity uses for numerical comparisons.
+// Leading whitespace is skipped, parsing stops at the first non digit and input without digits is 0.
+func atoi(input []byte) int64 {
+	i := 0
+	for i < len(input) && isSpace(input[i]) {
+		i++
+	}
+
+	negative := false
+	if i < len(input) && (input[i] == '-' || input[i] == '+') {
+		negative = input[i] == '-'
+		i++
+	}
+
+	var value int64
+	for ; i < len(input) && input[i] >= '0' && input[i] <= '9'; i++ {
+		digit := int64(input[i] - '0')
+
+		if value > (math.MaxInt64-digit)/10 {
+			value = math.MaxInt64
+			break
+		}
+
+		value = value*10 + digit
+	}
+
+	if negative {
+		return -value
+	}
+
+	return value
+}
+
+//isSpace returns true for the characters which are whitespace in the C locale
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\v' || c == '\f' || c == '\r'
+}
@@ -0,0 +1,134 @@
+//Package operator compiles the operators of rules into matchers which can be evaluated against variable values.
+// The matchers follow the behaviour of ModSecurity v2.
+package operator
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+
+	"github.com/dylandreimerink/go-modsec-parser/ast"
+	"github.com/dylandreimerink/go-modsec-parser/macro"
+)
+
+//ErrUnsupported is returned by Compile for operators which are not implemented,
+// and is matched by errors.Is for an UnsupportedPatternError
+var ErrUnsupported = errors.New("Unsupported operator")
+
+//MacroResolver resolves the macros in the parameters of operators at match time
+type MacroResolver interface {
+	macro.Resolver
+}
+
+//Matcher is a compiled operator
+type Matcher interface {
+	//Match returns true if the operator matches the value. The captures are the values which are stored in TX:0-9
+	// if the rule has the capture action, only the rx and pm operators return captures.
+	Match(value []byte, env MacroResolver) (bool, []string)
+}
+
+//Option changes the way operators are compiled
+type Option func(*compiler)
+
+type compiler struct {
+	dataFS        fs.FS
+	geo           GeoLookup
+	regexFallback RegexCompiler
+}
+
+//WithDataFS sets the file system from which the data files of the pmFromFile operator are read.
+// Without this option pmFromFile can't be compiled.
+func WithDataFS(fsys fs.FS) Option {
+	return func(c *compiler) {
+		c.dataFS = fsys
+	}
+}
+
+//Compile compiles the operator into a matcher, the negation of the operator is part of the matcher
+func Compile(op ast.Operator, opts ...Option) (Matcher, error) {
+	c := &compiler{}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	matcher, err := c.compile(op)
+	if err != nil {
+		return nil, err
+	}
+
+	if op.GetNegative() {
+		return &negated{matcher: matcher}, nil
+	}
+
+	return matcher, nil
+}
+
+func (c *compiler) compile(op ast.Operator) (Matcher, error) {
+	switch op := op.(type) {
+	case *ast.OperatorBeginsWith:
+		return &beginsWith{param: op.Value}, nil
+	case *ast.OperatorContains:
+		return &contains{param: op.Value}, nil
+	case *ast.OperatorEndsWith:
+		return &endsWith{param: op.Value}, nil
+	case *ast.OperatorStreq:
+		return &streq{param: op.Value}, nil
+	case *ast.OperatorWithin:
+		return &within{param: op.Value}, nil
+
+	case *ast.OperatorEquals:
+		return &numeric{param: op.Value, compare: func(a, b int64) bool { return a == b }}, nil
+	case *ast.OperatorGreaterThanOrEquals:
+		return &numeric{param: op.Value, compare: func(a, b int64) bool { return a >= b }}, nil
+	case *ast.OperatorGreaterThan:
+		return &numeric{param: op.Value, compare: func(a, b int64) bool { return a > b }}, nil
+	case *ast.OperatorLessThanOrEqual:
+		return &numeric{param: op.Value, compare: func(a, b int64) bool { return a <= b }}, nil
+	case *ast.OperatorLessThan:
+		return &numeric{param: op.Value, compare: func(a, b int64) bool { return a < b }}, nil
+
+	case *ast.OperatorGeoLookup:
+		if c.geo == nil {
+			return nil, fmt.Errorf("Unable to compile operator '%s', no geo lookup database is configured", op.Name())
+		}
+		return &geoLookup{db: c.geo}, nil
+	case *ast.OperatorIPMatch:
+		return &ipMatch{networks: op.IPs}, nil
+	case *ast.OperatorValidateByteRange:
+		return newValidateByteRange(op.Ranges), nil
+	case *ast.OperatorValidateURLEncoding:
+		return &validateURLEncoding{}, nil
+	case *ast.OperatorValidateUTF8Encoding:
+		return &validateUTF8Encoding{}, nil
+
+	case *ast.OperatorPM:
+		return newPM(op.Phrases), nil
+	case *ast.OperatorPMFromFile:
+		return c.compilePMFromFile(op)
+	case *ast.OperatorRegex:
+		return c.compileRegex(op.Value)
+	}
+
+	return nil, fmt.Errorf("%w '%s'", ErrUnsupported, op.Name())
+}
+
+//negated inverts the result of a matcher, a negated match never has captures
+type negated struct {
+	matcher Matcher
+}
+
+func (m *negated) Match(value []byte, env MacroResolver) (bool, []string) {
+	matched, _ := m.matcher.Match(value, env)
+	return !matched, nil
+}
+
+//expand expands the macros in the parameter, macros which can't be resolved expand to an empty string
+func expand(param *ast.ExpandableString, env MacroResolver) string {
+	var resolver macro.Resolver
+	if env != nil {
+		resolver = env
+	}
+
+	value, _ := macro.Expand(param, macro.Lenient(resolver))
+	return value
+}
@@ -0,0 +1,129 @@
+package operator
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+	"strings"
+)
+
+//Regexp is a compiled regular expression, it is implemented by *regexp.Regexp
+type Regexp interface {
+	//FindSubmatch returns the leftmost match followed by the values of the capture groups, nil if there is no match
+	FindSubmatch(b []byte) [][]byte
+}
+
+//RegexCompiler compiles the pattern of a rx operator
+type RegexCompiler func(pattern string) (Regexp, error)
+
+//UnsupportedPatternError is returned when a rx pattern is valid PCRE but uses a feature which the Go regexp package (RE2)
+// doesn't support, like lookarounds, backreferences and possessive quantifiers. Such patterns can be compiled by setting
+// a PCRE compatible engine with WithRegexFallback.
+type UnsupportedPatternError struct {
+	Pattern string
+
+	//The error reported by the regexp package
+	Err error
+}
+
+func (e *UnsupportedPatternError) Error() string {
+	return fmt.Sprintf("Unsupported regular expression '%s': %s", e.Pattern, e.Err)
+}
+
+func (e *UnsupportedPatternError) Unwrap() error {
+	return e.Err
+}
+
+//Is makes errors.Is(err, ErrUnsupported) true, since the regexp package doesn't support the pattern
+func (e *UnsupportedPatternError) Is(target error) bool {
+	return target == ErrUnsupported
+}
+
+//WithRegexFallback sets the compiler which is used for rx patterns which are not supported by the Go regexp package.
+// The pattern is passed as is, like ModSecurity the compiler should enable dot-all mode.
+// Without this option these patterns can't be compiled and an UnsupportedPatternError is returned.
+func WithRegexFallback(compile RegexCompiler) Option {
+	return func(c *compiler) {
+		c.regexFallback = compile
+	}
+}
+
+//regex matches if the regular expression matches the value.
+// Like ModSecurity the pattern is compiled in dot-all mode, so a dot also matches new lines.
+type regex struct {
+	re Regexp
+}
+
+//maxCaptures is the amount of capture groups which are stored in TX:0-9
+const maxCaptures = 10
+
+func (c *compiler) compileRegex(pattern string) (*regex, error) {
+	re, err := regexp.Compile("(?s)" + pattern)
+	if err == nil {
+		return &regex{re: re}, nil
+	}
+
+	if !IsUnsupportedRegex(err) {
+		return nil, fmt.Errorf("Unable to compile regular expression '%s': %w", pattern, err)
+	}
+
+	if c.regexFallback == nil {
+		return nil, &UnsupportedPatternError{Pattern: pattern, Err: err}
+	}
+
+	fallback, err := c.regexFallback(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to compile regular expression '%s': %w", pattern, err)
+	}
+
+	return &regex{re: fallback}, nil
+}
+
+//IsUnsupportedRegex returns true if the error of the regexp package is caused by valid PCRE syntax which RE2 doesn't
+// support: lookarounds, backreferences and possessive quantifiers. Other syntax errors are errors in PCRE as well.
+func IsUnsupportedRegex(err error) bool {
+	var syntaxErr *syntax.Error
+	if !errors.As(err, &syntaxErr) {
+		return false
+	}
+
+	switch syntaxErr.Code {
+	case syntax.ErrInvalidPerlOp, syntax.ErrInvalidNamedCapture:
+		//Lookaheads and lookbehinds, depending on the Go version lookbehinds are reported as invalid named captures
+		for _, prefix := range []string{"(?=", "(?!", "(?<=", "(?<!"} {
+			if strings.HasPrefix(syntaxErr.Expr, prefix) {
+				return true
+			}
+		}
+
+	case syntax.ErrInvalidEscape:
+		//Backreferences like \1, \g1 and \k<name>
+		expr := strings.TrimPrefix(syntaxErr.Expr, `\`)
+		return expr != "" && (expr[0] >= '1' && expr[0] <= '9' || expr[0] == 'g' || expr[0] == 'k')
+
+	case syntax.ErrInvalidRepeatOp:
+		//Possessive quantifiers like a++ and a{2}+ are reported as nested repetition
+		return len(syntaxErr.Expr) > 1 && strings.HasSuffix(syntaxErr.Expr, "+")
+	}
+
+	return false
+}
+
+func (m *regex) Match(value []byte, env MacroResolver) (bool, []string) {
+	submatches := m.re.FindSubmatch(value)
+	if submatches == nil {
+		return false, nil
+	}
+
+	if len(submatches) > maxCaptures {
+		submatches = submatches[:maxCaptures]
+	}
+
+	captures := make([]string, len(submatches))
+	for i, submatch := range submatches {
+		captures[i] = string(submatch)
+	}
+
+	return true, captures
+}
@@ -0,0 +1,123 @@
+package operator
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/dylandreimerink/go-modsec-parser/ast"
+)
+
+func TestRegex(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		value   string
+
+		want         bool
+		wantCaptures []string
+	}{
+		{name: "match", pattern: `(?i)select\s+(\w+)`, value: "SELECT name FROM users", want: true, wantCaptures: []string{"SELECT name", "name"}},
+		{name: "no match", pattern: `^admin$`, value: "user"},
+		{name: "dot matches new line", pattern: `a.b`, value: "a\nb", want: true, wantCaptures: []string{"a\nb"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m, err := Compile(&ast.OperatorRegex{Value: test.pattern})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			got, captures := m.Match([]byte(test.value), nil)
+			if got != test.want {
+				t.Fatalf("got %v, want %v", got, test.want)
+			}
+
+			if len(captures) != len(test.wantCaptures) {
+				t.Fatalf("got captures %q, want %q", captures, test.wantCaptures)
+			}
+
+			for i := range captures {
+				if captures[i] != test.wantCaptures[i] {
+					t.Fatalf("got captures %q, want %q", captures, test.wantCaptures)
+				}
+			}
+		})
+	}
+}
+
+func TestRegexUnsupported(t *testing.T) {
+	patterns := []string{
+		`foo(?=bar)`,
+		`foo(?!bar)`,
+		`(?<=a)b`,
+		`(?<!\d)id`,
+		`(a)\1`,
+		`(a)\g1`,
+		`(?<n>a)\k<n>`,
+		`a++`,
+		`a*+`,
+		`a{2}+`,
+	}
+
+	for _, pattern := range patterns {
+		_, err := Compile(&ast.OperatorRegex{Value: pattern})
+
+		var unsupported *UnsupportedPatternError
+		if !errors.As(err, &unsupported) {
+			t.Errorf("'%s': got error %v, want an UnsupportedPatternError", pattern, err)
+			continue
+		}
+
+		if unsupported.Pattern != pattern {
+			t.Errorf("got pattern '%s', want '%s'", unsupported.Pattern, pattern)
+		}
+	}
+
+	//Only lookarounds, backreferences and possessive quantifiers are reported as unsupported, other errors are compile errors
+	for _, pattern := range []string{`(unclosed`, `a**`, `(?z)`, `\e`, `\Z`} {
+		_, err := Compile(&ast.OperatorRegex{Value: pattern})
+
+		var unsupported *UnsupportedPatternError
+		if err == nil || errors.As(err, &unsupported) {
+			t.Errorf("'%s': got error %v, want a compile error", pattern, err)
+		}
+	}
+}
+
+func TestRegexFallback(t *testing.T) {
+	var compiled []string
+	fallback := func(pattern string) (Regexp, error) {
+		compiled = append(compiled, pattern)
+
+		//Stand-in for a PCRE engine, which only knows the lookahead of this test
+		return regexp.MustCompile(`foo(bar)`), nil
+	}
+
+	m, err := Compile(&ast.OperatorRegex{Value: `foo(?=bar)`}, WithRegexFallback(fallback))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got, _ := m.Match([]byte("foobar"), nil); !got {
+		t.Error("expected the fallback to match")
+	}
+
+	//Patterns which RE2 supports don't use the fallback
+	if _, err := Compile(&ast.OperatorRegex{Value: `foo`}, WithRegexFallback(fallback)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(compiled) != 1 || compiled[0] != `foo(?=bar)` {
+		t.Errorf("fallback compiled %q, want only the unsupported pattern", compiled)
+	}
+
+	failing := func(pattern string) (Regexp, error) {
+		return nil, errors.New("broken")
+	}
+
+	if _, err := Compile(&ast.OperatorRegex{Value: `foo(?=bar)`}, WithRegexFallback(failing)); err == nil {
+		t.Error("expected the error of the fallback")
+	}
+}
@@ -0,0 +1,206 @@
+package operator
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/fs"
+	"strings"
+
+	"github.com/dylandreimerink/go-modsec-parser/ast"
+)
+
+//beginsWith matches if the value starts with the expanded parameter
+type beginsWith struct {
+	param *ast.ExpandableString
+}
+
+func (m *beginsWith) Match(value []byte, env MacroResolver) (bool, []string) {
+	return bytes.HasPrefix(value, []byte(expand(m.param, env))), nil
+}
+
+//contains matches if the expanded parameter is found anywhere in the value
+type contains struct {
+	param *ast.ExpandableString
+}
+
+func (m *contains) Match(value []byte, env MacroResolver) (bool, []string) {
+	return bytes.Contains(value, []byte(expand(m.param, env))), nil
+}
+
+//endsWith matches if the value ends with the expanded parameter
+type endsWith struct {
+	param *ast.ExpandableString
+}
+
+func (m *endsWith) Match(value []byte, env MacroResolver) (bool, []string) {
+	return bytes.HasSuffix(value, []byte(expand(m.param, env))), nil
+}
+
+//streq matches if the value is identical to the expanded parameter
+type streq struct {
+	param *ast.ExpandableString
+}
+
+func (m *streq) Match(value []byte, env MacroResolver) (bool, []string) {
+	return string(value) == expand(m.param, env), nil
+}
+
+//within matches if the value is found anywhere in the expanded parameter
+type within struct {
+	param *ast.ExpandableString
+}
+
+func (m *within) Match(value []byte, env MacroResolver) (bool, []string) {
+	return strings.Contains(expand(m.param, env), string(value)), nil
+}
+
+//pm matches if any of the phrases is found in the value, case insensitive.
+// It uses an Aho-Corasick automaton so all phrases are matched in a single pass over the value.
+type pm struct {
+	phrases []string
+	nodes   []pmNode
+}
+
+type pmNode struct {
+	next map[byte]int
+
+	//The node of the longest proper suffix which is also in the trie
+	fail int
+
+	//The index of the phrase which ends at this node or at one of its suffixes, -1 if there is none
+	match int
+}
+
+func newPM(phrases []string) *pm {
+	m := &pm{
+		phrases: phrases,
+		nodes:   []pmNode{{next: map[byte]int{}, match: -1}},
+	}
+
+	for i, phrase := range phrases {
+		node := 0
+		for _, c := range []byte(phrase) {
+			c = toLower(c)
+
+			next, found := m.nodes[node].next[c]
+			if !found {
+				next = len(m.nodes)
+				m.nodes = append(m.nodes, pmNode{next: map[byte]int{}, match: -1})
+				m.nodes[node].next[c] = next
+			}
+
+			node = next
+		}
+
+		if m.nodes[node].match == -1 {
+			m.nodes[node].match = i
+		}
+	}
+
+	//Set the fail links breadth first, so the fail node of a node is always done before the node itself
+	queue := []int{}
+	for _, child := range m.nodes[0].next {
+		queue = append(queue, child)
+	}
+
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+
+		for c, child := range m.nodes[node].next {
+			fail := m.nodes[node].fail
+			for {
+				if next, found := m.nodes[fail].next[c]; found {
+					m.nodes[child].fail = next
+					break
+				}
+
+				if fail == 0 {
+					break
+				}
+
+				fail = m.nodes[fail].fail
+			}
+
+			if m.nodes[child].match == -1 {
+				m.nodes[child].match = m.nodes[m.nodes[child].fail].match
+			}
+
+			queue = append(queue, child)
+		}
+	}
+
+	return m
+}
+
+func (m *pm) Match(value []byte, env MacroResolver) (bool, []string) {
+	if m.nodes[0].match != -1 {
+		//An empty phrase matches everything
+		return true, []string{m.phrases[m.nodes[0].match]}
+	}
+
+	node := 0
+	for _, c := range value {
+		c = toLower(c)
+
+		for {
+			if next, found := m.nodes[node].next[c]; found {
+				node = next
+				break
+			}
+
+			if node == 0 {
+				break
+			}
+
+			node = m.nodes[node].fail
+		}
+
+		if match := m.nodes[node].match; match != -1 {
+			return true, []string{m.phrases[match]}
+		}
+	}
+
+	return false, nil
+}
+
+//compilePMFromFile reads the phrases of all files, one phrase per line. Empty lines and lines starting with # are ignored.
+func (c *compiler) compilePMFromFile(op *ast.OperatorPMFromFile) (Matcher, error) {
+	if c.dataFS == nil {
+		return nil, fmt.Errorf("Unable to compile '%s', no data file system configured", op.Name())
+	}
+
+	phrases := []string{}
+	for _, file := range op.Files {
+		//fs.FS paths are always relative and slash separated
+		data, err := fs.ReadFile(c.dataFS, strings.TrimPrefix(file, "/"))
+		if err != nil {
+			return nil, fmt.Errorf("Unable to read data file '%s': %w", file, err)
+		}
+
+		scanner := bufio.NewScanner(bytes.NewReader(data))
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+
+			phrases = append(phrases, line)
+		}
+
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("Unable to read data file '%s': %w", file, err)
+		}
+	}
+
+	return newPM(phrases), nil
+}
+
+func toLower(c byte) byte {
+	if c >= 'A' && c <= 'Z' {
+		return c + 'a' - 'A'
+	}
+
+	return c
+}
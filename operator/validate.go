@@ -0,0 +1,88 @@
+package operator
+
+import (
+	"net"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/dylandreimerink/go-modsec-parser/ast"
+)
+
+//ipMatch matches if the value is an IP address which is part of one of the networks
+type ipMatch struct {
+	networks []net.IPNet
+}
+
+func (m *ipMatch) Match(value []byte, env MacroResolver) (bool, []string) {
+	ip := net.ParseIP(strings.TrimSpace(string(value)))
+	if ip == nil {
+		return false, nil
+	}
+
+	for _, network := range m.networks {
+		if network.Contains(ip) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+//validateByteRange matches if the value contains a byte which is not in one of the ranges
+type validateByteRange struct {
+	allowed [256]bool
+}
+
+func newValidateByteRange(ranges []*ast.ByteRange) *validateByteRange {
+	m := &validateByteRange{}
+	for _, byteRange := range ranges {
+		for c := int(byteRange.StartID); c <= int(byteRange.EndID); c++ {
+			m.allowed[c] = true
+		}
+	}
+
+	return m
+}
+
+func (m *validateByteRange) Match(value []byte, env MacroResolver) (bool, []string) {
+	for _, c := range value {
+		if !m.allowed[c] {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+//validateURLEncoding matches if the value contains a % which isn't followed by two hexadecimal characters
+type validateURLEncoding struct{}
+
+func (m *validateURLEncoding) Match(value []byte, env MacroResolver) (bool, []string) {
+	i := 0
+	for i < len(value) {
+		if value[i] != '%' {
+			i++
+			continue
+		}
+
+		if i+2 >= len(value) || !isHex(value[i+1]) || !isHex(value[i+2]) {
+			return true, nil
+		}
+
+		i += 3
+	}
+
+	return false, nil
+}
+
+//validateUTF8Encoding matches if the value isn't valid UTF-8, this includes overlong encodings,
+// surrogates, code points above U+10FFFF and incomplete sequences
+type validateUTF8Encoding struct{}
+
+func (m *validateUTF8Encoding) Match(value []byte, env MacroResolver) (bool, []string) {
+	return !utf8.Valid(value), nil
+}
+
+func isHex(c byte) bool {
+	return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
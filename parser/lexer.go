@@ -1,4 +1,4 @@
-package main
+package parser
 
 import (
 	"fmt"
@@ -157,7 +157,6 @@ func (l *lexer) nextItem() item {
 			l.state = l.state(l)
 		}
 	}
-	panic("not reached")
 }
 
 // emit passes an item back to the client.
@@ -291,7 +290,7 @@ func lexLine(l *lexer) stateFn {
 			l.backup()
 			return lexDirective
 		}
-		return l.errorf("Invalid start of line, should be comment, whitespace or directive. found: '%s'", first)
+		return l.errorf("Invalid start of line, should be comment, whitespace or directive. found: '%s'", string(first))
 	}
 }
 
@@ -359,11 +358,12 @@ func lexDirective(l *lexer) stateFn {
 
 			return lexDirectiveArgument
 		default:
-			if unicode.IsLetter(next) {
+			//Directives always start with a letter but may contain digits after that (i.e. SecAuditLog2)
+			if unicode.IsLetter(next) || unicode.IsDigit(next) {
 				continue
 			}
 
-			return l.errorf("Directives should only contain letters, found: '%s'", string(next))
+			return l.errorf("Directives should only contain letters and digits, found: '%s'", string(next))
 		}
 	}
 }
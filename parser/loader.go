@@ -0,0 +1,244 @@
+package parser
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/dylandreimerink/go-modsec-parser/ast"
+	"github.com/dylandreimerink/go-modsec-parser/lint"
+)
+
+//LoaderOption changes the behavior of the loader
+type LoaderOption func(l *loader)
+
+//WithScripts makes the loader read the Lua scripts referenced by SecRuleScript directives and exec actions
+// and attach their contents to the nodes. Loading fails if a script can't be read.
+// Relative script paths are resolved relative to the config file first and relative to the root of the file system second,
+// absolute paths are resolved relative to the root of the file system.
+func WithScripts() LoaderOption {
+	return func(l *loader) {
+		l.resolveScripts = true
+	}
+}
+
+//WithRemoteFetcher makes the loader fetch the rules referenced by SecRemoteRules directives and insert them directly after the directive.
+// If fetching fails the loader aborts with an error, unless the last SecRemoteRulesFailAction before the directive is set to Warn,
+// in which case a warning diagnostic is reported and loading continues without the remote rules.
+func WithRemoteFetcher(fetcher RemoteFetcher) LoaderOption {
+	return func(l *loader) {
+		l.remoteFetcher = fetcher
+	}
+}
+
+//WithDiagnostics sets a handler which is called for every non fatal issue found while loading
+func WithDiagnostics(handler func(lint.Diagnostic)) LoaderOption {
+	return func(l *loader) {
+		l.diagnosticHandler = handler
+	}
+}
+
+type loader struct {
+	fsys fs.FS
+
+	resolveScripts bool
+
+	remoteFetcher RemoteFetcher
+
+	//The fail action set by the last SecRemoteRulesFailAction directive, persists across files
+	remoteRulesFailAction ast.SecRemoteRulesFailActionValue
+
+	diagnosticHandler func(lint.Diagnostic)
+}
+
+//ParseDirectory parses all .conf files in the directory in lexical order and combines them into one document
+func ParseDirectory(dir string, opts ...LoaderOption) (*ast.Document, error) {
+	return ParseFS(os.DirFS(dir), ".", opts...)
+}
+
+//ParseFS parses all .conf files in the directory of fsys in lexical order and combines them into one document
+func ParseFS(fsys fs.FS, dir string, opts ...LoaderOption) (*ast.Document, error) {
+	l := newLoader(fsys, opts...)
+
+	files, err := fs.Glob(fsys, path.Join(dir, "*.conf"))
+	if err != nil {
+		return nil, err
+	}
+
+	doc := &ast.Document{}
+	for _, file := range files {
+		fileDoc, err := l.parseFile(file)
+		if err != nil {
+			return doc, err
+		}
+
+		for _, node := range fileDoc.ChildNodes {
+			node.SetParent(doc)
+			doc.AddChild(node)
+		}
+	}
+
+	return doc, nil
+}
+
+//ParseFile parses a single config file from fsys
+func ParseFile(fsys fs.FS, name string, opts ...LoaderOption) (*ast.Document, error) {
+	return newLoader(fsys, opts...).parseFile(name)
+}
+
+func newLoader(fsys fs.FS, opts ...LoaderOption) *loader {
+	l := &loader{
+		fsys:                  fsys,
+		remoteRulesFailAction: ast.SecRemoteRulesFailActionAbort,
+	}
+
+	for _, opt := range opts {
+		opt(l)
+	}
+
+	return l
+}
+
+func (l *loader) parseFile(name string) (*ast.Document, error) {
+	input, err := fs.ReadFile(l.fsys, name)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := Parse(name, string(input))
+	if err != nil {
+		return doc, err
+	}
+
+	if l.resolveScripts {
+		err = l.attachScripts(doc, name)
+		if err != nil {
+			return doc, err
+		}
+	}
+
+	err = l.expandRemoteRules(doc)
+	if err != nil {
+		return doc, err
+	}
+
+	return doc, nil
+}
+
+//expandRemoteRules inserts the rules of SecRemoteRules directives directly after the directive
+func (l *loader) expandRemoteRules(doc *ast.Document) error {
+	nodes := make([]ast.Node, 0, len(doc.ChildNodes))
+
+	for _, node := range doc.ChildNodes {
+		nodes = append(nodes, node)
+
+		switch dir := node.(type) {
+		case *ast.DirectiveSecRemoteRulesFailAction:
+			l.remoteRulesFailAction = dir.Value
+
+		case *ast.DirectiveSecRemoteRules:
+			if l.remoteFetcher == nil {
+				continue
+			}
+
+			remoteDoc, err := l.fetchRemoteRules(dir)
+			if err != nil {
+				if l.remoteRulesFailAction != ast.SecRemoteRulesFailActionWarn {
+					return err
+				}
+
+				l.diagnostic(lint.Diagnostic{
+					Severity: lint.SeverityWarning,
+					Node:     dir,
+					Message:  err.Error(),
+				})
+
+				continue
+			}
+
+			for _, remoteNode := range remoteDoc.ChildNodes {
+				remoteNode.SetParent(doc)
+				nodes = append(nodes, remoteNode)
+			}
+		}
+	}
+
+	doc.ChildNodes = nodes
+
+	return nil
+}
+
+func (l *loader) fetchRemoteRules(dir *ast.DirectiveSecRemoteRules) (*ast.Document, error) {
+	rules, err := l.remoteFetcher.Fetch(dir.Key, dir.URL)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to fetch remote rules from '%s': %w", dir.URL, err)
+	}
+
+	remoteDoc, err := Parse(dir.URL, rules)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to parse remote rules from '%s': %w", dir.URL, err)
+	}
+
+	return remoteDoc, nil
+}
+
+func (l *loader) diagnostic(d lint.Diagnostic) {
+	if l.diagnosticHandler != nil {
+		l.diagnosticHandler(d)
+	}
+}
+
+//attachScripts reads the contents of all Lua scripts referenced in the doc which was loaded from the file with the given name
+func (l *loader) attachScripts(doc *ast.Document, name string) error {
+	for _, dir := range doc.Directives() {
+		var actions []ast.Action
+
+		switch dir := dir.(type) {
+		case *ast.DirectiveSecRuleScript:
+			script, err := l.readScript(dir.Path, name)
+			if err != nil {
+				return fmt.Errorf("Unable to read script of %s in '%s': %w", dir.Name(), name, err)
+			}
+
+			dir.Script = script
+			actions = dir.Actions()
+
+		case *ast.DirectiveSecRule:
+			actions = dir.Actions()
+
+		case *ast.DirectiveSecAction:
+			actions = dir.Actions()
+		}
+
+		for _, action := range actions {
+			exec, ok := action.(*ast.ActionExec)
+			if !ok || !exec.IsLua() {
+				continue
+			}
+
+			script, err := l.readScript(exec.Path, name)
+			if err != nil {
+				return fmt.Errorf("Unable to read script of exec action in '%s': %w", name, err)
+			}
+
+			exec.Script = script
+		}
+	}
+
+	return nil
+}
+
+func (l *loader) readScript(scriptPath, configPath string) ([]byte, error) {
+	if path.IsAbs(scriptPath) {
+		return fs.ReadFile(l.fsys, path.Clean(strings.TrimPrefix(scriptPath, "/")))
+	}
+
+	script, err := fs.ReadFile(l.fsys, path.Join(path.Dir(configPath), scriptPath))
+	if err == nil {
+		return script, nil
+	}
+
+	return fs.ReadFile(l.fsys, path.Clean(scriptPath))
+}
@@ -1,17 +1,28 @@
-package main
+package parser
 
 import (
 	"errors"
 	"fmt"
+	"math"
+	"mime"
 	"net"
+	"net/url"
+	"os"
+	"regexp/syntax"
 	"strconv"
 	"strings"
 
 	"github.com/davecgh/go-spew/spew"
 
 	"github.com/dylandreimerink/go-modsec-parser/ast"
+	"github.com/dylandreimerink/go-modsec-parser/operator"
 )
 
+//Parse parses the config in input, name is used to indicate the location of errors and is usually the file name
+func Parse(name, input string) (*ast.Document, error) {
+	return parseDocument(lex(name, input))
+}
+
 func parseDocument(lexer *lexer) (*ast.Document, error) {
 	doc := &ast.Document{}
 
@@ -81,6 +92,9 @@ func parseDirective(tokens []item) (ast.Directive, []item, error) {
 	case strings.ToLower((&ast.DirectiveSecAction{}).Name()):
 		directive, tokens, err = parseDirectiveSecAction(tokens[1:])
 
+	case strings.ToLower((&ast.DirectiveSecArgumentSeparator{}).Name()):
+		directive, tokens, err = parseDirectiveSecArgumentSeparator(tokens[1:])
+
 	case strings.ToLower((&ast.DirectiveSecAuditEngine{}).Name()):
 		secAuditEngine := &ast.DirectiveSecAuditEngine{}
 
@@ -93,6 +107,40 @@ func parseDirective(tokens []item) (ast.Directive, []item, error) {
 		secAuditEngine.Value, tokens, err = parseDirectiveSecAuditEngineValue(tokens[1:])
 		directive = secAuditEngine
 
+	case strings.ToLower((&ast.DirectiveSecAuditLog{}).Name()):
+		secAuditLog := &ast.DirectiveSecAuditLog{}
+
+		var arg item
+		arg, tokens, err = parseDirectiveArgument(tokens[1:])
+		secAuditLog.Value = arg.val
+		directive = secAuditLog
+
+	case strings.ToLower((&ast.DirectiveSecAuditLog2{}).Name()):
+		secAuditLog2 := &ast.DirectiveSecAuditLog2{}
+
+		var arg item
+		arg, tokens, err = parseDirectiveArgument(tokens[1:])
+		secAuditLog2.Value = arg.val
+		directive = secAuditLog2
+
+	case strings.ToLower((&ast.DirectiveSecAuditLogDirMode{}).Name()):
+		secAuditLogDirMode := &ast.DirectiveSecAuditLogDirMode{}
+
+		secAuditLogDirMode.Value, tokens, err = parseDirectiveFileModeValue(tokens[1:])
+		directive = secAuditLogDirMode
+
+	case strings.ToLower((&ast.DirectiveSecAuditLogFileMode{}).Name()):
+		secAuditLogFileMode := &ast.DirectiveSecAuditLogFileMode{}
+
+		secAuditLogFileMode.Value, tokens, err = parseDirectiveFileModeValue(tokens[1:])
+		directive = secAuditLogFileMode
+
+	case strings.ToLower((&ast.DirectiveSecAuditLogFormat{}).Name()):
+		secAuditLogFormat := &ast.DirectiveSecAuditLogFormat{}
+
+		secAuditLogFormat.Value, tokens, err = parseDirectiveSecAuditLogFormatValue(tokens[1:])
+		directive = secAuditLogFormat
+
 	case strings.ToLower((&ast.DirectiveSecAuditLogParts{}).Name()):
 		secAuditParts := &ast.DirectiveSecAuditLogParts{}
 
@@ -105,9 +153,165 @@ func parseDirective(tokens []item) (ast.Directive, []item, error) {
 		secAuditParts.Value, tokens, err = parseDirectiveSecAuditLogPartsValue(tokens[1:])
 		directive = secAuditParts
 
+	case strings.ToLower((&ast.DirectiveSecAuditLogRelevantStatus{}).Name()):
+		directive, tokens, err = parseDirectiveSecAuditLogRelevantStatus(tokens[1:])
+
+	case strings.ToLower((&ast.DirectiveSecAuditLogStorageDir{}).Name()):
+		secAuditLogStorageDir := &ast.DirectiveSecAuditLogStorageDir{}
+
+		var arg item
+		arg, tokens, err = parseDirectiveArgument(tokens[1:])
+		secAuditLogStorageDir.Value = arg.val
+		directive = secAuditLogStorageDir
+
+	case strings.ToLower((&ast.DirectiveSecAuditLogType{}).Name()):
+		secAuditLogType := &ast.DirectiveSecAuditLogType{}
+
+		secAuditLogType.Value, tokens, err = parseDirectiveSecAuditLogTypeValue(tokens[1:])
+		directive = secAuditLogType
+
+	case strings.ToLower((&ast.DirectiveSecChrootDir{}).Name()):
+		secChrootDir := &ast.DirectiveSecChrootDir{}
+
+		var arg item
+		arg, tokens, err = parseDirectiveArgument(tokens[1:])
+		secChrootDir.Value = arg.val
+		directive = secChrootDir
+
+	case strings.ToLower((&ast.DirectiveSecCollectionTimeout{}).Name()):
+		secCollectionTimeout := &ast.DirectiveSecCollectionTimeout{}
+
+		secCollectionTimeout.Value, tokens, err = parseDirectiveIntValue(tokens[1:], secCollectionTimeout.Name(), 1, math.MaxInt32)
+		directive = secCollectionTimeout
+
 	case strings.ToLower((&ast.DirectiveSecComponentSignature{}).Name()):
 		directive, tokens, err = parseDirectiveSecComponentSignature(tokens[1:])
 
+	case strings.ToLower((&ast.DirectiveSecConnEngine{}).Name()):
+		secConnEngine := &ast.DirectiveSecConnEngine{}
+
+		var value string
+		value, tokens, err = parseDirectiveEnumValue(tokens[1:], secConnEngine.Name(), ast.ModsecOn, ast.ModsecOff, ast.ModsecDetectionOnly)
+		secConnEngine.Value = ast.SecConnEngineValue(value)
+		directive = secConnEngine
+
+	//SecReadStateLimit is the deprecated name of SecConnReadStateLimit
+	case strings.ToLower((&ast.DirectiveSecConnReadStateLimit{}).Name()), "secreadstatelimit":
+		directive, tokens, err = parseDirectiveSecConnReadStateLimit(tokens[1:])
+
+	//SecWriteStateLimit is the deprecated name of SecConnWriteStateLimit
+	case strings.ToLower((&ast.DirectiveSecConnWriteStateLimit{}).Name()), "secwritestatelimit":
+		directive, tokens, err = parseDirectiveSecConnWriteStateLimit(tokens[1:])
+
+	case strings.ToLower((&ast.DirectiveSecContentInjection{}).Name()):
+		secContentInjection := &ast.DirectiveSecContentInjection{}
+
+		var value string
+		value, tokens, err = parseDirectiveEnumValue(tokens[1:], secContentInjection.Name(), ast.ModsecOn, ast.ModsecOff)
+		secContentInjection.Value = ast.SecContentInjectionValue(value)
+		directive = secContentInjection
+
+	case strings.ToLower((&ast.DirectiveSecCookieFormat{}).Name()):
+		secCookieFormat := &ast.DirectiveSecCookieFormat{}
+
+		var value int
+		value, tokens, err = parseDirectiveIntValue(tokens[1:], secCookieFormat.Name(), int(ast.SecCookieFormatVersion0), int(ast.SecCookieFormatVersion1))
+		secCookieFormat.Value = ast.SecCookieFormatValue(value)
+		directive = secCookieFormat
+
+	case strings.ToLower((&ast.DirectiveSecCookieV0Separator{}).Name()):
+		directive, tokens, err = parseDirectiveSecCookieV0Separator(tokens[1:])
+
+	case strings.ToLower((&ast.DirectiveSecDataDir{}).Name()):
+		secDataDir := &ast.DirectiveSecDataDir{}
+
+		var arg item
+		arg, tokens, err = parseDirectiveArgument(tokens[1:])
+		secDataDir.Value = arg.val
+		directive = secDataDir
+
+	case strings.ToLower((&ast.DirectiveSecDefaultAction{}).Name()):
+		directive, tokens, err = parseDirectiveSecDefaultAction(tokens[1:])
+
+	case strings.ToLower((&ast.DirectiveSecDebugLog{}).Name()):
+		secDebugLog := &ast.DirectiveSecDebugLog{}
+
+		var arg item
+		arg, tokens, err = parseDirectiveArgument(tokens[1:])
+		secDebugLog.Value = arg.val
+		directive = secDebugLog
+
+	case strings.ToLower((&ast.DirectiveSecDebugLogLevel{}).Name()):
+		secDebugLogLevel := &ast.DirectiveSecDebugLogLevel{}
+
+		secDebugLogLevel.Value, tokens, err = parseDirectiveIntValue(tokens[1:], secDebugLogLevel.Name(), 0, 9)
+		directive = secDebugLogLevel
+
+	case strings.ToLower((&ast.DirectiveSecDisableBackendCompression{}).Name()):
+		secDisableBackendCompression := &ast.DirectiveSecDisableBackendCompression{}
+
+		var value string
+		value, tokens, err = parseDirectiveEnumValue(tokens[1:], secDisableBackendCompression.Name(), ast.ModsecOn, ast.ModsecOff)
+		secDisableBackendCompression.Value = ast.SecDisableBackendCompressionValue(value)
+		directive = secDisableBackendCompression
+
+	case strings.ToLower((&ast.DirectiveSecGeoLookupDb{}).Name()):
+		secGeoLookupDb := &ast.DirectiveSecGeoLookupDb{}
+
+		var arg item
+		arg, tokens, err = parseDirectiveArgument(tokens[1:])
+		secGeoLookupDb.Value = arg.val
+		directive = secGeoLookupDb
+
+	case strings.ToLower((&ast.DirectiveSecGsbLookupDb{}).Name()):
+		secGsbLookupDb := &ast.DirectiveSecGsbLookupDb{}
+
+		var arg item
+		arg, tokens, err = parseDirectiveArgument(tokens[1:])
+		secGsbLookupDb.Value = arg.val
+		directive = secGsbLookupDb
+
+	case strings.ToLower((&ast.DirectiveSecGuardianLog{}).Name()):
+		secGuardianLog := &ast.DirectiveSecGuardianLog{}
+
+		var arg item
+		arg, tokens, err = parseDirectiveArgument(tokens[1:])
+		secGuardianLog.Value = arg.val
+		directive = secGuardianLog
+
+	case strings.ToLower((&ast.DirectiveSecHashEngine{}).Name()):
+		secHashEngine := &ast.DirectiveSecHashEngine{}
+
+		var value string
+		value, tokens, err = parseDirectiveEnumValue(tokens[1:], secHashEngine.Name(), ast.ModsecOn, ast.ModsecOff)
+		secHashEngine.Value = ast.SecHashEngineValue(value)
+		directive = secHashEngine
+
+	case strings.ToLower((&ast.DirectiveSecHashKey{}).Name()):
+		directive, tokens, err = parseDirectiveSecHashKey(tokens[1:])
+
+	case strings.ToLower((&ast.DirectiveSecHashMethodPm{}).Name()):
+		directive, tokens, err = parseDirectiveSecHashMethodPm(tokens[1:])
+
+	case strings.ToLower((&ast.DirectiveSecHashMethodRx{}).Name()):
+		directive, tokens, err = parseDirectiveSecHashMethodRx(tokens[1:])
+
+	case strings.ToLower((&ast.DirectiveSecHashParam{}).Name()):
+		secHashParam := &ast.DirectiveSecHashParam{}
+
+		var arg item
+		arg, tokens, err = parseDirectiveArgument(tokens[1:])
+		secHashParam.Value = arg.val
+		directive = secHashParam
+
+	case strings.ToLower((&ast.DirectiveSecInterceptOnError{}).Name()):
+		secInterceptOnError := &ast.DirectiveSecInterceptOnError{}
+
+		var value string
+		value, tokens, err = parseDirectiveEnumValue(tokens[1:], secInterceptOnError.Name(), ast.ModsecOn, ast.ModsecOff)
+		secInterceptOnError.Value = ast.SecInterceptOnErrorValue(value)
+		directive = secInterceptOnError
+
 	case strings.ToLower((&ast.DirectiveSecMarker{}).Name()):
 		secMarker := &ast.DirectiveSecMarker{}
 
@@ -126,6 +330,34 @@ func parseDirective(tokens []item) (ast.Directive, []item, error) {
 
 		directive = secMarker
 
+	case strings.ToLower((&ast.DirectiveSecPcreMatchLimit{}).Name()):
+		secPcreMatchLimit := &ast.DirectiveSecPcreMatchLimit{}
+
+		secPcreMatchLimit.Value, tokens, err = parseDirectiveIntValue(tokens[1:], secPcreMatchLimit.Name(), 1, math.MaxInt32)
+		directive = secPcreMatchLimit
+
+	case strings.ToLower((&ast.DirectiveSecPcreMatchLimitRecursion{}).Name()):
+		secPcreMatchLimitRecursion := &ast.DirectiveSecPcreMatchLimitRecursion{}
+
+		secPcreMatchLimitRecursion.Value, tokens, err = parseDirectiveIntValue(tokens[1:], secPcreMatchLimitRecursion.Name(), 1, math.MaxInt32)
+		directive = secPcreMatchLimitRecursion
+
+	case strings.ToLower((&ast.DirectiveSecRemoteRules{}).Name()):
+		directive, tokens, err = parseDirectiveSecRemoteRules(tokens[1:])
+
+	case strings.ToLower((&ast.DirectiveSecRemoteRulesFailAction{}).Name()):
+		secRemoteRulesFailAction := &ast.DirectiveSecRemoteRulesFailAction{}
+
+		var value string
+		value, tokens, err = parseDirectiveEnumValue(
+			tokens[1:],
+			secRemoteRulesFailAction.Name(),
+			string(ast.SecRemoteRulesFailActionAbort),
+			string(ast.SecRemoteRulesFailActionWarn),
+		)
+		secRemoteRulesFailAction.Value = ast.SecRemoteRulesFailActionValue(value)
+		directive = secRemoteRulesFailAction
+
 	case strings.ToLower((&ast.DirectiveSecRequestBodyAccess{}).Name()):
 		secReqBodyAccess := &ast.DirectiveSecRequestBodyAccess{}
 
@@ -138,6 +370,55 @@ func parseDirective(tokens []item) (ast.Directive, []item, error) {
 		secReqBodyAccess.Value, tokens, err = parseDirectiveSecRequestBodyAccessValue(tokens[1:])
 		directive = secReqBodyAccess
 
+	case strings.ToLower((&ast.DirectiveSecRequestBodyInMemoryLimit{}).Name()):
+		secReqBodyInMemoryLimit := &ast.DirectiveSecRequestBodyInMemoryLimit{}
+
+		secReqBodyInMemoryLimit.Value, tokens, err = parseDirectiveBodyLimitValue(tokens[1:])
+		directive = secReqBodyInMemoryLimit
+
+	case strings.ToLower((&ast.DirectiveSecRequestBodyLimit{}).Name()):
+		secReqBodyLimit := &ast.DirectiveSecRequestBodyLimit{}
+
+		secReqBodyLimit.Value, tokens, err = parseDirectiveBodyLimitValue(tokens[1:])
+		directive = secReqBodyLimit
+
+	case strings.ToLower((&ast.DirectiveSecRequestBodyLimitAction{}).Name()):
+		secReqBodyLimitAction := &ast.DirectiveSecRequestBodyLimitAction{}
+
+		secReqBodyLimitAction.Value, tokens, err = parseDirectiveBodyLimitActionValue(tokens[1:])
+		directive = secReqBodyLimitAction
+
+	case strings.ToLower((&ast.DirectiveSecRequestBodyNoFilesLimit{}).Name()):
+		secReqBodyNoFilesLimit := &ast.DirectiveSecRequestBodyNoFilesLimit{}
+
+		secReqBodyNoFilesLimit.Value, tokens, err = parseDirectiveBodyLimitValue(tokens[1:])
+		directive = secReqBodyNoFilesLimit
+
+	case strings.ToLower((&ast.DirectiveSecResponseBodyAccess{}).Name()):
+		secRespBodyAccess := &ast.DirectiveSecResponseBodyAccess{}
+
+		secRespBodyAccess.Value, tokens, err = parseDirectiveSecResponseBodyAccessValue(tokens[1:])
+		directive = secRespBodyAccess
+
+	case strings.ToLower((&ast.DirectiveSecResponseBodyLimit{}).Name()):
+		secRespBodyLimit := &ast.DirectiveSecResponseBodyLimit{}
+
+		secRespBodyLimit.Value, tokens, err = parseDirectiveBodyLimitValue(tokens[1:])
+		directive = secRespBodyLimit
+
+	case strings.ToLower((&ast.DirectiveSecResponseBodyLimitAction{}).Name()):
+		secRespBodyLimitAction := &ast.DirectiveSecResponseBodyLimitAction{}
+
+		secRespBodyLimitAction.Value, tokens, err = parseDirectiveBodyLimitActionValue(tokens[1:])
+		directive = secRespBodyLimitAction
+
+	case strings.ToLower((&ast.DirectiveSecResponseBodyMimeType{}).Name()):
+		directive, tokens, err = parseDirectiveSecResponseBodyMimeType(tokens[1:])
+
+	case strings.ToLower((&ast.DirectiveSecResponseBodyMimeTypesClear{}).Name()):
+		directive = &ast.DirectiveSecResponseBodyMimeTypesClear{}
+		tokens = tokens[1:]
+
 	case strings.ToLower((&ast.DirectiveSecRule{}).Name()):
 		directive, tokens, err = parseDirectiveSecRule(tokens[1:])
 
@@ -153,6 +434,124 @@ func parseDirective(tokens []item) (ast.Directive, []item, error) {
 		secRuleEngine.Value, tokens, err = parseDirectiveSecRuleEngineValue(tokens[1:])
 		directive = secRuleEngine
 
+	case strings.ToLower((&ast.DirectiveSecRulePerfTime{}).Name()):
+		secRulePerfTime := &ast.DirectiveSecRulePerfTime{}
+
+		secRulePerfTime.Value, tokens, err = parseDirectiveIntValue(tokens[1:], secRulePerfTime.Name(), 1, math.MaxInt32)
+		directive = secRulePerfTime
+
+	case strings.ToLower((&ast.DirectiveSecRuleScript{}).Name()):
+		directive, tokens, err = parseDirectiveSecRuleScript(tokens[1:])
+
+	case strings.ToLower((&ast.DirectiveSecSensorId{}).Name()):
+		secSensorID := &ast.DirectiveSecSensorId{}
+
+		var arg item
+		arg, tokens, err = parseDirectiveArgument(tokens[1:])
+		secSensorID.Value = arg.val
+		directive = secSensorID
+
+	case strings.ToLower((&ast.DirectiveSecServerSignature{}).Name()):
+		secServerSignature := &ast.DirectiveSecServerSignature{}
+
+		var arg item
+		arg, tokens, err = parseDirectiveArgument(tokens[1:])
+		secServerSignature.Value = arg.val
+		directive = secServerSignature
+
+	case strings.ToLower((&ast.DirectiveSecStatusEngine{}).Name()):
+		secStatusEngine := &ast.DirectiveSecStatusEngine{}
+
+		var value string
+		value, tokens, err = parseDirectiveEnumValue(tokens[1:], secStatusEngine.Name(), ast.ModsecOn, ast.ModsecOff)
+		secStatusEngine.Value = ast.SecStatusEngineValue(value)
+		directive = secStatusEngine
+
+	case strings.ToLower((&ast.DirectiveSecStreamInBodyInspection{}).Name()):
+		secStreamInBodyInspection := &ast.DirectiveSecStreamInBodyInspection{}
+
+		var value string
+		value, tokens, err = parseDirectiveEnumValue(tokens[1:], secStreamInBodyInspection.Name(), ast.ModsecOn, ast.ModsecOff)
+		secStreamInBodyInspection.Value = ast.SecStreamInBodyInspectionValue(value)
+		directive = secStreamInBodyInspection
+
+	case strings.ToLower((&ast.DirectiveSecStreamOutBodyInspection{}).Name()):
+		secStreamOutBodyInspection := &ast.DirectiveSecStreamOutBodyInspection{}
+
+		var value string
+		value, tokens, err = parseDirectiveEnumValue(tokens[1:], secStreamOutBodyInspection.Name(), ast.ModsecOn, ast.ModsecOff)
+		secStreamOutBodyInspection.Value = ast.SecStreamOutBodyInspectionValue(value)
+		directive = secStreamOutBodyInspection
+
+	case strings.ToLower((&ast.DirectiveSecTmpDir{}).Name()):
+		secTmpDir := &ast.DirectiveSecTmpDir{}
+
+		var arg item
+		arg, tokens, err = parseDirectiveArgument(tokens[1:])
+		secTmpDir.Value = arg.val
+		directive = secTmpDir
+
+	case strings.ToLower((&ast.DirectiveSecTmpSaveUploadedFiles{}).Name()):
+		secTmpSaveUploadedFiles := &ast.DirectiveSecTmpSaveUploadedFiles{}
+
+		var value string
+		value, tokens, err = parseDirectiveEnumValue(tokens[1:], secTmpSaveUploadedFiles.Name(), ast.ModsecOn, ast.ModsecOff)
+		secTmpSaveUploadedFiles.Value = ast.SecTmpSaveUploadedFilesValue(value)
+		directive = secTmpSaveUploadedFiles
+
+	case strings.ToLower((&ast.DirectiveSecUnicodeCodePage{}).Name()):
+		secUnicodeCodePage := &ast.DirectiveSecUnicodeCodePage{}
+
+		secUnicodeCodePage.Value, tokens, err = parseDirectiveIntValue(tokens[1:], secUnicodeCodePage.Name(), 1, math.MaxInt32)
+		directive = secUnicodeCodePage
+
+	case strings.ToLower((&ast.DirectiveSecUnicodeMapFile{}).Name()):
+		directive, tokens, err = parseDirectiveSecUnicodeMapFile(tokens[1:])
+
+	case strings.ToLower((&ast.DirectiveSecUploadDir{}).Name()):
+		secUploadDir := &ast.DirectiveSecUploadDir{}
+
+		var arg item
+		arg, tokens, err = parseDirectiveArgument(tokens[1:])
+		secUploadDir.Value = arg.val
+		directive = secUploadDir
+
+	case strings.ToLower((&ast.DirectiveSecUploadFileLimit{}).Name()):
+		secUploadFileLimit := &ast.DirectiveSecUploadFileLimit{}
+
+		secUploadFileLimit.Value, tokens, err = parseDirectiveIntValue(tokens[1:], secUploadFileLimit.Name(), 1, math.MaxInt32)
+		directive = secUploadFileLimit
+
+	case strings.ToLower((&ast.DirectiveSecUploadFileMode{}).Name()):
+		secUploadFileMode := &ast.DirectiveSecUploadFileMode{}
+
+		secUploadFileMode.Value, tokens, err = parseDirectiveFileModeValue(tokens[1:])
+		directive = secUploadFileMode
+
+	case strings.ToLower((&ast.DirectiveSecUploadKeepFiles{}).Name()):
+		secUploadKeepFiles := &ast.DirectiveSecUploadKeepFiles{}
+
+		var value string
+		value, tokens, err = parseDirectiveEnumValue(tokens[1:], secUploadKeepFiles.Name(), ast.ModsecOn, ast.ModsecOff, ast.ModsecRelevantOnly)
+		secUploadKeepFiles.Value = ast.SecUploadKeepFilesValue(value)
+		directive = secUploadKeepFiles
+
+	case strings.ToLower((&ast.DirectiveSecWebAppId{}).Name()):
+		secWebAppID := &ast.DirectiveSecWebAppId{}
+
+		var arg item
+		arg, tokens, err = parseDirectiveArgument(tokens[1:])
+		secWebAppID.Value = arg.val
+		directive = secWebAppID
+
+	case strings.ToLower((&ast.DirectiveSecXmlExternalEntity{}).Name()):
+		secXMLExternalEntity := &ast.DirectiveSecXmlExternalEntity{}
+
+		var value string
+		value, tokens, err = parseDirectiveEnumValue(tokens[1:], secXMLExternalEntity.Name(), ast.ModsecOn, ast.ModsecOff)
+		secXMLExternalEntity.Value = ast.SecXmlExternalEntityValue(value)
+		directive = secXMLExternalEntity
+
 	default:
 		return nil, tokens, fmt.Errorf("Unknown directive '%s' at '%s'", tokens[0].val, tokens[0].start)
 	}
@@ -173,6 +572,200 @@ func parseDirectiveSecRequestBodyAccessValue(tokens []item) (ast.SecRequestBodyA
 	}
 }
 
+func parseDirectiveSecResponseBodyAccessValue(tokens []item) (ast.SecResponseBodyAccessValue, []item, error) {
+	arg, tokens, err := parseDirectiveArgument(tokens)
+	if err != nil {
+		return ast.SecResponseBodyAccessValue(""), tokens, err
+	}
+
+	switch strings.ToLower(arg.val) {
+	case strings.ToLower(string(ast.SecResponseBodyAccessOn)):
+		return ast.SecResponseBodyAccessOn, tokens, nil
+
+	case strings.ToLower(string(ast.SecResponseBodyAccessOff)):
+		return ast.SecResponseBodyAccessOff, tokens, nil
+
+	default:
+		return ast.SecResponseBodyAccessValue(""), tokens, fmt.Errorf("Unknown SecResponseBodyAccess value '%s' at '%s'", arg.val, arg.start)
+	}
+}
+
+func parseDirectiveBodyLimitValue(tokens []item) (ast.BodyLimit, []item, error) {
+	arg, tokens, err := parseDirectiveArgument(tokens)
+	if err != nil {
+		return ast.BodyLimit(0), tokens, err
+	}
+
+	limit, err := strconv.ParseInt(arg.val, 10, 64)
+	if err != nil || !ast.BodyLimit(limit).Valid() {
+		return ast.BodyLimit(0), tokens, fmt.Errorf("Invalid body limit '%s' at '%s', expected a number of bytes between 1 and %d", arg.val, arg.start, ast.BodyLimitMax)
+	}
+
+	return ast.BodyLimit(limit), tokens, nil
+}
+
+func parseDirectiveBodyLimitActionValue(tokens []item) (ast.BodyLimitAction, []item, error) {
+	arg, tokens, err := parseDirectiveArgument(tokens)
+	if err != nil {
+		return ast.BodyLimitAction(""), tokens, err
+	}
+
+	switch strings.ToLower(arg.val) {
+	case strings.ToLower(string(ast.BodyLimitActionReject)):
+		return ast.BodyLimitActionReject, tokens, nil
+
+	case strings.ToLower(string(ast.BodyLimitActionProcessPartial)):
+		return ast.BodyLimitActionProcessPartial, tokens, nil
+
+	default:
+		return ast.BodyLimitAction(""), tokens, fmt.Errorf("Unknown body limit action '%s' at '%s', expected Reject or ProcessPartial", arg.val, arg.start)
+	}
+}
+
+func parseDirectiveSecResponseBodyMimeType(tokens []item) (*ast.DirectiveSecResponseBodyMimeType, []item, error) {
+	dir := &ast.DirectiveSecResponseBodyMimeType{
+		Value: []string{},
+	}
+
+	//The mime types can be passed as separate arguments or as one quoted argument seperated by whitespace
+	for {
+		var arg item
+		var err error
+		arg, tokens, err = parseDirectiveArgument(tokens)
+		if err != nil {
+			return nil, tokens, err
+		}
+
+		for _, mimeType := range strings.Fields(arg.val) {
+			if _, _, err := mime.ParseMediaType(mimeType); err != nil || strings.Count(mimeType, "/") != 1 {
+				return nil, tokens, fmt.Errorf("Invalid MIME type '%s' at '%s'", mimeType, arg.start)
+			}
+
+			dir.Value = append(dir.Value, mimeType)
+		}
+
+		if len(tokens) == 0 || tokens[0].typ != itemArgumentStart {
+			break
+		}
+	}
+
+	return dir, tokens, nil
+}
+
+func parseDirectiveSecRemoteRules(tokens []item) (*ast.DirectiveSecRemoteRules, []item, error) {
+	dir := &ast.DirectiveSecRemoteRules{}
+
+	arg, tokens, err := parseDirectiveArgument(tokens)
+	if err != nil {
+		return nil, tokens, err
+	}
+
+	dir.Key = arg.val
+
+	arg, tokens, err = parseDirectiveArgument(tokens)
+	if err != nil {
+		return nil, tokens, err
+	}
+
+	//ModSecurity refuses to download rules over an unencrypted connection
+	remoteURL, err := url.Parse(arg.val)
+	if err != nil || !strings.EqualFold(remoteURL.Scheme, "https") || remoteURL.Host == "" {
+		return nil, tokens, fmt.Errorf("Invalid URL '%s' at '%s', %s requires a https URL", arg.val, arg.start, dir.Name())
+	}
+
+	dir.URL = arg.val
+
+	return dir, tokens, nil
+}
+
+func parseDirectiveSecHashKey(tokens []item) (*ast.DirectiveSecHashKey, []item, error) {
+	dir := &ast.DirectiveSecHashKey{}
+
+	arg, tokens, err := parseDirectiveArgument(tokens)
+	if err != nil {
+		return nil, tokens, err
+	}
+
+	dir.Key = arg.val
+	if dir.Random() {
+		dir.Key = ast.SecHashKeyRand
+	}
+
+	var mask string
+	mask, tokens, err = parseDirectiveEnumValue(
+		tokens,
+		dir.Name(),
+		string(ast.SecHashKeyMaskKeyOnly),
+		string(ast.SecHashKeyMaskSessionID),
+		string(ast.SecHashKeyMaskRemoteIP),
+	)
+	if err != nil {
+		return nil, tokens, err
+	}
+
+	dir.Mask = ast.SecHashKeyMask(mask)
+
+	return dir, tokens, nil
+}
+
+func parseDirectiveHashMethod(tokens []item, directiveName string) (ast.HashMethod, []item, error) {
+	method, tokens, err := parseDirectiveEnumValue(
+		tokens,
+		directiveName,
+		string(ast.HashMethodHref),
+		string(ast.HashMethodFormAction),
+		string(ast.HashMethodIframeSrc),
+		string(ast.HashMethodFrameSrc),
+		string(ast.HashMethodLocation),
+	)
+
+	return ast.HashMethod(method), tokens, err
+}
+
+func parseDirectiveSecHashMethodPm(tokens []item) (*ast.DirectiveSecHashMethodPm, []item, error) {
+	dir := &ast.DirectiveSecHashMethodPm{}
+
+	var err error
+	dir.Method, tokens, err = parseDirectiveHashMethod(tokens, dir.Name())
+	if err != nil {
+		return nil, tokens, err
+	}
+
+	var arg item
+	arg, tokens, err = parseDirectiveArgument(tokens)
+	if err != nil {
+		return nil, tokens, err
+	}
+
+	dir.Phrases = strings.Fields(arg.val)
+
+	return dir, tokens, nil
+}
+
+func parseDirectiveSecHashMethodRx(tokens []item) (*ast.DirectiveSecHashMethodRx, []item, error) {
+	dir := &ast.DirectiveSecHashMethodRx{}
+
+	var err error
+	dir.Method, tokens, err = parseDirectiveHashMethod(tokens, dir.Name())
+	if err != nil {
+		return nil, tokens, err
+	}
+
+	var arg item
+	arg, tokens, err = parseDirectiveArgument(tokens)
+	if err != nil {
+		return nil, tokens, err
+	}
+
+	if err := validateRegex(arg.val); err != nil {
+		return nil, tokens, fmt.Errorf("Invalid regular expression '%s' at '%s': %w", arg.val, arg.start, err)
+	}
+
+	dir.Regex = arg.val
+
+	return dir, tokens, nil
+}
+
 func parseDirectiveSecAuditEngineValue(tokens []item) (ast.SecAuditEngineValue, []item, error) {
 	switch strings.ToLower(tokens[0].val) {
 	case strings.ToLower(string(ast.ModsecOn)):
@@ -209,6 +802,278 @@ func parseDirectiveSecAuditLogPartsValue(tokens []item) ([]ast.SecAuditLogPart,
 	return parts, tokens, nil
 }
 
+func parseDirectiveSecAuditLogFormatValue(tokens []item) (ast.SecAuditLogFormatValue, []item, error) {
+	arg, tokens, err := parseDirectiveArgument(tokens)
+	if err != nil {
+		return ast.SecAuditLogFormatValue(""), tokens, err
+	}
+
+	switch strings.ToLower(arg.val) {
+	case strings.ToLower(string(ast.SecAuditLogFormatJSON)):
+		return ast.SecAuditLogFormatJSON, tokens, nil
+
+	case strings.ToLower(string(ast.SecAuditLogFormatNative)):
+		return ast.SecAuditLogFormatNative, tokens, nil
+
+	default:
+		return ast.SecAuditLogFormatValue(""), tokens, fmt.Errorf("Unknown SecAuditLogFormat value '%s' at '%s'", arg.val, arg.start)
+	}
+}
+
+func parseDirectiveSecAuditLogRelevantStatus(tokens []item) (*ast.DirectiveSecAuditLogRelevantStatus, []item, error) {
+	arg, tokens, err := parseDirectiveArgument(tokens)
+	if err != nil {
+		return nil, tokens, err
+	}
+
+	if err := validateRegex(arg.val); err != nil {
+		return nil, tokens, fmt.Errorf("Invalid regular expression '%s' at '%s': %w", arg.val, arg.start, err)
+	}
+
+	return &ast.DirectiveSecAuditLogRelevantStatus{Value: arg.val}, tokens, nil
+}
+
+func parseDirectiveSecAuditLogTypeValue(tokens []item) (ast.SecAuditLogTypeValue, []item, error) {
+	arg, tokens, err := parseDirectiveArgument(tokens)
+	if err != nil {
+		return ast.SecAuditLogTypeValue(""), tokens, err
+	}
+
+	switch strings.ToLower(arg.val) {
+	case strings.ToLower(string(ast.SecAuditLogTypeSerial)):
+		return ast.SecAuditLogTypeSerial, tokens, nil
+
+	case strings.ToLower(string(ast.SecAuditLogTypeConcurrent)):
+		return ast.SecAuditLogTypeConcurrent, tokens, nil
+
+	case strings.ToLower(string(ast.SecAuditLogTypeHTTPS)):
+		return ast.SecAuditLogTypeHTTPS, tokens, nil
+
+	default:
+		return ast.SecAuditLogTypeValue(""), tokens, fmt.Errorf("Unknown SecAuditLogType value '%s' at '%s'", arg.val, arg.start)
+	}
+}
+
+func parseDirectiveSecArgumentSeparator(tokens []item) (*ast.DirectiveSecArgumentSeparator, []item, error) {
+	arg, tokens, err := parseDirectiveArgument(tokens)
+	if err != nil {
+		return nil, tokens, err
+	}
+
+	if len(arg.val) != 1 {
+		return nil, tokens, fmt.Errorf("Invalid separator '%s' at '%s', the separator must be a single character", arg.val, arg.start)
+	}
+
+	return &ast.DirectiveSecArgumentSeparator{Value: arg.val}, tokens, nil
+}
+
+func parseDirectiveSecConnReadStateLimit(tokens []item) (*ast.DirectiveSecConnReadStateLimit, []item, error) {
+	dir := &ast.DirectiveSecConnReadStateLimit{}
+
+	var err error
+	dir.Limit, dir.Operator, tokens, err = parseDirectiveConnStateLimit(tokens, dir.Name())
+	if err != nil {
+		return nil, tokens, err
+	}
+
+	if dir.Operator != nil {
+		dir.Operator.SetParent(dir)
+	}
+
+	return dir, tokens, nil
+}
+
+func parseDirectiveSecConnWriteStateLimit(tokens []item) (*ast.DirectiveSecConnWriteStateLimit, []item, error) {
+	dir := &ast.DirectiveSecConnWriteStateLimit{}
+
+	var err error
+	dir.Limit, dir.Operator, tokens, err = parseDirectiveConnStateLimit(tokens, dir.Name())
+	if err != nil {
+		return nil, tokens, err
+	}
+
+	if dir.Operator != nil {
+		dir.Operator.SetParent(dir)
+	}
+
+	return dir, tokens, nil
+}
+
+//parseDirectiveConnStateLimit parses the arguments of the SecConnReadStateLimit and SecConnWriteStateLimit directives.
+// The first argument is the limit, the optional second argument is a @ipMatch operator
+func parseDirectiveConnStateLimit(tokens []item, directiveName string) (int, ast.Operator, []item, error) {
+	limit, tokens, err := parseDirectiveIntValue(tokens, directiveName, 0, math.MaxInt32)
+	if err != nil {
+		return 0, nil, tokens, err
+	}
+
+	//There is no second argument
+	if len(tokens) == 0 || tokens[0].typ != itemArgumentStart {
+		return limit, nil, tokens, nil
+	}
+
+	start := tokens[0].start
+
+	operator, tokens, err := parseSecRuleOperator(tokens)
+	if err != nil {
+		return 0, nil, tokens, err
+	}
+
+	if _, ok := operator.(*ast.OperatorIPMatch); !ok {
+		return 0, nil, tokens, fmt.Errorf("Unexpected operator '%s' at '%s', %s only supports @ipMatch", operator.Name(), start, directiveName)
+	}
+
+	return limit, operator, tokens, nil
+}
+
+func parseDirectiveSecCookieV0Separator(tokens []item) (*ast.DirectiveSecCookieV0Separator, []item, error) {
+	arg, tokens, err := parseDirectiveArgument(tokens)
+	if err != nil {
+		return nil, tokens, err
+	}
+
+	if len(arg.val) != 1 {
+		return nil, tokens, fmt.Errorf("Invalid separator '%s' at '%s', the separator must be a single character", arg.val, arg.start)
+	}
+
+	return &ast.DirectiveSecCookieV0Separator{Value: arg.val}, tokens, nil
+}
+
+func parseDirectiveSecUnicodeMapFile(tokens []item) (*ast.DirectiveSecUnicodeMapFile, []item, error) {
+	dir := &ast.DirectiveSecUnicodeMapFile{}
+
+	arg, tokens, err := parseDirectiveArgument(tokens)
+	if err != nil {
+		return nil, tokens, err
+	}
+	dir.Path = arg.val
+
+	//The code page is optional, it can also be set with SecUnicodeCodePage
+	if len(tokens) == 0 || tokens[0].typ != itemArgumentStart {
+		return dir, tokens, nil
+	}
+
+	dir.CodePage, tokens, err = parseDirectiveIntValue(tokens, dir.Name(), 1, math.MaxInt32)
+	if err != nil {
+		return nil, tokens, err
+	}
+
+	return dir, tokens, nil
+}
+
+//parseDirectiveFileModeValue parses a octal file mode like 0640 or the keyword 'default'
+func parseDirectiveFileModeValue(tokens []item) (ast.FileMode, []item, error) {
+	arg, tokens, err := parseDirectiveArgument(tokens)
+	if err != nil {
+		return ast.FileMode{}, tokens, err
+	}
+
+	if strings.ToLower(arg.val) == "default" {
+		return ast.FileMode{Default: true}, tokens, nil
+	}
+
+	mode, err := strconv.ParseUint(arg.val, 8, 32)
+	fileMode := ast.FileMode{Mode: os.FileMode(mode)}
+	if err != nil || !fileMode.Valid() {
+		return ast.FileMode{}, tokens, fmt.Errorf("Invalid file mode '%s' at '%s', expected 'default' or a octal value between 0000 and 07777", arg.val, arg.start)
+	}
+
+	return fileMode, tokens, nil
+}
+
+//parseDirectiveEnumValue parses a directive argument which must be one of the given options.
+// Options are matched case insensitive, the returned value is the option as it was passed
+func parseDirectiveEnumValue(tokens []item, directiveName string, options ...string) (string, []item, error) {
+	arg, tokens, err := parseDirectiveArgument(tokens)
+	if err != nil {
+		return "", tokens, err
+	}
+
+	for _, option := range options {
+		if strings.ToLower(arg.val) == strings.ToLower(option) {
+			return option, tokens, nil
+		}
+	}
+
+	return "", tokens, fmt.Errorf("Unknown %s value '%s' at '%s', expected: %s", directiveName, arg.val, arg.start, strings.Join(options, ", "))
+}
+
+//parseDirectiveIntValue parses a directive argument which must be a decimal number between min and max (inclusive)
+func parseDirectiveIntValue(tokens []item, directiveName string, min, max int) (int, []item, error) {
+	arg, tokens, err := parseDirectiveArgument(tokens)
+	if err != nil {
+		return 0, tokens, err
+	}
+
+	value, err := strconv.Atoi(arg.val)
+	if err != nil || value < min || value > max {
+		return 0, tokens, fmt.Errorf("Value of %s must be a number between %d and %d, got: '%s' at '%s'", directiveName, min, max, arg.val, arg.start)
+	}
+
+	return value, tokens, nil
+}
+
+//parseDirectiveArgument consumes all tokens of the next directive argument and returns them as a single ident item.
+// The start of the returned item is the start of the first token in the argument
+func parseDirectiveArgument(tokens []item) (item, []item, error) {
+	arg := item{typ: itemIdent}
+
+	//Consume all tokens until the start of the argument
+	for {
+		if len(tokens) == 0 {
+			return arg, tokens, fmt.Errorf("Unexpected end of file, expected directive argument")
+		}
+
+		if tokens[0].typ == itemArgumentStart {
+			tokens = tokens[1:]
+			break
+		}
+
+		if tokens[0].typ == itemDirective || tokens[0].typ == itemCommentStart {
+			return arg, tokens, fmt.Errorf("Unexpected '%s' at '%s', expected directive argument", tokens[0].val, tokens[0].start)
+		}
+
+		tokens = tokens[1:]
+	}
+
+	if len(tokens) > 0 {
+		arg.start = tokens[0].start
+	}
+
+	for {
+		//An unquoted argument at the end of a line has no stop token, the next line starts with a directive or comment
+		if len(tokens) == 0 || tokens[0].typ == itemDirective || tokens[0].typ == itemCommentStart {
+			break
+		}
+
+		if tokens[0].typ == itemArgumentStop {
+			tokens = tokens[1:]
+			break
+		}
+
+		arg.val += tokens[0].val
+		tokens = tokens[1:]
+	}
+
+	if arg.val == "" {
+		return arg, tokens, fmt.Errorf("Empty directive argument at '%s'", arg.start)
+	}
+
+	return arg, tokens, nil
+}
+
+//validateRegex checks if the pattern is a valid PCRE regular expression.
+// Go implements RE2 which doesn't support all PCRE features (lookarounds, backreferences and possessive quantifiers)
+// so we only report errors which are also errors in PCRE, like unbalanced parentheses and brackets.
+func validateRegex(pattern string) error {
+	_, err := syntax.Parse(pattern, syntax.Perl)
+	if err == nil || operator.IsUnsupportedRegex(err) {
+		return nil
+	}
+
+	return err
+}
+
 func parseDirectiveSecRuleEngineValue(tokens []item) (ast.SecRuleEngineValue, []item, error) {
 	switch strings.ToLower(tokens[0].val) {
 	case strings.ToLower(string(ast.ModsecOn)):
@@ -238,6 +1103,19 @@ func parseDirectiveSecAction(tokens []item) (*ast.DirectiveSecAction, []item, er
 	return secAction, tokens, err
 }
 
+func parseDirectiveSecDefaultAction(tokens []item) (*ast.DirectiveSecDefaultAction, []item, error) {
+	if tokens[0].typ != itemArgumentStart {
+		return nil, tokens, fmt.Errorf("Unexpected '%s' as '%s', Expected start of argument", tokens[0].typ, tokens[0].start)
+	}
+
+	secDefaultAction := &ast.DirectiveSecDefaultAction{}
+	var err error
+
+	secDefaultAction.ActionNodes, tokens, err = parseActionList(tokens)
+
+	return secDefaultAction, tokens, err
+}
+
 func parseDirectiveSecComponentSignature(tokens []item) (*ast.DirectiveSecComponentSignature, []item, error) {
 	if tokens[0].typ != itemArgumentStart {
 		return nil, tokens, fmt.Errorf("Unexpected '%s' as '%s', Expected start of argument", tokens[0].typ, tokens[0].start)
@@ -283,8 +1161,31 @@ func parseDirectiveSecRule(tokens []item) (*ast.DirectiveSecRule, []item, error)
 		return nil, tokens, err
 	}
 
-	//Actions are optional. So if there is no start of the third argument this is it
-	if tokens[0].typ != itemArgumentStart {
+	//Actions are optional. So if there is no start of the third argument this is it
+	if tokens[0].typ != itemArgumentStart {
+		return rule, tokens, nil
+	}
+
+	rule.ActionNodes, tokens, err = parseActionList(tokens)
+	if err != nil {
+		return nil, tokens, err
+	}
+
+	return rule, tokens, nil
+}
+
+func parseDirectiveSecRuleScript(tokens []item) (*ast.DirectiveSecRuleScript, []item, error) {
+	rule := &ast.DirectiveSecRuleScript{}
+
+	arg, tokens, err := parseDirectiveArgument(tokens)
+	if err != nil {
+		return nil, tokens, err
+	}
+
+	rule.Path = arg.val
+
+	//Actions are optional. So if there is no start of the second argument this is it
+	if len(tokens) == 0 || tokens[0].typ != itemArgumentStart {
 		return rule, tokens, nil
 	}
 
@@ -446,6 +1347,28 @@ func parseSecRuleOperator(tokens []item) (ast.Operator, []item, error) {
 
 		operator = op
 
+	case strings.ToLower((&ast.OperatorInspectFile{}).Name()):
+		op := &ast.OperatorInspectFile{}
+		if tokens[1].typ == itemWhitespace {
+			tokens = tokens[2:]
+			for {
+				if len(tokens) == 0 {
+					break
+				}
+
+				if tokens[0].typ == itemArgumentStop {
+					tokens = tokens[1:]
+					break
+				}
+
+				op.Value += tokens[0].val
+				tokens = tokens[1:]
+			}
+		} else {
+			return operator, tokens, fmt.Errorf("Unexpected '%s' at '%s', expected operator argument", tokens[0].val, tokens[0].start)
+		}
+		operator = op
+
 	case strings.ToLower((&ast.OperatorIPMatch{}).Name()):
 		op := &ast.OperatorIPMatch{}
 
@@ -922,9 +1845,21 @@ func parseVariable(tokens []item) (ast.Variable, []item, error) {
 	case strings.ToLower((&ast.VariableArgsNames{}).Name()):
 		return &ast.VariableArgsNames{}, tokens[1:], nil
 
+	case strings.ToLower((&ast.VariableArgsPost{}).Name()):
+		return &ast.VariableArgsPost{}, tokens[1:], nil
+
+	case strings.ToLower((&ast.VariableArgsPostNames{}).Name()):
+		return &ast.VariableArgsPostNames{}, tokens[1:], nil
+
+	case strings.ToLower((&ast.VariableAuthType{}).Name()):
+		return &ast.VariableAuthType{}, tokens[1:], nil
+
 	case strings.ToLower((&ast.VariableDuration{}).Name()):
 		return &ast.VariableDuration{}, tokens[1:], nil
 
+	case strings.ToLower((&ast.VariableEnv{}).Name()):
+		return &ast.VariableEnv{}, tokens[1:], nil
+
 	case strings.ToLower((&ast.VariableFiles{}).Name()):
 		return &ast.VariableFiles{}, tokens[1:], nil
 
@@ -934,36 +1869,178 @@ func parseVariable(tokens []item) (ast.Variable, []item, error) {
 	case strings.ToLower((&ast.VariableFilesNames{}).Name()):
 		return &ast.VariableFilesNames{}, tokens[1:], nil
 
+	case strings.ToLower((&ast.VariableFilesSizes{}).Name()):
+		return &ast.VariableFilesSizes{}, tokens[1:], nil
+
+	case strings.ToLower((&ast.VariableFilesTmpNames{}).Name()):
+		return &ast.VariableFilesTmpNames{}, tokens[1:], nil
+
+	case strings.ToLower((&ast.VariableFilesTmpContent{}).Name()):
+		return &ast.VariableFilesTmpContent{}, tokens[1:], nil
+
+	case strings.ToLower((&ast.VariableFullRequest{}).Name()):
+		return &ast.VariableFullRequest{}, tokens[1:], nil
+
+	case strings.ToLower((&ast.VariableFullRequestLength{}).Name()):
+		return &ast.VariableFullRequestLength{}, tokens[1:], nil
+
 	case strings.ToLower((&ast.VariableGEO{}).Name()):
 		return &ast.VariableGEO{}, tokens[1:], nil
 
+	case strings.ToLower((&ast.VariableGlobal{}).Name()):
+		return &ast.VariableGlobal{}, tokens[1:], nil
+
+	case strings.ToLower((&ast.VariableHighestSeverity{}).Name()):
+		return &ast.VariableHighestSeverity{}, tokens[1:], nil
+
+	case strings.ToLower((&ast.VariableInboundDataError{}).Name()):
+		return &ast.VariableInboundDataError{}, tokens[1:], nil
+
+	case strings.ToLower((&ast.VariableIP{}).Name()):
+		return &ast.VariableIP{}, tokens[1:], nil
+
+	case strings.ToLower((&ast.VariableMatchedVar{}).Name()):
+		return &ast.VariableMatchedVar{}, tokens[1:], nil
+
+	case strings.ToLower((&ast.VariableMatchedVarName{}).Name()):
+		return &ast.VariableMatchedVarName{}, tokens[1:], nil
+
 	case strings.ToLower((&ast.VariableMatchedVars{}).Name()):
 		return &ast.VariableMatchedVars{}, tokens[1:], nil
 
 	case strings.ToLower((&ast.VariableMatchedVarsNames{}).Name()):
 		return &ast.VariableMatchedVarsNames{}, tokens[1:], nil
 
+	case strings.ToLower((&ast.VariableModsecBuild{}).Name()):
+		return &ast.VariableModsecBuild{}, tokens[1:], nil
+
+	case strings.ToLower((&ast.VariableMultipartBoundaryQuoted{}).Name()):
+		return &ast.VariableMultipartBoundaryQuoted{}, tokens[1:], nil
+
+	case strings.ToLower((&ast.VariableMultipartBoundaryWhitespace{}).Name()):
+		return &ast.VariableMultipartBoundaryWhitespace{}, tokens[1:], nil
+
+	case strings.ToLower((&ast.VariableMultipartCRLFLFLines{}).Name()):
+		return &ast.VariableMultipartCRLFLFLines{}, tokens[1:], nil
+
+	case strings.ToLower((&ast.VariableMultipartDataAfter{}).Name()):
+		return &ast.VariableMultipartDataAfter{}, tokens[1:], nil
+
+	case strings.ToLower((&ast.VariableMultipartDataBefore{}).Name()):
+		return &ast.VariableMultipartDataBefore{}, tokens[1:], nil
+
+	case strings.ToLower((&ast.VariableMultipartFileLimitExceeded{}).Name()):
+		return &ast.VariableMultipartFileLimitExceeded{}, tokens[1:], nil
+
+	case strings.ToLower((&ast.VariableMultipartFilename{}).Name()):
+		return &ast.VariableMultipartFilename{}, tokens[1:], nil
+
+	case strings.ToLower((&ast.VariableMultipartHeaderFolding{}).Name()):
+		return &ast.VariableMultipartHeaderFolding{}, tokens[1:], nil
+
+	case strings.ToLower((&ast.VariableMultipartInvalidHeaderFolding{}).Name()):
+		return &ast.VariableMultipartInvalidHeaderFolding{}, tokens[1:], nil
+
+	case strings.ToLower((&ast.VariableMultipartInvalidPart{}).Name()):
+		return &ast.VariableMultipartInvalidPart{}, tokens[1:], nil
+
+	case strings.ToLower((&ast.VariableMultipartInvalidQuoting{}).Name()):
+		return &ast.VariableMultipartInvalidQuoting{}, tokens[1:], nil
+
+	case strings.ToLower((&ast.VariableMultipartLFLine{}).Name()):
+		return &ast.VariableMultipartLFLine{}, tokens[1:], nil
+
+	//MULTIPART_SEMICOLON_MISSING is an alias of MULTIPART_MISSING_SEMICOLON
+	case strings.ToLower((&ast.VariableMultipartMissingSemicolon{}).Name()), "multipart_semicolon_missing":
+		return &ast.VariableMultipartMissingSemicolon{}, tokens[1:], nil
+
+	case strings.ToLower((&ast.VariableMultipartName{}).Name()):
+		return &ast.VariableMultipartName{}, tokens[1:], nil
+
 	case strings.ToLower((&ast.VariableMultipartStructError{}).Name()):
 		return &ast.VariableMultipartStructError{}, tokens[1:], nil
 
+	case strings.ToLower((&ast.VariableMultipartUnmatchedBoundary{}).Name()):
+		return &ast.VariableMultipartUnmatchedBoundary{}, tokens[1:], nil
+
+	case strings.ToLower((&ast.VariableOutboundDataError{}).Name()):
+		return &ast.VariableOutboundDataError{}, tokens[1:], nil
+
+	case strings.ToLower((&ast.VariablePathInfo{}).Name()):
+		return &ast.VariablePathInfo{}, tokens[1:], nil
+
+	case strings.ToLower((&ast.VariablePerfAll{}).Name()):
+		return &ast.VariablePerfAll{}, tokens[1:], nil
+
+	case strings.ToLower((&ast.VariablePerfCombined{}).Name()):
+		return &ast.VariablePerfCombined{}, tokens[1:], nil
+
+	case strings.ToLower((&ast.VariablePerfGC{}).Name()):
+		return &ast.VariablePerfGC{}, tokens[1:], nil
+
+	case strings.ToLower((&ast.VariablePerfLogging{}).Name()):
+		return &ast.VariablePerfLogging{}, tokens[1:], nil
+
+	case strings.ToLower((&ast.VariablePerfPhase1{}).Name()):
+		return &ast.VariablePerfPhase1{}, tokens[1:], nil
+
+	case strings.ToLower((&ast.VariablePerfPhase2{}).Name()):
+		return &ast.VariablePerfPhase2{}, tokens[1:], nil
+
+	case strings.ToLower((&ast.VariablePerfPhase3{}).Name()):
+		return &ast.VariablePerfPhase3{}, tokens[1:], nil
+
+	case strings.ToLower((&ast.VariablePerfPhase4{}).Name()):
+		return &ast.VariablePerfPhase4{}, tokens[1:], nil
+
+	case strings.ToLower((&ast.VariablePerfPhase5{}).Name()):
+		return &ast.VariablePerfPhase5{}, tokens[1:], nil
+
+	case strings.ToLower((&ast.VariablePerfRules{}).Name()):
+		return &ast.VariablePerfRules{}, tokens[1:], nil
+
+	case strings.ToLower((&ast.VariablePerfSread{}).Name()):
+		return &ast.VariablePerfSread{}, tokens[1:], nil
+
+	case strings.ToLower((&ast.VariablePerfSwrite{}).Name()):
+		return &ast.VariablePerfSwrite{}, tokens[1:], nil
+
 	case strings.ToLower((&ast.VariableQueryString{}).Name()):
 		return &ast.VariableQueryString{}, tokens[1:], nil
 
 	case strings.ToLower((&ast.VariableRemoteAddress{}).Name()):
 		return &ast.VariableRemoteAddress{}, tokens[1:], nil
 
+	case strings.ToLower((&ast.VariableRemoteHost{}).Name()):
+		return &ast.VariableRemoteHost{}, tokens[1:], nil
+
+	case strings.ToLower((&ast.VariableRemotePort{}).Name()):
+		return &ast.VariableRemotePort{}, tokens[1:], nil
+
+	case strings.ToLower((&ast.VariableRemoteUser{}).Name()):
+		return &ast.VariableRemoteUser{}, tokens[1:], nil
+
 	case strings.ToLower((&ast.VariableRequestBodyError{}).Name()):
 		return &ast.VariableRequestBodyError{}, tokens[1:], nil
 
+	case strings.ToLower((&ast.VariableRequestBodyErrorMessage{}).Name()):
+		return &ast.VariableRequestBodyErrorMessage{}, tokens[1:], nil
+
 	case strings.ToLower((&ast.VariableRequestBodyProcessor{}).Name()):
 		return &ast.VariableRequestBodyProcessor{}, tokens[1:], nil
 
+	case strings.ToLower((&ast.VariableRequestBodyProcessorError{}).Name()):
+		return &ast.VariableRequestBodyProcessorError{}, tokens[1:], nil
+
 	case strings.ToLower((&ast.VariableRequestBasename{}).Name()):
 		return &ast.VariableRequestBasename{}, tokens[1:], nil
 
 	case strings.ToLower((&ast.VariableRequestBody{}).Name()):
 		return &ast.VariableRequestBody{}, tokens[1:], nil
 
+	case strings.ToLower((&ast.VariableRequestBodyLength{}).Name()):
+		return &ast.VariableRequestBodyLength{}, tokens[1:], nil
+
 	case strings.ToLower((&ast.VariableRequestCookies{}).Name()):
 		return &ast.VariableRequestCookies{}, tokens[1:], nil
 
@@ -994,31 +2071,141 @@ func parseVariable(tokens []item) (ast.Variable, []item, error) {
 	case strings.ToLower((&ast.VariableRequestURIRaw{}).Name()):
 		return &ast.VariableRequestURIRaw{}, tokens[1:], nil
 
+	case strings.ToLower((&ast.VariableResponseBody{}).Name()):
+		return &ast.VariableResponseBody{}, tokens[1:], nil
+
+	case strings.ToLower((&ast.VariableResponseContentLength{}).Name()):
+		return &ast.VariableResponseContentLength{}, tokens[1:], nil
+
+	case strings.ToLower((&ast.VariableResponseContentType{}).Name()):
+		return &ast.VariableResponseContentType{}, tokens[1:], nil
+
+	case strings.ToLower((&ast.VariableResponseHeaders{}).Name()):
+		return &ast.VariableResponseHeaders{}, tokens[1:], nil
+
+	case strings.ToLower((&ast.VariableResponseHeadersNames{}).Name()):
+		return &ast.VariableResponseHeadersNames{}, tokens[1:], nil
+
+	case strings.ToLower((&ast.VariableResponseProtocol{}).Name()):
+		return &ast.VariableResponseProtocol{}, tokens[1:], nil
+
+	case strings.ToLower((&ast.VariableResponseStatus{}).Name()):
+		return &ast.VariableResponseStatus{}, tokens[1:], nil
+
+	case strings.ToLower((&ast.VariableResource{}).Name()):
+		return &ast.VariableResource{}, tokens[1:], nil
+
+	case strings.ToLower((&ast.VariableRule{}).Name()):
+		return &ast.VariableRule{}, tokens[1:], nil
+
+	case strings.ToLower((&ast.VariableScriptBasename{}).Name()):
+		return &ast.VariableScriptBasename{}, tokens[1:], nil
+
+	case strings.ToLower((&ast.VariableScriptFilename{}).Name()):
+		return &ast.VariableScriptFilename{}, tokens[1:], nil
+
+	case strings.ToLower((&ast.VariableScriptGID{}).Name()):
+		return &ast.VariableScriptGID{}, tokens[1:], nil
+
+	case strings.ToLower((&ast.VariableScriptGroupname{}).Name()):
+		return &ast.VariableScriptGroupname{}, tokens[1:], nil
+
+	case strings.ToLower((&ast.VariableScriptMode{}).Name()):
+		return &ast.VariableScriptMode{}, tokens[1:], nil
+
+	case strings.ToLower((&ast.VariableScriptUID{}).Name()):
+		return &ast.VariableScriptUID{}, tokens[1:], nil
+
+	case strings.ToLower((&ast.VariableScriptUsername{}).Name()):
+		return &ast.VariableScriptUsername{}, tokens[1:], nil
+
+	case strings.ToLower((&ast.VariableSdbmDeleteError{}).Name()):
+		return &ast.VariableSdbmDeleteError{}, tokens[1:], nil
+
+	case strings.ToLower((&ast.VariableServerAddress{}).Name()):
+		return &ast.VariableServerAddress{}, tokens[1:], nil
+
+	case strings.ToLower((&ast.VariableServerName{}).Name()):
+		return &ast.VariableServerName{}, tokens[1:], nil
+
+	case strings.ToLower((&ast.VariableServerPort{}).Name()):
+		return &ast.VariableServerPort{}, tokens[1:], nil
+
+	case strings.ToLower((&ast.VariableSession{}).Name()):
+		return &ast.VariableSession{}, tokens[1:], nil
+
+	case strings.ToLower((&ast.VariableSessionID{}).Name()):
+		return &ast.VariableSessionID{}, tokens[1:], nil
+
+	case strings.ToLower((&ast.VariableStatusLine{}).Name()):
+		return &ast.VariableStatusLine{}, tokens[1:], nil
+
+	case strings.ToLower((&ast.VariableStreamInputBody{}).Name()):
+		return &ast.VariableStreamInputBody{}, tokens[1:], nil
+
+	case strings.ToLower((&ast.VariableStreamOutputBody{}).Name()):
+		return &ast.VariableStreamOutputBody{}, tokens[1:], nil
+
+	case strings.ToLower((&ast.VariableTime{}).Name()):
+		return &ast.VariableTime{}, tokens[1:], nil
+
+	case strings.ToLower((&ast.VariableTimeDay{}).Name()):
+		return &ast.VariableTimeDay{}, tokens[1:], nil
+
+	case strings.ToLower((&ast.VariableTimeEpoch{}).Name()):
+		return &ast.VariableTimeEpoch{}, tokens[1:], nil
+
+	case strings.ToLower((&ast.VariableTimeHour{}).Name()):
+		return &ast.VariableTimeHour{}, tokens[1:], nil
+
+	case strings.ToLower((&ast.VariableTimeMin{}).Name()):
+		return &ast.VariableTimeMin{}, tokens[1:], nil
+
+	case strings.ToLower((&ast.VariableTimeMon{}).Name()):
+		return &ast.VariableTimeMon{}, tokens[1:], nil
+
+	case strings.ToLower((&ast.VariableTimeSec{}).Name()):
+		return &ast.VariableTimeSec{}, tokens[1:], nil
+
+	case strings.ToLower((&ast.VariableTimeWDay{}).Name()):
+		return &ast.VariableTimeWDay{}, tokens[1:], nil
+
+	case strings.ToLower((&ast.VariableTimeYear{}).Name()):
+		return &ast.VariableTimeYear{}, tokens[1:], nil
+
 	case strings.ToLower((&ast.VariableTransientTransactionCollection{}).Name()):
 		return &ast.VariableTransientTransactionCollection{}, tokens[1:], nil
 
 	case strings.ToLower((&ast.VariableUniqueID{}).Name()):
 		return &ast.VariableUniqueID{}, tokens[1:], nil
 
-	case strings.ToLower((&ast.VariableXML{}).Name()):
-		return &ast.VariableXML{}, tokens[1:], nil
-	}
+	case strings.ToLower((&ast.VariableURLEncodedError{}).Name()):
+		return &ast.VariableURLEncodedError{}, tokens[1:], nil
 
-	//Assume the variable is a custom collection
-	//TODO remove whitelist when all variables in the spec have been implemented
+	case strings.ToLower((&ast.VariableUser{}).Name()):
+		return &ast.VariableUser{}, tokens[1:], nil
 
-	//A whitelist of variables of which we know they are custom collections in the CRS
-	// we use this while developing so we trigger errors if the variable name is unknown
-	whitelist := []string{"IP"}
-	for _, name := range whitelist {
-		if strings.ToLower(tokens[0].val) == strings.ToLower(name) {
-			return &ast.VariableCustomCollection{
-				VariableName: strings.ToUpper(tokens[0].val),
-			}, tokens[1:], nil
-		}
+	case strings.ToLower((&ast.VariableUserAgentIP{}).Name()):
+		return &ast.VariableUserAgentIP{}, tokens[1:], nil
+
+	case strings.ToLower((&ast.VariableUserID{}).Name()):
+		return &ast.VariableUserID{}, tokens[1:], nil
+
+	case strings.ToLower((&ast.VariableWebAppID{}).Name()):
+		return &ast.VariableWebAppID{}, tokens[1:], nil
+
+	case strings.ToLower((&ast.VariableWebserverErrorLog{}).Name()):
+		return &ast.VariableWebserverErrorLog{}, tokens[1:], nil
+
+	case strings.ToLower((&ast.VariableXML{}).Name()):
+		return &ast.VariableXML{}, tokens[1:], nil
 	}
 
-	return nil, tokens, fmt.Errorf("Unknown variable name '%s' at '%s'", tokens[0].val, tokens[0].start)
+	//Any other name is assumed to be a custom collection created with initcol.
+	// Since it can be initialized anywhere in the config, checking if it actually exists or is a misspelled variable is left to the linter
+	return &ast.VariableCustomCollection{
+		VariableName: strings.ToUpper(tokens[0].val),
+	}, tokens[1:], nil
 }
 
 func parseActionList(tokens []item) ([]ast.Action, []item, error) {
@@ -1054,8 +2241,6 @@ func parseActionList(tokens []item) ([]ast.Action, []item, error) {
 			return actions, tokens, fmt.Errorf("Unexpected '%s' at '%s', expected action name", tokens[0].val, tokens[0].start)
 		}
 	}
-
-	panic("Last token was not EOF")
 }
 
 func parseAction(tokens []item) (ast.Action, []item, error) {
@@ -1101,6 +2286,9 @@ func parseAction(tokens []item) (ast.Action, []item, error) {
 		action = &ast.ActionDrop{}
 		tokens = tokens[1:]
 
+	case (&ast.ActionExec{}).Name():
+		action, tokens, err = parseActionExec(tokens)
+
 	case (&ast.ActionExpireVar{}).Name():
 		action, tokens, err = parseActionExpireVar(tokens)
 
@@ -1136,9 +2324,18 @@ func parseAction(tokens []item) (ast.Action, []item, error) {
 		action = &ast.ActionPass{}
 		tokens = tokens[1:]
 
+	case (&ast.ActionPause{}).Name():
+		action, tokens, err = parseActionPause(tokens)
+
 	case (&ast.ActionPhase{}).Name():
 		action, tokens, err = parseActionPhase(tokens)
 
+	case (&ast.ActionProxy{}).Name():
+		action, tokens, err = parseActionProxy(tokens)
+
+	case (&ast.ActionRedirect{}).Name():
+		action, tokens, err = parseActionRedirect(tokens)
+
 	case (&ast.ActionSeverity{}).Name():
 		action, tokens, err = parseActionSeverity(tokens)
 
@@ -1247,6 +2444,23 @@ func parseActionVer(tokens []item) (*ast.ActionVer, []item, error) {
 	return action, tokens, nil
 }
 
+func parseActionExec(tokens []item) (*ast.ActionExec, []item, error) {
+	action := &ast.ActionExec{}
+
+	if tokens[1].typ != itemColon {
+		return nil, tokens, fmt.Errorf("Unexpected '%s' at '%s', expected a colon", tokens[1].val, tokens[1].start)
+	}
+
+	start := tokens[1].start
+
+	action.Path, tokens = parseRawActionArgument(tokens[2:])
+	if action.Path == "" {
+		return nil, tokens, fmt.Errorf("Missing path of exec action at '%s'", start)
+	}
+
+	return action, tokens, nil
+}
+
 func parseActionCTL(tokens []item) (*ast.ActionCTL, []item, error) {
 	action := &ast.ActionCTL{}
 
@@ -1307,6 +2521,50 @@ func parseActionCTL(tokens []item) (*ast.ActionCTL, []item, error) {
 
 		tokens = tokens[5:]
 
+	case strings.ToLower("hashEngine"):
+		if tokens[3].typ != itemEquals {
+			return nil, tokens, fmt.Errorf("Unexpected '%s' at '%s', expected a equals sign", tokens[3].val, tokens[3].start)
+		}
+
+		option := &ast.DirectiveSecHashEngine{}
+
+		switch strings.ToLower(tokens[4].val) {
+		case strings.ToLower(string(ast.ModsecOn)):
+			option.Value = ast.SecHashEngineValue(ast.ModsecOn)
+
+		case strings.ToLower(string(ast.ModsecOff)):
+			option.Value = ast.SecHashEngineValue(ast.ModsecOff)
+
+		default:
+			return nil, tokens, fmt.Errorf("Unexpected '%s' at '%s', expected: On or Off", tokens[4].val, tokens[4].start)
+		}
+
+		action.Option = option
+
+		tokens = tokens[5:]
+
+	case strings.ToLower((&ast.ActionCTLHashEnforcement{}).Name()):
+		if tokens[3].typ != itemEquals {
+			return nil, tokens, fmt.Errorf("Unexpected '%s' at '%s', expected a equals sign", tokens[3].val, tokens[3].start)
+		}
+
+		option := &ast.ActionCTLHashEnforcement{}
+
+		switch strings.ToLower(tokens[4].val) {
+		case strings.ToLower(string(ast.ModsecOn)):
+			option.Enabled = true
+
+		case strings.ToLower(string(ast.ModsecOff)):
+			option.Enabled = false
+
+		default:
+			return nil, tokens, fmt.Errorf("Unexpected '%s' at '%s', expected: On or Off", tokens[4].val, tokens[4].start)
+		}
+
+		action.Option = option
+
+		tokens = tokens[5:]
+
 	case strings.ToLower((&ast.ActionCTLRequestBodyProcessor{}).Name()):
 		if tokens[3].typ != itemEquals {
 			return nil, tokens, fmt.Errorf("Unexpected '%s' at '%s', expected a equals sign", tokens[3].val, tokens[3].start)
@@ -1764,6 +3022,33 @@ func parseExpandableStringActionArgument(tokens []item) (*ast.ExpandableString,
 	return expString, tokens, nil
 }
 
+//parseRawActionArgument returns the value of a action argument as is, without parsing macros
+func parseRawActionArgument(tokens []item) (string, []item) {
+	var value strings.Builder
+
+	if len(tokens) > 0 && tokens[0].typ == itemSingleQuote {
+		tokens = tokens[1:]
+		for len(tokens) > 0 {
+			if tokens[0].typ == itemSingleQuote {
+				return value.String(), tokens[1:]
+			}
+
+			value.WriteString(tokens[0].val)
+			tokens = tokens[1:]
+		}
+
+		return value.String(), tokens
+	}
+
+	//A unquoted action argument ends at the end of the Directive argument end or at a comma in the action list
+	for len(tokens) > 0 && tokens[0].typ != itemArgumentStop && tokens[0].typ != itemComma {
+		value.WriteString(tokens[0].val)
+		tokens = tokens[1:]
+	}
+
+	return value.String(), tokens
+}
+
 func parseActionAccuracy(tokens []item) (*ast.ActionAccuracy, []item, error) {
 	action := &ast.ActionAccuracy{}
 
@@ -1809,18 +3094,36 @@ func parseActionID(tokens []item) (*ast.ActionID, []item, error) {
 		return nil, tokens, fmt.Errorf("Unexpected '%s' at '%s', expected a colon", tokens[1].val, tokens[1].start)
 	}
 
-	if tokens[2].typ != itemIdent {
-		return nil, tokens, fmt.Errorf("Unexpected '%s' at '%s', expected id number", tokens[2].val, tokens[2].start)
+	var idToken item
+
+	//The id may be quoted, like in modsecurity.conf-recommended
+	switch tokens[2].typ {
+	case itemSingleQuote:
+		if tokens[3].typ != itemIdent {
+			return nil, tokens, fmt.Errorf("Unexpected '%s' at '%s', expected id number", tokens[3].val, tokens[3].start)
+		}
+
+		if tokens[4].typ != itemSingleQuote {
+			return nil, tokens, fmt.Errorf("Unexpected '%s' at '%s', expected single quote (')", tokens[4].val, tokens[4].start)
+		}
+
+		idToken = tokens[3]
+		tokens = tokens[5:]
+	case itemIdent:
+		idToken = tokens[2]
+		tokens = tokens[3:]
+	default:
+		return nil, tokens, fmt.Errorf("Unexpected '%s' at '%s', expected id number or start of string", tokens[2].val, tokens[2].start)
 	}
 
-	id, err := strconv.Atoi(tokens[2].val)
+	id, err := strconv.Atoi(idToken.val)
 	if err != nil {
-		return nil, tokens, fmt.Errorf("Value of id action must be a number, got: '%s' at '%s'", tokens[2].val, tokens[2].start)
+		return nil, tokens, fmt.Errorf("Value of id action must be a number, got: '%s' at '%s'", idToken.val, idToken.start)
 	}
 
 	action.Value = id
 
-	return action, tokens[3:], nil
+	return action, tokens, nil
 }
 
 func parseActionStatus(tokens []item) (*ast.ActionStatus, []item, error) {
@@ -1843,6 +3146,58 @@ func parseActionStatus(tokens []item) (*ast.ActionStatus, []item, error) {
 	return action, tokens[3:], nil
 }
 
+func parseActionPause(tokens []item) (*ast.ActionPause, []item, error) {
+	action := &ast.ActionPause{}
+
+	if tokens[1].typ != itemColon {
+		return nil, tokens, fmt.Errorf("Unexpected '%s' at '%s', expected a colon", tokens[1].val, tokens[1].start)
+	}
+
+	if tokens[2].typ != itemIdent {
+		return nil, tokens, fmt.Errorf("Unexpected '%s' at '%s', expected pause duration", tokens[2].val, tokens[2].start)
+	}
+
+	pause, err := strconv.Atoi(tokens[2].val)
+	if err != nil || pause < 0 {
+		return nil, tokens, fmt.Errorf("Value of pause action must be a positive number of milliseconds, got: '%s' at '%s'", tokens[2].val, tokens[2].start)
+	}
+	action.Value = pause
+
+	return action, tokens[3:], nil
+}
+
+func parseActionProxy(tokens []item) (*ast.ActionProxy, []item, error) {
+	action := &ast.ActionProxy{}
+
+	if tokens[1].typ != itemColon {
+		return nil, tokens, fmt.Errorf("Unexpected '%s' at '%s', expected a colon", tokens[1].val, tokens[1].start)
+	}
+
+	var err error
+	action.Value, tokens, err = parseExpandableStringActionArgument(tokens[2:])
+	if err != nil {
+		return action, tokens, err
+	}
+
+	return action, tokens, nil
+}
+
+func parseActionRedirect(tokens []item) (*ast.ActionRedirect, []item, error) {
+	action := &ast.ActionRedirect{}
+
+	if tokens[1].typ != itemColon {
+		return nil, tokens, fmt.Errorf("Unexpected '%s' at '%s', expected a colon", tokens[1].val, tokens[1].start)
+	}
+
+	var err error
+	action.Value, tokens, err = parseExpandableStringActionArgument(tokens[2:])
+	if err != nil {
+		return action, tokens, err
+	}
+
+	return action, tokens, nil
+}
+
 func parseActionPhase(tokens []item) (*ast.ActionPhase, []item, error) {
 	action := &ast.ActionPhase{}
 
@@ -1979,9 +3334,9 @@ func parseActionInitcol(tokens []item) (*ast.ActionInitcol, []item, error) {
 		//Two single quotes
 		consumedTokens += 2
 	} else {
-		//all tokens until the next comma are part of the action value
+		//all tokens until the next comma or the end of the action list are part of the action value
 		for _, token := range tokens[2:] {
-			if token.typ == itemComma {
+			if token.typ == itemComma || token.typ == itemArgumentStop {
 				break
 			}
 
@@ -2321,6 +3676,12 @@ func parseActionTransform(tokens []item) (*ast.ActionTransform, []item, error) {
 	case strings.ToLower((&ast.TransformBase64Decode{}).Name()):
 		action.Value = &ast.TransformBase64Decode{}
 
+	case strings.ToLower((&ast.TransformBase64DecodeExt{}).Name()):
+		action.Value = &ast.TransformBase64DecodeExt{}
+
+	case strings.ToLower((&ast.TransformBase64Encode{}).Name()):
+		action.Value = &ast.TransformBase64Encode{}
+
 	case strings.ToLower((&ast.TransformCMDLine{}).Name()):
 		action.Value = &ast.TransformCMDLine{}
 
@@ -2330,6 +3691,12 @@ func parseActionTransform(tokens []item) (*ast.ActionTransform, []item, error) {
 	case strings.ToLower((&ast.TransformCSSDecode{}).Name()):
 		action.Value = &ast.TransformCSSDecode{}
 
+	case strings.ToLower((&ast.TransformEscapeSeqDecode{}).Name()):
+		action.Value = &ast.TransformEscapeSeqDecode{}
+
+	case strings.ToLower((&ast.TransformHexDecode{}).Name()):
+		action.Value = &ast.TransformHexDecode{}
+
 	case strings.ToLower((&ast.TransformHexEncode{}).Name()):
 		action.Value = &ast.TransformHexEncode{}
 
@@ -2345,6 +3712,9 @@ func parseActionTransform(tokens []item) (*ast.ActionTransform, []item, error) {
 	case strings.ToLower((&ast.TransformLowercase{}).Name()):
 		action.Value = &ast.TransformLowercase{}
 
+	case strings.ToLower((&ast.TransformMD5{}).Name()):
+		action.Value = &ast.TransformMD5{}
+
 	case strings.ToLower((&ast.TransformNone{}).Name()):
 		action.Value = &ast.TransformNone{}
 
@@ -2354,18 +3724,57 @@ func parseActionTransform(tokens []item) (*ast.ActionTransform, []item, error) {
 	case strings.ToLower((&ast.TransformNormalizePathWin{}).Name()), "normalisepathwin":
 		action.Value = &ast.TransformNormalizePathWin{}
 
+	case strings.ToLower((&ast.TransformParityEven7Bit{}).Name()):
+		action.Value = &ast.TransformParityEven7Bit{}
+
+	case strings.ToLower((&ast.TransformParityOdd7Bit{}).Name()):
+		action.Value = &ast.TransformParityOdd7Bit{}
+
+	case strings.ToLower((&ast.TransformParityZero7Bit{}).Name()):
+		action.Value = &ast.TransformParityZero7Bit{}
+
+	case strings.ToLower((&ast.TransformRemoveComments{}).Name()):
+		action.Value = &ast.TransformRemoveComments{}
+
+	case strings.ToLower((&ast.TransformRemoveCommentsChar{}).Name()):
+		action.Value = &ast.TransformRemoveCommentsChar{}
+
 	case strings.ToLower((&ast.TransformRemoveNulls{}).Name()):
 		action.Value = &ast.TransformRemoveNulls{}
 
+	case strings.ToLower((&ast.TransformRemoveWhitespace{}).Name()):
+		action.Value = &ast.TransformRemoveWhitespace{}
+
 	case strings.ToLower((&ast.TransformReplaceComments{}).Name()):
 		action.Value = &ast.TransformReplaceComments{}
 
+	case strings.ToLower((&ast.TransformReplaceNulls{}).Name()):
+		action.Value = &ast.TransformReplaceNulls{}
+
+	case strings.ToLower((&ast.TransformSQLHexDecode{}).Name()):
+		action.Value = &ast.TransformSQLHexDecode{}
+
+	case strings.ToLower((&ast.TransformTrim{}).Name()):
+		action.Value = &ast.TransformTrim{}
+
+	case strings.ToLower((&ast.TransformTrimLeft{}).Name()):
+		action.Value = &ast.TransformTrimLeft{}
+
+	case strings.ToLower((&ast.TransformTrimRight{}).Name()):
+		action.Value = &ast.TransformTrimRight{}
+
+	case strings.ToLower((&ast.TransformUppercase{}).Name()):
+		action.Value = &ast.TransformUppercase{}
+
 	case strings.ToLower((&ast.TransformUrlDecode{}).Name()):
 		action.Value = &ast.TransformUrlDecode{}
 
 	case strings.ToLower((&ast.TransformUrlDecodeUni{}).Name()):
 		action.Value = &ast.TransformUrlDecodeUni{}
 
+	case strings.ToLower((&ast.TransformUrlEncode{}).Name()):
+		action.Value = &ast.TransformUrlEncode{}
+
 	case strings.ToLower((&ast.TransformUTF8ToUnicode{}).Name()):
 		action.Value = &ast.TransformUTF8ToUnicode{}
 
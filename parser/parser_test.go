@@ -0,0 +1,71 @@
+package parser
+
+import (
+	"os"
+	"testing"
+
+	"github.com/dylandreimerink/go-modsec-parser/ast"
+)
+
+//TestParseRecommendedConfig parses modsecurity.conf-recommended of ModSecurity v2 without modifications
+func TestParseRecommendedConfig(t *testing.T) {
+	data, err := os.ReadFile("../testdata/modsecurity.conf-recommended")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	doc, err := Parse("modsecurity.conf-recommended", string(data))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ids := []int{}
+	for _, dir := range doc.Directives() {
+		rule, ok := dir.(*ast.DirectiveSecRule)
+		if !ok {
+			continue
+		}
+
+		for _, action := range rule.ActionNodes {
+			if id, ok := action.(*ast.ActionID); ok {
+				ids = append(ids, id.Value)
+			}
+		}
+	}
+
+	if len(ids) != 6 || ids[0] != 200000 || ids[5] != 200005 {
+		t.Errorf("got rule ids %v, want 200000 to 200005", ids)
+	}
+}
+
+func TestParseSecUnicodeMapFile(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        string
+		wantPath     string
+		wantCodePage int
+	}{
+		{name: "path and code page", input: "SecUnicodeMapFile unicode.mapping 20127\n", wantPath: "unicode.mapping", wantCodePage: 20127},
+		{name: "path only", input: "SecUnicodeMapFile unicode.mapping\n", wantPath: "unicode.mapping"},
+		{name: "path only followed by a directive", input: "SecUnicodeMapFile unicode.mapping\nSecUnicodeCodePage 20127", wantPath: "unicode.mapping"},
+		{name: "path only at end of file", input: "SecUnicodeMapFile unicode.mapping", wantPath: "unicode.mapping"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			doc, err := Parse("unicode.conf", test.input)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			dir, ok := doc.Directives()[0].(*ast.DirectiveSecUnicodeMapFile)
+			if !ok {
+				t.Fatalf("got directive %T, want SecUnicodeMapFile", doc.Directives()[0])
+			}
+
+			if dir.Path != test.wantPath || dir.CodePage != test.wantCodePage {
+				t.Errorf("got path '%s' and code page %d, want '%s' and %d", dir.Path, dir.CodePage, test.wantPath, test.wantCodePage)
+			}
+		})
+	}
+}
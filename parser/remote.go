@@ -0,0 +1,59 @@
+package parser
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+//RemoteFetcher fetches the rules referenced by SecRemoteRules directives
+type RemoteFetcher interface {
+	//Fetch returns the rules which can be found at the URL, key is the key configured in the directive
+	Fetch(key, url string) (string, error)
+}
+
+//RemoteFetcherFunc is a function which implements the RemoteFetcher interface
+type RemoteFetcherFunc func(key, url string) (string, error)
+
+//Fetch calls f(key, url)
+func (f RemoteFetcherFunc) Fetch(key, url string) (string, error) {
+	return f(key, url)
+}
+
+//HTTPFetcher fetches remote rules like ModSecurity does, with a GET request which contains the key in the ModSec-key header
+type HTTPFetcher struct {
+	//The client used to make the requests, http.DefaultClient is used if nil
+	Client *http.Client
+}
+
+//Fetch downloads the rules from the URL, any status code other than 200 is an error
+func (f *HTTPFetcher) Fetch(key, url string) (string, error) {
+	client := f.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+
+	req.Header.Set("ModSec-key", key)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("Unexpected status '%s' from '%s'", resp.Status, url)
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	return string(body), nil
+}
@@ -0,0 +1,155 @@
+package parser
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+
+	"github.com/dylandreimerink/go-modsec-parser/ast"
+	"github.com/dylandreimerink/go-modsec-parser/lint"
+)
+
+func newRemoteRulesServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("ModSec-key") != "secret" {
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+
+		w.Write([]byte("SecRuleEngine DetectionOnly\nSecRequestBodyAccess On\n"))
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func TestHTTPFetcher(t *testing.T) {
+	srv := newRemoteRulesServer(t)
+	fetcher := &HTTPFetcher{Client: srv.Client()}
+
+	rules, err := fetcher.Fetch("secret", srv.URL+"/rules.conf")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if rules != "SecRuleEngine DetectionOnly\nSecRequestBodyAccess On\n" {
+		t.Errorf("unexpected rules: %q", rules)
+	}
+
+	if _, err := fetcher.Fetch("wrong", srv.URL+"/rules.conf"); err == nil {
+		t.Error("expected an error for a non 200 status")
+	}
+}
+
+func TestLoaderRemoteRules(t *testing.T) {
+	srv := newRemoteRulesServer(t)
+	fetcher := &HTTPFetcher{Client: srv.Client()}
+
+	tests := []struct {
+		name string
+		conf string
+
+		wantErr         bool
+		wantDirectives  []string
+		wantDiagnostics int
+	}{
+		{
+			name:           "expanded inline",
+			conf:           "SecRuleEngine On\nSecRemoteRules secret " + srv.URL + "/rules.conf\nSecResponseBodyAccess On\n",
+			wantDirectives: []string{"SecRuleEngine", "SecRemoteRules", "SecRuleEngine", "SecRequestBodyAccess", "SecResponseBodyAccess"},
+		},
+		{
+			name:    "abort by default",
+			conf:    "SecRemoteRules wrong " + srv.URL + "/rules.conf\n",
+			wantErr: true,
+		},
+		{
+			name:    "abort",
+			conf:    "SecRemoteRulesFailAction Abort\nSecRemoteRules wrong " + srv.URL + "/rules.conf\n",
+			wantErr: true,
+		},
+		{
+			name:            "warn",
+			conf:            "SecRemoteRulesFailAction Warn\nSecRemoteRules wrong " + srv.URL + "/rules.conf\nSecRuleEngine On\n",
+			wantDirectives:  []string{"SecRemoteRulesFailAction", "SecRemoteRules", "SecRuleEngine"},
+			wantDiagnostics: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fsys := fstest.MapFS{
+				"main.conf": &fstest.MapFile{Data: []byte(test.conf)},
+			}
+
+			var diagnostics []lint.Diagnostic
+			doc, err := ParseFile(fsys, "main.conf", WithRemoteFetcher(fetcher), WithDiagnostics(func(d lint.Diagnostic) {
+				diagnostics = append(diagnostics, d)
+			}))
+			if test.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			names := []string{}
+			for _, dir := range doc.Directives() {
+				names = append(names, dir.Name())
+			}
+
+			if len(names) != len(test.wantDirectives) {
+				t.Fatalf("got directives %v, want %v", names, test.wantDirectives)
+			}
+
+			for i := range names {
+				if names[i] != test.wantDirectives[i] {
+					t.Fatalf("got directives %v, want %v", names, test.wantDirectives)
+				}
+			}
+
+			if len(diagnostics) != test.wantDiagnostics {
+				t.Errorf("got %d diagnostics, want %d", len(diagnostics), test.wantDiagnostics)
+			}
+		})
+	}
+}
+
+func TestLoaderRemoteFetcherFunc(t *testing.T) {
+	fsys := fstest.MapFS{
+		"main.conf": &fstest.MapFile{Data: []byte("SecRemoteRules key https://example.com/rules.conf\n")},
+	}
+
+	var gotKey, gotURL string
+	fetcher := RemoteFetcherFunc(func(key, url string) (string, error) {
+		gotKey, gotURL = key, url
+		return "", errors.New("offline")
+	})
+
+	_, err := ParseFile(fsys, "main.conf", WithRemoteFetcher(fetcher))
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if gotKey != "key" || gotURL != "https://example.com/rules.conf" {
+		t.Errorf("fetcher called with '%s', '%s'", gotKey, gotURL)
+	}
+
+	//Without a fetcher the directive is kept as is
+	doc, err := ParseFile(fsys, "main.conf")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, ok := doc.Directives()[0].(*ast.DirectiveSecRemoteRules); !ok || len(doc.Directives()) != 1 {
+		t.Errorf("unexpected directives %v", doc.Directives())
+	}
+}
@@ -0,0 +1,143 @@
+package request
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/dylandreimerink/go-modsec-parser/ast"
+)
+
+//ErrBodyLimit is returned by ProcessBody if the request body is rejected because it exceeds a limit
+var ErrBodyLimit = errors.New("Request body is larger than the configured limit")
+
+//ProcessBody reads the request body and processes it with the body processor in REQBODY_PROCESSOR, which is
+// set by Populate or ctl:requestBodyProcessor. REQUEST_BODY_LENGTH is always set, REQUEST_BODY only if the body
+// isn't processed or processed as URLENCODED.
+//
+// Errors of the body processor don't cause an error, they are reported with REQBODY_ERROR, REQBODY_PROCESSOR_ERROR and
+// REQBODY_ERROR_MSG like ModSecurity does. An error wrapping ErrBodyLimit is returned if the body is rejected because of a limit,
+// in which case INBOUND_DATA_ERROR is set. The limits are enforced while the body is read, with ProcessPartial only
+// the part of the body within the limits is processed.
+func ProcessBody(colls Collections, contentType string, body io.Reader, opts ...Option) error {
+	c := newConfig(opts)
+
+	var processor string
+	if processorColl := colls.Collection((&ast.VariableRequestBodyProcessor{}).Name()); processorColl.Len() > 0 {
+		processor, _ = processorColl.First("")
+	}
+
+	//A multipart body is parsed while it is read, so the size without the uploaded files is known while reading
+	var mp *multipartParser
+	if ast.RequestBodyProcessorType(processor) == ast.RequestBodyProcessorTypeMultipart {
+		mp = newMultipartParser(colls, c, contentType)
+	}
+
+	data, err := readBody(body, c, mp)
+	if err != nil && !errors.Is(err, ErrBodyLimit) {
+		return fmt.Errorf("Unable to read request body: %w", err)
+	}
+
+	inboundDataError := "0"
+	if err != nil {
+		inboundDataError = "1"
+
+		if c.bodyLimitAction != ast.BodyLimitActionProcessPartial {
+			setVariable(colls, (&ast.VariableInboundDataError{}).Name(), inboundDataError)
+			return err
+		}
+	}
+	setVariable(colls, (&ast.VariableInboundDataError{}).Name(), inboundDataError)
+	setVariable(colls, (&ast.VariableRequestBodyLength{}).Name(), strconv.Itoa(len(data)))
+
+	var processErr error
+	switch ast.RequestBodyProcessorType(processor) {
+	case ast.RequestBodyProcessorTypeURLEncoded, "":
+		setVariable(colls, (&ast.VariableRequestBody{}).Name(), string(data))
+
+		if processor != "" {
+			for _, arg := range ParseArguments(string(data), c.argumentSeparator) {
+				AddArgument(colls, (&ast.VariableArgsPost{}).Name(), arg)
+			}
+		}
+
+	case ast.RequestBodyProcessorTypeMultipart:
+		processErr = mp.finish()
+
+	case ast.RequestBodyProcessorTypeXML:
+		processErr = processXML(colls, data)
+
+	case ast.RequestBodyProcessorTypeJSON:
+		processErr = processJSON(colls, data)
+
+	default:
+		processErr = fmt.Errorf("Unknown request body processor '%s'", processor)
+	}
+
+	if processErr != nil {
+		setVariable(colls, (&ast.VariableRequestBodyError{}).Name(), "1")
+		setVariable(colls, (&ast.VariableRequestBodyProcessorError{}).Name(), "1")
+		setVariable(colls, (&ast.VariableRequestBodyErrorMessage{}).Name(), processErr.Error())
+	} else {
+		setVariable(colls, (&ast.VariableRequestBodyError{}).Name(), "0")
+		setVariable(colls, (&ast.VariableRequestBodyProcessorError{}).Name(), "0")
+	}
+
+	return nil
+}
+
+//readBody reads the body until SecRequestBodyLimit or SecRequestBodyNoFilesLimit is exceeded. The multipart parser,
+// if any, is fed while reading since only it knows which part of the body is the content of uploaded files.
+// If a limit is exceeded an error wrapping ErrBodyLimit is returned with the part of the body within the limits.
+func readBody(body io.Reader, c *config, mp *multipartParser) ([]byte, error) {
+	bodyLimitErr := fmt.Errorf("%w of %d bytes", ErrBodyLimit, c.bodyLimit)
+	noFilesLimitErr := fmt.Errorf("%w of %d bytes excluding files", ErrBodyLimit, c.noFilesLimit)
+
+	var data []byte
+	chunk := make([]byte, 32*1024)
+	for {
+		n, readErr := body.Read(chunk)
+		read := chunk[:n]
+
+		var limitErr error
+		if int64(len(data)+len(read)) > int64(c.bodyLimit) {
+			read = read[:int64(c.bodyLimit)-int64(len(data))]
+			limitErr = bodyLimitErr
+		}
+
+		if mp == nil {
+			//Without uploaded files the complete body counts towards SecRequestBodyNoFilesLimit
+			if int64(len(data)+len(read)) > int64(c.noFilesLimit) {
+				read = read[:int64(c.noFilesLimit)-int64(len(data))]
+				limitErr = noFilesLimitErr
+			}
+
+			data = append(data, read...)
+		} else {
+			data = append(data, read...)
+
+			mp.write(read)
+			if readErr == io.EOF || limitErr != nil {
+				mp.end()
+			}
+
+			if mp.exceeded {
+				data = data[:mp.consumed]
+				limitErr = noFilesLimitErr
+			}
+		}
+
+		if limitErr != nil {
+			return data, limitErr
+		}
+
+		if readErr == io.EOF {
+			return data, nil
+		}
+
+		if readErr != nil {
+			return nil, readErr
+		}
+	}
+}
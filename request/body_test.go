@@ -0,0 +1,119 @@
+package request
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/dylandreimerink/go-modsec-parser/ast"
+	"github.com/dylandreimerink/go-modsec-parser/collection"
+)
+
+// testCollections is a minimal implementation of Collections
+type testCollections map[string]*collection.Collection
+
+func (c testCollections) Collection(name string) *collection.Collection {
+	if c[name] == nil {
+		c[name] = collection.New()
+	}
+
+	return c[name]
+}
+
+func TestProcessBodyErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		processor   string
+		contentType string
+		body        string
+
+		wantError    string
+		wantErrorMsg bool
+	}{
+		{name: "urlencoded", processor: "URLENCODED", contentType: "application/x-www-form-urlencoded", body: "a=1&b=2", wantError: "0"},
+		{name: "valid json", processor: "JSON", contentType: "application/json", body: `{"a": 1}`, wantError: "0"},
+		{name: "invalid json", processor: "JSON", contentType: "application/json", body: `{"a": `, wantError: "1", wantErrorMsg: true},
+		{name: "invalid xml", processor: "XML", contentType: "text/xml", body: `<a><b></a>`, wantError: "1", wantErrorMsg: true},
+		{name: "multipart without boundary", processor: "MULTIPART", contentType: "multipart/form-data", body: "data", wantError: "1", wantErrorMsg: true},
+		{name: "unknown processor", processor: "YAML", contentType: "application/yaml", body: "a: 1", wantError: "1", wantErrorMsg: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			colls := testCollections{}
+			colls.Collection("REQBODY_PROCESSOR").Set("", test.processor)
+
+			if err := ProcessBody(colls, test.contentType, strings.NewReader(test.body)); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			for _, name := range []string{"REQBODY_ERROR", "REQBODY_PROCESSOR_ERROR"} {
+				if got, _ := colls.Collection(name).First(""); got != test.wantError {
+					t.Errorf("got %s '%s', want '%s'", name, got, test.wantError)
+				}
+			}
+
+			msg, _ := colls.Collection("REQBODY_ERROR_MSG").First("")
+			if (msg != "") != test.wantErrorMsg {
+				t.Errorf("got REQBODY_ERROR_MSG '%s'", msg)
+			}
+		})
+	}
+}
+
+func TestProcessBodyNoFilesLimit(t *testing.T) {
+	multipart := func(parts ...string) string {
+		return strings.Join(parts, "\r\n") + "\r\n--b--\r\n"
+	}
+
+	file := "--b\r\nContent-Disposition: form-data; name=\"upload\"; filename=\"a.txt\"\r\n\r\n" + strings.Repeat("x", 200)
+	field := func(name, value string) string {
+		return "--b\r\nContent-Disposition: form-data; name=\"" + name + "\"\r\n\r\n" + value
+	}
+
+	tests := []struct {
+		name      string
+		processor string
+		action    ast.BodyLimitAction
+		body      string
+
+		wantErr        bool
+		wantArgs       string
+		wantInboundErr string
+	}{
+		{name: "urlencoded within limit", processor: "URLENCODED", action: ast.BodyLimitActionReject, body: "a=1&b=2", wantArgs: "a=1,b=2", wantInboundErr: "0"},
+		{name: "urlencoded reject", processor: "URLENCODED", action: ast.BodyLimitActionReject, body: "a=1&b=" + strings.Repeat("2", 200), wantErr: true, wantInboundErr: "1"},
+		{name: "urlencoded process partial", processor: "URLENCODED", action: ast.BodyLimitActionProcessPartial, body: "a=1&b=" + strings.Repeat("2", 200), wantArgs: "a=1,b=" + strings.Repeat("2", 144), wantInboundErr: "1"},
+		//The content of uploaded files doesn't count towards the limit
+		{name: "multipart file", processor: "MULTIPART", action: ast.BodyLimitActionReject, body: multipart(field("a", "1"), file), wantArgs: "a=1", wantInboundErr: "0"},
+		{name: "multipart reject", processor: "MULTIPART", action: ast.BodyLimitActionReject, body: multipart(field("a", "1"), field("b", strings.Repeat("2", 200))), wantErr: true, wantInboundErr: "1"},
+		{name: "multipart process partial", processor: "MULTIPART", action: ast.BodyLimitActionProcessPartial, body: multipart(field("a", "1"), field("b", strings.Repeat("2", 200))), wantArgs: "a=1", wantInboundErr: "1"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			colls := testCollections{}
+			colls.Collection("REQBODY_PROCESSOR").Set("", test.processor)
+
+			err := ProcessBody(colls, "multipart/form-data; boundary=b", strings.NewReader(test.body),
+				WithNoFilesLimit(150), WithBodyLimitAction(test.action))
+			if test.wantErr != errors.Is(err, ErrBodyLimit) {
+				t.Fatalf("got error %v, want an error %v", err, test.wantErr)
+			}
+
+			args := []string{}
+			for _, entry := range colls.Collection("ARGS_POST").Entries() {
+				args = append(args, entry.Key+"="+entry.Value)
+			}
+
+			//A rejected body isn't processed at all
+			if strings.Join(args, ",") != test.wantArgs {
+				t.Errorf("got ARGS_POST %v, want '%s'", args, test.wantArgs)
+			}
+
+			if got, _ := colls.Collection("INBOUND_DATA_ERROR").First(""); got != test.wantInboundErr {
+				t.Errorf("got INBOUND_DATA_ERROR '%s', want '%s'", got, test.wantInboundErr)
+			}
+		})
+	}
+}
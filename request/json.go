@@ -0,0 +1,110 @@
+package request
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/dylandreimerink/go-modsec-parser/ast"
+	"github.com/dylandreimerink/go-modsec-parser/collection"
+)
+
+//jsonFrame is an object or array which is being parsed
+type jsonFrame struct {
+	//The argument name of the object or array, i.e. "json.user"
+	prefix string
+
+	array bool
+	index int
+
+	//The key of the next value in an object, empty if a key is expected
+	key       string
+	expectKey bool
+}
+
+//processJSON flattens a JSON document into ARGS_POST. The names are the path of the value prefixed with "json",
+// object keys and array indexes are separated by a dot. {"a":{"b":[1,true]}} becomes json.a.b.0=1 and json.a.b.1=true.
+// null is an empty value and empty objects and arrays are not added.
+func processJSON(colls Collections, body []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	stack := []*jsonFrame{}
+	done := false
+
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return fmt.Errorf("JSON parser error: %w", err)
+		}
+
+		if done {
+			return errors.New("JSON parser error: Extra content at the end of the document")
+		}
+
+		var top *jsonFrame
+		if len(stack) > 0 {
+			top = stack[len(stack)-1]
+		}
+
+		//The end of an object or array
+		if delim, ok := token.(json.Delim); ok && (delim == '}' || delim == ']') {
+			stack = stack[:len(stack)-1]
+			done = len(stack) == 0
+			continue
+		}
+
+		if top != nil && top.expectKey {
+			top.key, _ = token.(string)
+			top.expectKey = false
+			continue
+		}
+
+		name := "json"
+		switch {
+		case top == nil:
+		case top.array:
+			name = top.prefix + "." + strconv.Itoa(top.index)
+			top.index++
+		default:
+			name = top.prefix + "." + top.key
+			top.expectKey = true
+		}
+
+		var value string
+		switch token := token.(type) {
+		case json.Delim:
+			stack = append(stack, &jsonFrame{
+				prefix:    name,
+				array:     token == '[',
+				expectKey: token == '{',
+			})
+			continue
+
+		case string:
+			value = token
+		case json.Number:
+			value = token.String()
+		case bool:
+			value = strconv.FormatBool(token)
+		case nil:
+			value = ""
+		}
+
+		AddArgument(colls, (&ast.VariableArgsPost{}).Name(), collection.Entry{Key: name, Value: value})
+		done = len(stack) == 0
+	}
+
+	if !done {
+		return errors.New("JSON parser error: Unexpected end of the document")
+	}
+
+	return nil
+}
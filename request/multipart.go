@@ -0,0 +1,562 @@
+package request
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dylandreimerink/go-modsec-parser/ast"
+	"github.com/dylandreimerink/go-modsec-parser/collection"
+)
+
+//The flags which are set by the multipart parser, MULTIPART_STRICT_ERROR is set if any of them is set
+var multipartFlags = []string{
+	(&ast.VariableMultipartBoundaryQuoted{}).Name(),
+	(&ast.VariableMultipartBoundaryWhitespace{}).Name(),
+	(&ast.VariableMultipartDataBefore{}).Name(),
+	(&ast.VariableMultipartDataAfter{}).Name(),
+	(&ast.VariableMultipartHeaderFolding{}).Name(),
+	(&ast.VariableMultipartLFLine{}).Name(),
+	(&ast.VariableMultipartMissingSemicolon{}).Name(),
+	(&ast.VariableMultipartInvalidQuoting{}).Name(),
+	(&ast.VariableMultipartInvalidPart{}).Name(),
+	(&ast.VariableMultipartInvalidHeaderFolding{}).Name(),
+	(&ast.VariableMultipartFileLimitExceeded{}).Name(),
+}
+
+//multipartParser is a port of the multipart/form-data parser of ModSecurity v2, which is line based and strict
+// about the things browsers never do so evasion attempts can be detected. The body is parsed while it is read,
+// the parts are only added to the collections by finish so nothing is added if the body is rejected.
+type multipartParser struct {
+	colls Collections
+	c     *config
+
+	boundary string
+	flags    map[string]bool
+
+	state int
+	err   error
+
+	//The start of a line which hasn't been terminated yet
+	pending []byte
+
+	//The amount of bytes which have been parsed and the amount of them which aren't the content of a file
+	consumed     int
+	noFilesBytes int
+
+	//exceeded is set if SecRequestBodyNoFilesLimit is exceeded, the rest of the body isn't parsed
+	exceeded bool
+
+	//The amount of boundary and header lines terminated by CRLF and LF only
+	crlfLines int
+	lfLines   int
+
+	files     int
+	fileBytes int
+
+	//The part which is being parsed
+	headers    map[string]string
+	lastHeader string
+	name       string
+	filename   string
+	isFile     bool
+	data       bytes.Buffer
+
+	//The parts which have been parsed completely
+	parts []multipartPart
+}
+
+//multipartPart is a part of a multipart body
+type multipartPart struct {
+	name     string
+	filename string
+	isFile   bool
+	data     string
+}
+
+func newMultipartParser(colls Collections, c *config, contentType string) *multipartParser {
+	p := &multipartParser{
+		colls: colls,
+		c:     c,
+		flags: map[string]bool{},
+		state: multipartPreamble,
+	}
+
+	p.err = p.parseContentType(contentType)
+
+	return p
+}
+
+//write parses the lines of the next chunk of the body, an unterminated line is kept until the next write or end
+func (p *multipartParser) write(chunk []byte) {
+	p.pending = append(p.pending, chunk...)
+
+	for !p.exceeded {
+		i := bytes.IndexByte(p.pending, '\n')
+		if i == -1 {
+			return
+		}
+
+		p.parseLine(p.pending[:i+1])
+		p.pending = p.pending[i+1:]
+	}
+}
+
+//end parses the last line of the body if it isn't terminated
+func (p *multipartParser) end() {
+	if len(p.pending) > 0 && !p.exceeded {
+		p.parseLine(p.pending)
+		p.pending = nil
+	}
+}
+
+//finish adds the parts and the flags to the collections, the error of the parser is returned
+func (p *multipartParser) finish() error {
+	err := p.err
+	if err == nil && p.state != multipartEpilogue {
+		err = errors.New("Multipart: Final boundary missing")
+	}
+
+	for _, part := range p.parts {
+		p.addPart(part)
+	}
+
+	if p.lfLines > 0 {
+		p.flags[(&ast.VariableMultipartLFLine{}).Name()] = true
+
+		if p.crlfLines > 0 {
+			p.flags[(&ast.VariableMultipartCRLFLFLines{}).Name()] = true
+		}
+	}
+
+	strict := err != nil
+	for _, flag := range append(multipartFlags, (&ast.VariableMultipartCRLFLFLines{}).Name(), (&ast.VariableMultipartUnmatchedBoundary{}).Name()) {
+		setFlag(p.colls, flag, p.flags[flag])
+	}
+
+	for _, flag := range multipartFlags {
+		strict = strict || p.flags[flag]
+	}
+	setFlag(p.colls, (&ast.VariableMultipartStructError{}).Name(), strict)
+
+	filesSize := p.colls.Collection((&ast.VariableFilesCombinedSize{}).Name())
+	filesSize.Set("", strconv.Itoa(p.fileBytes))
+
+	return err
+}
+
+//parseContentType extracts the boundary from the Content-Type header
+func (p *multipartParser) parseContentType(contentType string) error {
+	if !strings.HasPrefix(strings.ToLower(contentType), "multipart/form-data") {
+		return errors.New("Multipart: Invalid Content-Type header")
+	}
+
+	lower := strings.ToLower(contentType)
+	i := strings.Index(lower, "boundary")
+	if i == -1 {
+		return errors.New("Multipart: Boundary not found in C-T")
+	}
+
+	if strings.Contains(lower[i+len("boundary"):], "boundary") {
+		return errors.New("Multipart: Multiple boundary parameters in C-T")
+	}
+
+	value := contentType[i+len("boundary"):]
+	trimmed := strings.TrimLeft(value, " \t")
+	if len(trimmed) != len(value) {
+		p.flags[(&ast.VariableMultipartBoundaryWhitespace{}).Name()] = true
+	}
+
+	if !strings.HasPrefix(trimmed, "=") {
+		return errors.New("Multipart: Invalid boundary in C-T (malformed)")
+	}
+
+	value = trimmed[1:]
+	trimmed = strings.TrimLeft(value, " \t")
+	if len(trimmed) != len(value) {
+		p.flags[(&ast.VariableMultipartBoundaryWhitespace{}).Name()] = true
+	}
+
+	if strings.HasPrefix(trimmed, `"`) {
+		p.flags[(&ast.VariableMultipartBoundaryQuoted{}).Name()] = true
+
+		end := strings.IndexByte(trimmed[1:], '"')
+		if end == -1 {
+			return errors.New("Multipart: Invalid boundary in C-T (quote)")
+		}
+
+		p.boundary = trimmed[1 : end+1]
+	} else {
+		if end := strings.IndexByte(trimmed, ';'); end != -1 {
+			trimmed = trimmed[:end]
+		}
+
+		p.boundary = strings.TrimRight(trimmed, " \t")
+	}
+
+	if p.boundary == "" {
+		return errors.New("Multipart: Invalid boundary in C-T (length)")
+	}
+
+	for _, c := range []byte(p.boundary) {
+		switch {
+		case c == ' ' || c == '\t':
+			p.flags[(&ast.VariableMultipartBoundaryWhitespace{}).Name()] = true
+		case c >= '0' && c <= '9', c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', strings.IndexByte("'()+_,-./:=?", c) != -1:
+		default:
+			return errors.New("Multipart: Invalid boundary in C-T (characters)")
+		}
+	}
+
+	return nil
+}
+
+//The states of the multipart parser
+const (
+	multipartPreamble = iota
+	multipartHeaders
+	multipartData
+	multipartEpilogue
+)
+
+//parseLine counts the line towards SecRequestBodyNoFilesLimit and parses it, after an error the lines are only counted
+func (p *multipartParser) parseLine(line []byte) {
+	//After an error it is unknown which lines are the content of a file, so they are all counted
+	if p.err != nil || p.state != multipartData || !p.isFile {
+		if int64(p.noFilesBytes+len(line)) > int64(p.c.noFilesLimit) {
+			p.exceeded = true
+			return
+		}
+
+		p.noFilesBytes += len(line)
+	}
+	p.consumed += len(line)
+
+	if p.err == nil {
+		p.err = p.parseStateLine(line)
+	}
+}
+
+//parseStateLine parses a line in the current state of the parser
+func (p *multipartParser) parseStateLine(line []byte) error {
+	content := bytes.TrimSuffix(line, []byte("\n"))
+	hasCR := bytes.HasSuffix(content, []byte("\r"))
+	content = bytes.TrimSuffix(content, []byte("\r"))
+
+	countLine := func() {
+		if hasCR {
+			p.crlfLines++
+		} else if len(line) > len(content) {
+			p.lfLines++
+		}
+	}
+
+	if p.state != multipartEpilogue {
+		if final, ok := p.isBoundary(content); ok {
+			countLine()
+
+			if p.state == multipartHeaders {
+				return errors.New("Multipart: Invalid part, boundary in part headers")
+			}
+
+			if p.state == multipartData {
+				p.finishPart()
+			}
+
+			p.state = multipartHeaders
+			if final {
+				p.state = multipartEpilogue
+			}
+
+			p.startPart()
+			return nil
+		}
+	}
+
+	switch p.state {
+	case multipartPreamble:
+		if len(bytes.TrimSpace(content)) > 0 {
+			p.flags[(&ast.VariableMultipartDataBefore{}).Name()] = true
+		}
+
+	case multipartHeaders:
+		countLine()
+
+		if len(content) == 0 {
+			if err := p.parseHeaders(); err != nil {
+				return err
+			}
+
+			p.state = multipartData
+			return nil
+		}
+
+		if err := p.addHeaderLine(string(content)); err != nil {
+			return err
+		}
+
+	case multipartData:
+		if bytes.HasPrefix(content, []byte("--")) && bytes.Contains(content, []byte(p.boundary)) {
+			p.flags[(&ast.VariableMultipartUnmatchedBoundary{}).Name()] = true
+		}
+
+		p.data.Write(line)
+
+	case multipartEpilogue:
+		if len(bytes.TrimSpace(content)) > 0 {
+			p.flags[(&ast.VariableMultipartDataAfter{}).Name()] = true
+		}
+	}
+
+	return nil
+}
+
+//isBoundary returns true if the line is a boundary, final is true for the last boundary which ends with "--"
+func (p *multipartParser) isBoundary(line []byte) (final bool, ok bool) {
+	prefix := "--" + p.boundary
+	if !bytes.HasPrefix(line, []byte(prefix)) {
+		return false, false
+	}
+
+	rest := line[len(prefix):]
+	if bytes.HasPrefix(rest, []byte("--")) {
+		final = true
+		rest = rest[2:]
+	}
+
+	//Like ModSecurity trailing whitespace after the boundary is allowed
+	if len(bytes.Trim(rest, " \t")) > 0 {
+		p.flags[(&ast.VariableMultipartUnmatchedBoundary{}).Name()] = true
+		return false, false
+	}
+
+	return final, true
+}
+
+func (p *multipartParser) startPart() {
+	p.headers = map[string]string{}
+	p.lastHeader = ""
+	p.name = ""
+	p.filename = ""
+	p.isFile = false
+	p.data.Reset()
+}
+
+//addHeaderLine adds a part header line, which may be the continuation of a folded header
+func (p *multipartParser) addHeaderLine(line string) error {
+	if line[0] == ' ' || line[0] == '\t' || line[0] == '\v' || line[0] == '\f' {
+		p.flags[(&ast.VariableMultipartHeaderFolding{}).Name()] = true
+
+		if line[0] != ' ' && line[0] != '\t' {
+			p.flags[(&ast.VariableMultipartInvalidHeaderFolding{}).Name()] = true
+		}
+
+		if p.lastHeader == "" {
+			return errors.New("Multipart: Invalid part header (folding error)")
+		}
+
+		p.headers[p.lastHeader] += " " + strings.TrimLeft(line, " \t\v\f")
+		return nil
+	}
+
+	i := strings.IndexByte(line, ':')
+	if i == -1 {
+		return errors.New("Multipart: Invalid part header (colon missing)")
+	}
+
+	name := strings.ToLower(strings.TrimSpace(line[:i]))
+	if name == "" {
+		return errors.New("Multipart: Invalid part header (header name missing)")
+	}
+
+	if _, found := p.headers[name]; found {
+		return fmt.Errorf("Multipart: Duplicate part header: %s", line[:i])
+	}
+
+	p.headers[name] = strings.TrimLeft(line[i+1:], " \t")
+	p.lastHeader = name
+
+	return nil
+}
+
+//parseHeaders parses the Content-Disposition header of the part once all headers are read
+func (p *multipartParser) parseHeaders() error {
+	disposition, found := p.headers["content-disposition"]
+	if !found {
+		p.flags[(&ast.VariableMultipartInvalidPart{}).Name()] = true
+		return errors.New("Multipart: Part missing Content-Disposition header")
+	}
+
+	return p.parseContentDisposition(disposition)
+}
+
+//parseContentDisposition parses the name and filename parameters, like ModSecurity any other parameter is an error
+func (p *multipartParser) parseContentDisposition(value string) error {
+	const formData = "form-data"
+	if !strings.HasPrefix(value, formData) {
+		return errors.New("Multipart: Invalid Content-Disposition header (form-data)")
+	}
+
+	i := len(formData)
+	skipSpace := func() {
+		for i < len(value) && (value[i] == ' ' || value[i] == '\t') {
+			i++
+		}
+	}
+
+	skipSpace()
+	if i >= len(value) || value[i] != ';' {
+		return errors.New("Multipart: Invalid Content-Disposition header (semicolon)")
+	}
+	i++
+
+	seen := map[string]bool{}
+	for {
+		skipSpace()
+		if i >= len(value) {
+			break
+		}
+
+		start := i
+		for i < len(value) && value[i] != '=' && value[i] != ' ' && value[i] != '\t' {
+			i++
+		}
+		param := strings.ToLower(value[start:i])
+
+		skipSpace()
+		if i >= len(value) || value[i] != '=' {
+			return errors.New("Multipart: Invalid Content-Disposition header (equals sign)")
+		}
+		i++
+		skipSpace()
+
+		var paramValue strings.Builder
+		if i < len(value) && value[i] == '"' {
+			i++
+
+			closed := false
+			for i < len(value) {
+				c := value[i]
+				if c == '\\' && i+1 < len(value) && (value[i+1] == '"' || value[i+1] == '\\') {
+					paramValue.WriteByte(value[i+1])
+					i += 2
+					continue
+				}
+
+				if c == '"' {
+					closed = true
+					i++
+					break
+				}
+
+				if c == '\'' {
+					p.flags[(&ast.VariableMultipartInvalidQuoting{}).Name()] = true
+				}
+
+				paramValue.WriteByte(c)
+				i++
+			}
+
+			if !closed {
+				return errors.New("Multipart: Invalid Content-Disposition header (quoting)")
+			}
+		} else {
+			start = i
+			for i < len(value) && value[i] != ';' && value[i] != ' ' && value[i] != '\t' {
+				if value[i] == '\'' || value[i] == '"' {
+					p.flags[(&ast.VariableMultipartInvalidQuoting{}).Name()] = true
+				}
+				i++
+			}
+
+			paramValue.WriteString(value[start:i])
+		}
+
+		if seen[param] {
+			return fmt.Errorf("Multipart: Duplicate Content-Disposition %s", param)
+		}
+		seen[param] = true
+
+		switch param {
+		case "name":
+			p.name = paramValue.String()
+		case "filename":
+			p.filename = paramValue.String()
+			p.isFile = true
+		default:
+			return fmt.Errorf("Multipart: Invalid Content-Disposition header (unknown parameter '%s')", param)
+		}
+
+		skipSpace()
+		if i >= len(value) {
+			break
+		}
+
+		if value[i] != ';' {
+			p.flags[(&ast.VariableMultipartMissingSemicolon{}).Name()] = true
+			continue
+		}
+		i++
+	}
+
+	if p.name == "" {
+		return errors.New("Multipart: Content-Disposition header missing name field")
+	}
+
+	return nil
+}
+
+//finishPart ends the part at a boundary
+func (p *multipartParser) finishPart() {
+	//The line terminator before the boundary is part of the boundary
+	data := p.data.Bytes()
+	if bytes.HasSuffix(data, []byte("\r\n")) {
+		data = data[:len(data)-2]
+	} else if bytes.HasSuffix(data, []byte("\n")) {
+		data = data[:len(data)-1]
+	}
+
+	if p.isFile {
+		p.fileBytes += len(data)
+	}
+
+	p.parts = append(p.parts, multipartPart{
+		name:     p.name,
+		filename: p.filename,
+		isFile:   p.isFile,
+		data:     string(data),
+	})
+}
+
+//addPart adds a parsed part to the collections
+func (p *multipartParser) addPart(part multipartPart) {
+	p.colls.Collection((&ast.VariableMultipartName{}).Name()).Add(part.name, part.name)
+
+	if !part.isFile {
+		AddArgument(p.colls, (&ast.VariableArgsPost{}).Name(), collection.Entry{Key: part.name, Value: part.data})
+		return
+	}
+
+	//Like ModSecurity files over the limit are not added to the collections
+	p.files++
+	if p.files > p.c.uploadFileLimit {
+		p.flags[(&ast.VariableMultipartFileLimitExceeded{}).Name()] = true
+		return
+	}
+
+	p.colls.Collection((&ast.VariableMultipartFilename{}).Name()).Add(part.name, part.filename)
+	p.colls.Collection((&ast.VariableFiles{}).Name()).Add(part.name, part.filename)
+	p.colls.Collection((&ast.VariableFilesNames{}).Name()).Add(part.name, part.name)
+	p.colls.Collection((&ast.VariableFilesSizes{}).Name()).Add(part.name, strconv.Itoa(len(part.data)))
+}
+
+//setFlag sets a variable to "1" or "0"
+func setFlag(colls Collections, name string, value bool) {
+	if value {
+		setVariable(colls, name, "1")
+	} else {
+		setVariable(colls, name, "0")
+	}
+}
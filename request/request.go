@@ -0,0 +1,367 @@
+//Package request maps HTTP requests to the collections of ModSecurity variables, following the parsing quirks of ModSecurity
+package request
+
+import (
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/dylandreimerink/go-modsec-parser/ast"
+	"github.com/dylandreimerink/go-modsec-parser/collection"
+	"github.com/dylandreimerink/go-modsec-parser/transform"
+)
+
+//Collections holds the collections of a transaction, this interface is satisfied by *engine.Transaction
+type Collections interface {
+	//Collection returns the collection of the variable with the given name, it is created if it doesn't exist yet
+	Collection(name string) *collection.Collection
+}
+
+//Option changes the way a request is mapped
+type Option func(*config)
+
+type config struct {
+	argumentSeparator byte
+	cookieFormat      ast.SecCookieFormatValue
+	cookieV0Separator byte
+
+	bodyLimit       ast.BodyLimit
+	noFilesLimit    ast.BodyLimit
+	bodyLimitAction ast.BodyLimitAction
+	uploadFileLimit int
+}
+
+//WithArgumentSeparator sets the separator of arguments in the query string and url encoded bodies, the default is '&'
+func WithArgumentSeparator(separator byte) Option {
+	return func(c *config) {
+		c.argumentSeparator = separator
+	}
+}
+
+//WithCookieFormat sets the format which is used to parse cookies, the default is version 0
+func WithCookieFormat(format ast.SecCookieFormatValue) Option {
+	return func(c *config) {
+		c.cookieFormat = format
+	}
+}
+
+//WithCookieV0Separator sets the separator of version 0 cookies, the default is ';'
+func WithCookieV0Separator(separator byte) Option {
+	return func(c *config) {
+		c.cookieV0Separator = separator
+	}
+}
+
+//WithBodyLimit sets the maximum size of the request body, the default is 134217728 bytes like ModSecurity
+func WithBodyLimit(limit ast.BodyLimit) Option {
+	return func(c *config) {
+		c.bodyLimit = limit
+	}
+}
+
+//WithNoFilesLimit sets the maximum size of the request body excluding uploaded files, the default is 1048576 bytes like ModSecurity
+func WithNoFilesLimit(limit ast.BodyLimit) Option {
+	return func(c *config) {
+		c.noFilesLimit = limit
+	}
+}
+
+//WithBodyLimitAction sets what happens if the request body is larger than the body limit, the default is to reject it
+func WithBodyLimitAction(action ast.BodyLimitAction) Option {
+	return func(c *config) {
+		c.bodyLimitAction = action
+	}
+}
+
+//WithUploadFileLimit sets the maximum amount of files in a multipart body, the default is 100 like ModSecurity
+func WithUploadFileLimit(limit int) Option {
+	return func(c *config) {
+		c.uploadFileLimit = limit
+	}
+}
+
+//WithDocument applies the SecArgumentSeparator, SecCookieFormat, SecCookieV0Separator, SecRequestBodyLimit,
+// SecRequestBodyNoFilesLimit, SecRequestBodyLimitAction and SecUploadFileLimit directives of the document.
+// Like ModSecurity the last directive wins.
+func WithDocument(doc *ast.Document) Option {
+	return func(c *config) {
+		for _, dir := range doc.Directives() {
+			switch dir := dir.(type) {
+			case *ast.DirectiveSecArgumentSeparator:
+				if dir.Value != "" {
+					c.argumentSeparator = dir.Value[0]
+				}
+			case *ast.DirectiveSecCookieFormat:
+				c.cookieFormat = dir.Value
+			case *ast.DirectiveSecCookieV0Separator:
+				if dir.Value != "" {
+					c.cookieV0Separator = dir.Value[0]
+				}
+			case *ast.DirectiveSecRequestBodyLimit:
+				c.bodyLimit = dir.Value
+			case *ast.DirectiveSecRequestBodyNoFilesLimit:
+				c.noFilesLimit = dir.Value
+			case *ast.DirectiveSecRequestBodyLimitAction:
+				c.bodyLimitAction = dir.Value
+			case *ast.DirectiveSecUploadFileLimit:
+				c.uploadFileLimit = dir.Value
+			}
+		}
+	}
+}
+
+func newConfig(opts []Option) *config {
+	c := &config{
+		argumentSeparator: '&',
+		cookieFormat:      ast.SecCookieFormatVersion0,
+		cookieV0Separator: ';',
+		bodyLimit:         134217728,
+		noFilesLimit:      1048576,
+		bodyLimitAction:   ast.BodyLimitActionReject,
+		uploadFileLimit:   100,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+//Populate fills the collections of the request line, query string, headers, cookies and remote address of the request.
+// The request body isn't read, it is handled by ProcessBody. Like ModSecurity REQBODY_PROCESSOR is set to URLENCODED or
+// MULTIPART based on the Content-Type header, rules in phase 1 can change it with ctl:requestBodyProcessor.
+func Populate(colls Collections, r *http.Request, opts ...Option) {
+	c := newConfig(opts)
+
+	//The URI as it was on the request line, which is only set for requests received by a server
+	rawURI := r.RequestURI
+	if rawURI == "" {
+		rawURI = r.URL.RequestURI()
+		if r.URL.IsAbs() {
+			rawURI = r.URL.String()
+		}
+	}
+
+	uri := stripSchemeAndHost(rawURI)
+	filename, query := uri, ""
+	if i := strings.IndexByte(uri, '?'); i != -1 {
+		filename, query = uri[:i], uri[i+1:]
+	}
+
+	setVariable(colls, (&ast.VariableRequestMethod{}).Name(), r.Method)
+	setVariable(colls, (&ast.VariableRequestProtocol{}).Name(), r.Proto)
+	setVariable(colls, (&ast.VariableRequestLine{}).Name(), r.Method+" "+rawURI+" "+r.Proto)
+	setVariable(colls, (&ast.VariableRequestURIRaw{}).Name(), rawURI)
+	setVariable(colls, (&ast.VariableRequestURI{}).Name(), uri)
+	setVariable(colls, (&ast.VariableRequestFilename{}).Name(), filename)
+	setVariable(colls, (&ast.VariableRequestBasename{}).Name(), filename[strings.LastIndexByte(filename, '/')+1:])
+	setVariable(colls, (&ast.VariableQueryString{}).Name(), query)
+
+	host, port, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host, port = r.RemoteAddr, ""
+	}
+	setVariable(colls, (&ast.VariableRemoteAddress{}).Name(), host)
+	setVariable(colls, (&ast.VariableRemotePort{}).Name(), port)
+
+	for _, arg := range ParseArguments(query, c.argumentSeparator) {
+		AddArgument(colls, (&ast.VariableArgsGet{}).Name(), arg)
+	}
+
+	populateHeaders(colls, r)
+
+	mediaType := strings.ToLower(strings.TrimSpace(strings.SplitN(r.Header.Get("Content-Type"), ";", 2)[0]))
+	switch mediaType {
+	case "application/x-www-form-urlencoded":
+		setVariable(colls, (&ast.VariableRequestBodyProcessor{}).Name(), string(ast.RequestBodyProcessorTypeURLEncoded))
+	case "multipart/form-data":
+		setVariable(colls, (&ast.VariableRequestBodyProcessor{}).Name(), string(ast.RequestBodyProcessorTypeMultipart))
+	}
+
+	for _, header := range r.Header.Values("Cookie") {
+		var cookies []collection.Entry
+		if c.cookieFormat == ast.SecCookieFormatVersion1 {
+			cookies = ParseCookiesV1(header)
+		} else {
+			cookies = ParseCookiesV0(header, c.cookieV0Separator)
+		}
+
+		for _, cookie := range cookies {
+			colls.Collection((&ast.VariableRequestCookies{}).Name()).Add(cookie.Key, cookie.Value)
+			colls.Collection((&ast.VariableRequestCookiesNames{}).Name()).Add(cookie.Key, cookie.Key)
+		}
+	}
+}
+
+//populateHeaders fills REQUEST_HEADERS in alphabetical order, since the order of the header map isn't stable.
+// The Host header is taken from the request since it is removed from the header map by net/http.
+func populateHeaders(colls Collections, r *http.Request) {
+	headers := colls.Collection((&ast.VariableRequestHeaders{}).Name())
+	names := colls.Collection((&ast.VariableRequestHeadersNames{}).Name())
+
+	if r.Host != "" && r.Header.Get("Host") == "" {
+		headers.Add("Host", r.Host)
+		names.Add("Host", "Host")
+	}
+
+	keys := make([]string, 0, len(r.Header))
+	for key := range r.Header {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		for _, value := range r.Header[key] {
+			headers.Add(key, value)
+			names.Add(key, key)
+		}
+	}
+}
+
+//stripSchemeAndHost removes the scheme and host of an absolute URI, "http://example.com/a?b" becomes "/a?b"
+func stripSchemeAndHost(uri string) string {
+	if strings.HasPrefix(uri, "/") {
+		return uri
+	}
+
+	i := strings.Index(uri, "://")
+	if i == -1 {
+		return uri
+	}
+
+	rest := uri[i+3:]
+	if j := strings.IndexAny(rest, "/?"); j != -1 {
+		if rest[j] == '?' {
+			return "/" + rest[j:]
+		}
+
+		return rest[j:]
+	}
+
+	return "/"
+}
+
+//ParseArguments parses url encoded arguments like ModSecurity does. Names and values are url decoded, an argument
+// without '=' has an empty value and arguments with the same name are all kept in order.
+func ParseArguments(input string, separator byte) []collection.Entry {
+	args := []collection.Entry{}
+
+	for _, pair := range strings.Split(input, string(separator)) {
+		if pair == "" {
+			continue
+		}
+
+		name, value := pair, ""
+		if i := strings.IndexByte(pair, '='); i != -1 {
+			name, value = pair[:i], pair[i+1:]
+		}
+
+		args = append(args, collection.Entry{
+			Key:   urlDecode(name),
+			Value: urlDecode(value),
+		})
+	}
+
+	return args
+}
+
+//AddArgument adds the argument to the collection, for example ARGS_GET, as well as to ARGS and the matching _NAMES collections.
+// ARGS_COMBINED_SIZE is updated with the length of the name and value.
+func AddArgument(colls Collections, collectionName string, arg collection.Entry) {
+	argsName := (&ast.VariableArgs{}).Name()
+
+	colls.Collection(collectionName).Add(arg.Key, arg.Value)
+	colls.Collection(collectionName+"_NAMES").Add(arg.Key, arg.Key)
+	colls.Collection(argsName).Add(arg.Key, arg.Value)
+	colls.Collection(argsName+"_NAMES").Add(arg.Key, arg.Key)
+
+	combinedSize := colls.Collection((&ast.VariableArgsCombinedSize{}).Name())
+	size, _ := combinedSize.First("")
+	total, _ := strconv.Atoi(size)
+	combinedSize.Set("", strconv.Itoa(total+len(arg.Key)+len(arg.Value)))
+}
+
+//ParseCookiesV0 parses a Cookie header with version 0 (Netscape) cookies. Values are used as is, including quotes.
+func ParseCookiesV0(header string, separator byte) []collection.Entry {
+	cookies := []collection.Entry{}
+
+	for _, cookie := range strings.Split(header, string(separator)) {
+		cookie = strings.TrimLeft(cookie, " \t")
+
+		name, value := cookie, ""
+		if i := strings.IndexByte(cookie, '='); i != -1 {
+			name, value = cookie[:i], cookie[i+1:]
+		}
+
+		if name == "" {
+			continue
+		}
+
+		cookies = append(cookies, collection.Entry{Key: name, Value: value})
+	}
+
+	return cookies
+}
+
+//ParseCookiesV1 parses a Cookie header with version 1 (RFC 2109) cookies. Cookies are separated by ';' or ',',
+// quoted values are unquoted and backslash escapes in them are removed.
+func ParseCookiesV1(header string) []collection.Entry {
+	cookies := []collection.Entry{}
+
+	i := 0
+	for i < len(header) {
+		//Skip separators and whitespace before the name
+		for i < len(header) && strings.IndexByte(" \t;,", header[i]) != -1 {
+			i++
+		}
+
+		start := i
+		for i < len(header) && header[i] != '=' && header[i] != ';' && header[i] != ',' {
+			i++
+		}
+		name := strings.TrimRight(header[start:i], " \t")
+
+		var value strings.Builder
+		if i < len(header) && header[i] == '=' {
+			i++
+
+			if i < len(header) && header[i] == '"' {
+				i++
+				for i < len(header) && header[i] != '"' {
+					if header[i] == '\\' && i+1 < len(header) {
+						i++
+					}
+
+					value.WriteByte(header[i])
+					i++
+				}
+
+				//Skip the closing quote
+				i++
+			} else {
+				start = i
+				for i < len(header) && header[i] != ';' && header[i] != ',' {
+					i++
+				}
+				value.WriteString(strings.TrimRight(header[start:i], " \t"))
+			}
+		}
+
+		if name != "" {
+			cookies = append(cookies, collection.Entry{Key: name, Value: value.String()})
+		}
+	}
+
+	return cookies
+}
+
+func setVariable(colls Collections, name, value string) {
+	colls.Collection(name).Set("", value)
+}
+
+func urlDecode(value string) string {
+	return string((&transform.UrlDecode{}).Apply([]byte(value)))
+}
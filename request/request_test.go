@@ -0,0 +1,164 @@
+package request
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dylandreimerink/go-modsec-parser/ast"
+	"github.com/dylandreimerink/go-modsec-parser/collection"
+)
+
+//entries formats the entries as "key=value" pairs in order
+func entries(list []collection.Entry) string {
+	pairs := []string{}
+	for _, entry := range list {
+		pairs = append(pairs, entry.Key+"="+entry.Value)
+	}
+
+	return strings.Join(pairs, ",")
+}
+
+func TestParseArguments(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		separator byte
+		want      string
+	}{
+		{name: "empty", input: "", separator: '&', want: ""},
+		{name: "repeated", input: "a=1&b=2&a=3", separator: '&', want: "a=1,b=2,a=3"},
+		{name: "plus", input: "q=hello+world", separator: '&', want: "q=hello world"},
+		{name: "percent", input: "q%5B%5D=%3Cscript%3E&x=%41", separator: '&', want: "q[]=<script>,x=A"},
+		{name: "invalid escape", input: "a=%zz&b=100%&c=%4", separator: '&', want: "a=%zz,b=100%,c=%4"},
+		{name: "empty value", input: "a=&b", separator: '&', want: "a=,b="},
+		{name: "empty name", input: "=1&&c=2", separator: '&', want: "=1,c=2"},
+		{name: "value with equals sign", input: "a=b=c", separator: '&', want: "a=b=c"},
+		{name: "separator", input: "a=1;b=2&c=3", separator: ';', want: "a=1,b=2&c=3"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := entries(ParseArguments(test.input, test.separator)); got != test.want {
+				t.Errorf("got '%s', want '%s'", got, test.want)
+			}
+		})
+	}
+}
+
+func TestParseCookies(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		wantV0 string
+		wantV1 string
+	}{
+		{name: "simple", header: "a=1; b=2", wantV0: "a=1,b=2", wantV1: "a=1,b=2"},
+		{name: "repeated", header: "a=1; a=2", wantV0: "a=1,a=2", wantV1: "a=1,a=2"},
+		//Version 0 cookies keep their quotes, version 1 cookies are unquoted and unescaped
+		{name: "quoted", header: `a="x y"; b="\"q\""`, wantV0: `a="x y",b="\"q\""`, wantV1: `a=x y,b="q"`},
+		{name: "comma", header: "a=1, b=2", wantV0: "a=1, b=2", wantV1: "a=1,b=2"},
+		{name: "empty value", header: "a=; b", wantV0: "a=,b=", wantV1: "a=,b="},
+		{name: "empty name", header: "=1; b=2", wantV0: "b=2", wantV1: "b=2"},
+		{name: "not decoded", header: "a=%41+b", wantV0: "a=%41+b", wantV1: "a=%41+b"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := entries(ParseCookiesV0(test.header, ';')); got != test.wantV0 {
+				t.Errorf("got version 0 cookies '%s', want '%s'", got, test.wantV0)
+			}
+
+			if got := entries(ParseCookiesV1(test.header)); got != test.wantV1 {
+				t.Errorf("got version 1 cookies '%s', want '%s'", got, test.wantV1)
+			}
+		})
+	}
+}
+
+func TestPopulate(t *testing.T) {
+	tests := []struct {
+		name   string
+		target string
+		opts   []Option
+
+		want map[string]string
+	}{
+		{
+			name:   "path and query",
+			target: "/app/index.php?a=1&b=x+y&a=%3C",
+			want: map[string]string{
+				"REQUEST_LINE":     "GET /app/index.php?a=1&b=x+y&a=%3C HTTP/1.1",
+				"REQUEST_URI":      "/app/index.php?a=1&b=x+y&a=%3C",
+				"REQUEST_URI_RAW":  "/app/index.php?a=1&b=x+y&a=%3C",
+				"REQUEST_FILENAME": "/app/index.php",
+				"REQUEST_BASENAME": "index.php",
+				"QUERY_STRING":     "a=1&b=x+y&a=%3C",
+				"ARGS_GET":         "a=1,b=x y,a=<",
+				"ARGS_NAMES":       "a=a,b=b,a=a",
+			},
+		},
+		{
+			name:   "absolute URI",
+			target: "http://example.com/a/b?c=d",
+			want: map[string]string{
+				"REQUEST_LINE":    "GET http://example.com/a/b?c=d HTTP/1.1",
+				"REQUEST_URI":     "/a/b?c=d",
+				"REQUEST_URI_RAW": "http://example.com/a/b?c=d",
+				//The Host header comes first, the other headers are sorted by name and keep the order of their values
+				"REQUEST_HEADERS": "Host=example.com,Cookie=a=\"1\"; b=2,X-A=1,X-B=2,X-B=3",
+			},
+		},
+		{
+			name:   "cookies version 0",
+			target: "/",
+			want: map[string]string{
+				"REQUEST_COOKIES":       `a="1",b=2`,
+				"REQUEST_COOKIES_NAMES": "a=a,b=b",
+			},
+		},
+		{
+			name:   "cookies version 1",
+			target: "/",
+			opts:   []Option{WithCookieFormat(ast.SecCookieFormatVersion1)},
+			want: map[string]string{
+				"REQUEST_COOKIES": "a=1,b=2",
+			},
+		},
+		{
+			name:   "argument separator",
+			target: "/?a=1;b=2",
+			opts:   []Option{WithArgumentSeparator(';')},
+			want: map[string]string{
+				"ARGS_GET": "a=1,b=2",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", test.target, nil)
+			r.Header.Add("X-B", "2")
+			r.Header.Add("X-A", "1")
+			r.Header.Add("X-B", "3")
+			r.Header.Set("Cookie", `a="1"; b=2`)
+
+			colls := testCollections{}
+			Populate(colls, r, test.opts...)
+
+			for name, want := range test.want {
+				coll := colls.Collection(name)
+
+				//Variables are compared by value, collections as key=value pairs in the order they were added
+				got := entries(coll.Entries())
+				if value, ok := coll.First(""); ok && coll.Len() == 1 {
+					got = value
+				}
+
+				if got != want {
+					t.Errorf("got %s '%s', want '%s'", name, got, want)
+				}
+			}
+		})
+	}
+}
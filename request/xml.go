@@ -0,0 +1,80 @@
+package request
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dylandreimerink/go-modsec-parser/ast"
+)
+
+//The XPath expressions which are supported as key of the XML collection
+const (
+	//The text content of the document element, this is what CRS inspects with XML:/*
+	xmlTextContent = "/*"
+
+	//The value of every attribute in the document, this is what CRS inspects with XML://@*
+	xmlAttributes = "//@*"
+)
+
+//processXML parses the body as XML document. Since there is no XPath engine, the XML collection only supports the
+// keys "/*" and "//@*", which are the expressions used by CRS. External entities are never resolved.
+func processXML(colls Collections, body []byte) error {
+	decoder := xml.NewDecoder(bytes.NewReader(body))
+	decoder.Strict = true
+
+	var (
+		text     strings.Builder
+		attrs    []string
+		depth    int
+		rootSeen bool
+	)
+
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return fmt.Errorf("XML parser error: %w", err)
+		}
+
+		switch token := token.(type) {
+		case xml.StartElement:
+			if depth == 0 && rootSeen {
+				return errors.New("XML parser error: Extra content at the end of the document")
+			}
+
+			rootSeen = true
+			depth++
+
+			for _, attr := range token.Attr {
+				attrs = append(attrs, attr.Value)
+			}
+
+		case xml.EndElement:
+			depth--
+
+		case xml.CharData:
+			if depth > 0 {
+				text.Write(token)
+			}
+		}
+	}
+
+	if !rootSeen {
+		return errors.New("XML parser error: Document is empty")
+	}
+
+	xmlColl := colls.Collection((&ast.VariableXML{}).Name())
+	xmlColl.Add(xmlTextContent, text.String())
+	for _, attr := range attrs {
+		xmlColl.Add(xmlAttributes, attr)
+	}
+
+	return nil
+}
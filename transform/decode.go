@@ -0,0 +1,508 @@
+package transform
+
+import (
+	"encoding/base64"
+	"math"
+	"strconv"
+	"strings"
+)
+
+//Base64Decode decodes a Base64-encoded string, decoding stops at the first character which is not part of the Base64 alphabet
+type Base64Decode struct{}
+
+//Apply decodes the input, "VGVzdENhc2U=" becomes "TestCase"
+func (t *Base64Decode) Apply(input []byte) []byte {
+	end := 0
+	for end < len(input) && isBase64(input[end]) {
+		end++
+	}
+
+	return decodeBase64(input[:end])
+}
+
+//Base64DecodeExt decodes a Base64-encoded string, characters which are not part of the Base64 alphabet are ignored
+type Base64DecodeExt struct{}
+
+//Apply decodes the input, "VGVz dENh\nc2U=" becomes "TestCase"
+func (t *Base64DecodeExt) Apply(input []byte) []byte {
+	valid := make([]byte, 0, len(input))
+	for _, c := range input {
+		if isBase64(c) {
+			valid = append(valid, c)
+		}
+	}
+
+	return decodeBase64(valid)
+}
+
+//decodeBase64 decodes unpadded Base64 data, a trailing group of a single character is dropped since it can't hold a byte
+func decodeBase64(input []byte) []byte {
+	if len(input)%4 == 1 {
+		input = input[:len(input)-1]
+	}
+
+	output := make([]byte, base64.RawStdEncoding.DecodedLen(len(input)))
+	n, _ := base64.RawStdEncoding.Decode(output, input)
+
+	return output[:n]
+}
+
+func isBase64(c byte) bool {
+	return (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') || c == '+' || c == '/'
+}
+
+//CSSDecode decodes characters encoded using the CSS 2.x escape rules
+type CSSDecode struct{}
+
+//Apply decodes the input, "\3c script" and "\3Cscript" both become "<script"
+func (t *CSSDecode) Apply(input []byte) []byte {
+	output := make([]byte, 0, len(input))
+
+	i := 0
+	for i < len(input) {
+		if input[i] != '\\' {
+			output = append(output, input[i])
+			i++
+			continue
+		}
+
+		//A backslash at the end of the input is ignored
+		if i+1 >= len(input) {
+			i++
+			continue
+		}
+
+		i++
+
+		j := 0
+		for j < 6 && i+j < len(input) && isHex(input[i+j]) {
+			j++
+		}
+
+		if j == 0 {
+			//An escaped newline is a line continuation, any other character is copied without the backslash
+			if input[i] != '\n' {
+				output = append(output, input[i])
+			}
+
+			i++
+			continue
+		}
+
+		//Only the last byte of the code point is used
+		var c byte
+		fullCheck := false
+		switch j {
+		case 1:
+			c = xsingle2c(input[i])
+		case 2, 3:
+			c = x2c(input[i+j-2], input[i+j-1])
+		case 4:
+			c = x2c(input[i+2], input[i+3])
+			fullCheck = true
+		case 5:
+			c = x2c(input[i+3], input[i+4])
+			fullCheck = input[i] == '0'
+		case 6:
+			c = x2c(input[i+4], input[i+5])
+			fullCheck = input[i] == '0' && input[i+1] == '0'
+		}
+
+		//Full width ASCII (FF01 - FF5E) needs 0x20 added
+		if fullCheck && c > 0x00 && c < 0x5f && isF(input[i+j-3]) && isF(input[i+j-4]) {
+			c += 0x20
+		}
+
+		output = append(output, c)
+
+		//A single whitespace after the escape is part of the escape
+		if i+j < len(input) && isSpace(input[i+j]) {
+			j++
+		}
+
+		i += j
+	}
+
+	return output
+}
+
+//EscapeSeqDecode decodes ANSI C escape sequences, unknown escape sequences are replaced by the escaped character
+type EscapeSeqDecode struct{}
+
+//Apply decodes the input, "\x41\102\n" becomes "AB\n"
+func (t *EscapeSeqDecode) Apply(input []byte) []byte {
+	output := make([]byte, 0, len(input))
+
+	i := 0
+	for i < len(input) {
+		if input[i] != '\\' || i+1 >= len(input) {
+			output = append(output, input[i])
+			i++
+			continue
+		}
+
+		c, ok := cEscape(input[i+1], true)
+		if ok {
+			output = append(output, c)
+			i += 2
+			continue
+		}
+
+		if (input[i+1] == 'x' || input[i+1] == 'X') && i+3 < len(input) && isHex(input[i+2]) && isHex(input[i+3]) {
+			output = append(output, x2c(input[i+2], input[i+3]))
+			i += 4
+			continue
+		}
+
+		if isODigit(input[i+1]) {
+			digits := octalDigits(input[i+1:])
+			output = append(output, octal(input[i+1:i+1+digits]))
+			i += 1 + digits
+			continue
+		}
+
+		output = append(output, input[i+1])
+		i += 2
+	}
+
+	return output
+}
+
+//HexDecode decodes pairs of hexadecimal characters, a trailing odd character is dropped
+type HexDecode struct{}
+
+//Apply decodes the input, "414243" becomes "ABC"
+func (t *HexDecode) Apply(input []byte) []byte {
+	output := make([]byte, 0, len(input)/2)
+	for i := 0; i+1 < len(input); i += 2 {
+		output = append(output, x2c(input[i], input[i+1]))
+	}
+
+	return output
+}
+
+//HTMLEntityDecode decodes HTML entities, only the &quot; &amp; &lt; &gt; and &nbsp; named entities are decoded.
+// The semicolon at the end of the entity is optional.
+type HTMLEntityDecode struct{}
+
+//htmlEntities are the named entities which are decoded by HTMLEntityDecode
+var htmlEntities = map[string]byte{
+	"quot": '"',
+	"amp":  '&',
+	"lt":   '<',
+	"gt":   '>',
+	"nbsp": 0xa0,
+}
+
+//Apply decodes the input, "&lt;script&gt;" and "&#x3c;script&#62" both become "<script>"
+func (t *HTMLEntityDecode) Apply(input []byte) []byte {
+	output := make([]byte, 0, len(input))
+
+	i := 0
+	for i < len(input) {
+		if input[i] != '&' || i+1 >= len(input) {
+			output = append(output, input[i])
+			i++
+			continue
+		}
+
+		var (
+			c     byte
+			start int
+			end   int
+			ok    bool
+		)
+
+		switch {
+		case input[i+1] == '#' && i+2 < len(input) && (input[i+2] == 'x' || input[i+2] == 'X'):
+			start = i + 3
+			end = start
+			for end < len(input) && isHex(input[end]) {
+				end++
+			}
+
+			if end > start {
+				c, ok = entityValue(input[start:end], 16), true
+			}
+
+		case input[i+1] == '#':
+			start = i + 2
+			end = start
+			for end < len(input) && isDigit(input[end]) {
+				end++
+			}
+
+			if end > start {
+				c, ok = entityValue(input[start:end], 10), true
+			}
+
+		default:
+			start = i + 1
+			end = start
+			for end < len(input) && isAlnum(input[end]) {
+				end++
+			}
+
+			c, ok = htmlEntities[strings.ToLower(string(input[start:end]))]
+		}
+
+		if !ok {
+			output = append(output, input[i])
+			i++
+			continue
+		}
+
+		output = append(output, c)
+
+		i = end
+		if i < len(input) && input[i] == ';' {
+			i++
+		}
+	}
+
+	return output
+}
+
+//entityValue returns the lowest byte of a numeric entity, like strtol the value is clamped to the max int64
+func entityValue(digits []byte, base int) byte {
+	value, err := strconv.ParseInt(string(digits), base, 64)
+	if err != nil {
+		value = math.MaxInt64
+	}
+
+	return byte(value)
+}
+
+//JSDecode decodes JavaScript escape sequences, \uHHHH sequences are decoded to the lowest byte of the code point
+type JSDecode struct{}
+
+//Apply decodes the input, "\u0041\x42\103\n" becomes "ABC\n"
+func (t *JSDecode) Apply(input []byte) []byte {
+	output := make([]byte, 0, len(input))
+
+	i := 0
+	for i < len(input) {
+		if input[i] != '\\' {
+			output = append(output, input[i])
+			i++
+			continue
+		}
+
+		switch {
+		case i+5 < len(input) && input[i+1] == 'u' &&
+			isHex(input[i+2]) && isHex(input[i+3]) && isHex(input[i+4]) && isHex(input[i+5]):
+
+			c := x2c(input[i+4], input[i+5])
+
+			//Full width ASCII (FF01 - FF5E) needs 0x20 added
+			if c > 0x00 && c < 0x5f && isF(input[i+2]) && isF(input[i+3]) {
+				c += 0x20
+			}
+
+			output = append(output, c)
+			i += 6
+
+		case i+3 < len(input) && input[i+1] == 'x' && isHex(input[i+2]) && isHex(input[i+3]):
+			output = append(output, x2c(input[i+2], input[i+3]))
+			i += 4
+
+		case i+1 < len(input) && isODigit(input[i+1]):
+			digits := octalDigits(input[i+1:])
+
+			//Don't use 3 digits if the value doesn't fit in a byte
+			if digits == 3 && input[i+1] > '3' {
+				digits = 2
+			}
+
+			output = append(output, octal(input[i+1:i+1+digits]))
+			i += 1 + digits
+
+		case i+1 < len(input):
+			c, _ := cEscape(input[i+1], false)
+			output = append(output, c)
+			i += 2
+
+		default:
+			output = append(output, input[i])
+			i++
+		}
+	}
+
+	return output
+}
+
+//SQLHexDecode decodes the hexadecimal literals used in SQL
+type SQLHexDecode struct{}
+
+//Apply decodes the input, "0x414243" becomes "ABC"
+func (t *SQLHexDecode) Apply(input []byte) []byte {
+	output := make([]byte, 0, len(input))
+
+	i := 0
+	for i < len(input) {
+		if input[i] == '0' && i+3 < len(input) && (input[i+1] == 'x' || input[i+1] == 'X') &&
+			isHex(input[i+2]) && isHex(input[i+3]) {
+
+			i += 2
+			for i+1 < len(input) && isHex(input[i]) && isHex(input[i+1]) {
+				output = append(output, x2c(input[i], input[i+1]))
+				i += 2
+			}
+
+			continue
+		}
+
+		output = append(output, input[i])
+		i++
+	}
+
+	return output
+}
+
+//UrlDecode decodes URL encoded input, invalid encodings are left in the output
+type UrlDecode struct{}
+
+//Apply decodes the input, "%3Cscript%3E+x" becomes "<script> x"
+func (t *UrlDecode) Apply(input []byte) []byte {
+	return urlDecode(input, false)
+}
+
+//UrlDecodeUni decodes URL encoded input like UrlDecode, but also decodes the %uHHHH encoding.
+// Without a unicode map the lowest byte of the code point is used, like ModSecurity does.
+type UrlDecodeUni struct{}
+
+//Apply decodes the input, "%u003Cscript%3E" becomes "<script>"
+func (t *UrlDecodeUni) Apply(input []byte) []byte {
+	return urlDecode(input, true)
+}
+
+func urlDecode(input []byte, unicode bool) []byte {
+	output := make([]byte, 0, len(input))
+
+	i := 0
+	for i < len(input) {
+		switch input[i] {
+		case '+':
+			output = append(output, ' ')
+			i++
+
+		case '%':
+			switch {
+			case unicode && i+1 < len(input) && (input[i+1] == 'u' || input[i+1] == 'U'):
+				if i+5 < len(input) && isHex(input[i+2]) && isHex(input[i+3]) && isHex(input[i+4]) && isHex(input[i+5]) {
+					c := x2c(input[i+4], input[i+5])
+
+					//Full width ASCII (FF01 - FF5E) needs 0x20 added
+					if c > 0x00 && c < 0x5f && isF(input[i+2]) && isF(input[i+3]) {
+						c += 0x20
+					}
+
+					output = append(output, c)
+					i += 6
+				} else {
+					//Invalid encoding, copy the %u
+					output = append(output, input[i], input[i+1])
+					i += 2
+				}
+
+			case i+2 < len(input) && isHex(input[i+1]) && isHex(input[i+2]):
+				output = append(output, x2c(input[i+1], input[i+2]))
+				i += 3
+
+			default:
+				output = append(output, input[i])
+				i++
+			}
+
+		default:
+			output = append(output, input[i])
+			i++
+		}
+	}
+
+	return output
+}
+
+//cEscape returns the character of a single character escape sequence like \n.
+// If the escape is unknown c is returned and ok is false.
+func cEscape(c byte, withPunctuation bool) (byte, bool) {
+	switch c {
+	case 'a':
+		return '\a', true
+	case 'b':
+		return '\b', true
+	case 'f':
+		return '\f', true
+	case 'n':
+		return '\n', true
+	case 'r':
+		return '\r', true
+	case 't':
+		return '\t', true
+	case 'v':
+		return '\v', true
+	case '\\', '?', '\'', '"':
+		return c, withPunctuation
+	}
+
+	return c, false
+}
+
+//octalDigits returns the number of octal digits at the start of the input, at most 3
+func octalDigits(input []byte) int {
+	digits := 0
+	for digits < 3 && digits < len(input) && isODigit(input[digits]) {
+		digits++
+	}
+
+	return digits
+}
+
+//octal returns the lowest byte of the octal number
+func octal(digits []byte) byte {
+	value := 0
+	for _, digit := range digits {
+		value = value*8 + int(digit-'0')
+	}
+
+	return byte(value)
+}
+
+//x2c converts two hexadecimal characters into a byte, the characters are not validated
+func x2c(high, low byte) byte {
+	return xsingle2c(high)<<4 + xsingle2c(low)
+}
+
+//xsingle2c converts a single hexadecimal character into a byte, the character is not validated
+func xsingle2c(c byte) byte {
+	if c >= 'A' {
+		return (c & 0xdf) - 'A' + 10
+	}
+
+	return c - '0'
+}
+
+func isHex(c byte) bool {
+	return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
+
+func isF(c byte) bool {
+	return c == 'f' || c == 'F'
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isODigit(c byte) bool {
+	return c >= '0' && c <= '7'
+}
+
+func isAlnum(c byte) bool {
+	return isDigit(c) || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+//isSpace returns true for the characters which are whitespace in the C locale
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\v' || c == '\f' || c == '\r'
+}
@@ -0,0 +1,139 @@
+package transform
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/hex"
+	"strconv"
+)
+
+//Base64Encode encodes the input using padded Base64 encoding
+type Base64Encode struct{}
+
+//Apply encodes the input, "TestCase" becomes "VGVzdENhc2U="
+func (t *Base64Encode) Apply(input []byte) []byte {
+	output := make([]byte, base64.StdEncoding.EncodedLen(len(input)))
+	base64.StdEncoding.Encode(output, input)
+
+	return output
+}
+
+//HexEncode encodes the input using lower case hexadecimal characters
+type HexEncode struct{}
+
+//Apply encodes the input, "ABC" becomes "414243"
+func (t *HexEncode) Apply(input []byte) []byte {
+	output := make([]byte, hex.EncodedLen(len(input)))
+	hex.Encode(output, input)
+
+	return output
+}
+
+//Length replaces the input with its length in bytes
+type Length struct{}
+
+//Apply returns the decimal length of the input, "ABC" becomes "3"
+func (t *Length) Apply(input []byte) []byte {
+	return []byte(strconv.Itoa(len(input)))
+}
+
+//MD5 replaces the input with its MD5 hash in raw binary form
+type MD5 struct{}
+
+//Apply returns the 16 byte hash of the input
+func (t *MD5) Apply(input []byte) []byte {
+	hash := md5.Sum(input)
+
+	return hash[:]
+}
+
+//SHA1 replaces the input with its SHA1 hash in raw binary form
+type SHA1 struct{}
+
+//Apply returns the 20 byte hash of the input
+func (t *SHA1) Apply(input []byte) []byte {
+	hash := sha1.Sum(input)
+
+	return hash[:]
+}
+
+//UrlEncode encodes the input using URL encoding, only alphanumeric characters and '*' are not encoded
+type UrlEncode struct{}
+
+//Apply encodes the input, "a b/c" becomes "a+b%2fc"
+func (t *UrlEncode) Apply(input []byte) []byte {
+	const hexDigits = "0123456789abcdef"
+
+	output := make([]byte, 0, len(input))
+	for _, c := range input {
+		switch {
+		case c == ' ':
+			output = append(output, '+')
+		case c == '*' || isAlnum(c):
+			output = append(output, c)
+		default:
+			output = append(output, '%', hexDigits[c>>4], hexDigits[c&0xf])
+		}
+	}
+
+	return output
+}
+
+//UTF8ToUnicode converts UTF-8 multi byte sequences to the %uHHHH encoding, invalid sequences are left in the output
+type UTF8ToUnicode struct{}
+
+//Apply converts the input, "\xc3\xa9t\xc3\xa9" becomes "%u00e9t%u00e9"
+func (t *UTF8ToUnicode) Apply(input []byte) []byte {
+	output := make([]byte, 0, len(input))
+
+	i := 0
+	for i < len(input) {
+		c := input[i]
+
+		var (
+			length    int
+			codePoint rune
+		)
+
+		switch {
+		case c&0xe0 == 0xc0:
+			length, codePoint = 2, rune(c&0x1f)
+		case c&0xf0 == 0xe0:
+			length, codePoint = 3, rune(c&0x0f)
+		case c&0xf8 == 0xf0:
+			length, codePoint = 4, rune(c&0x07)
+		default:
+			output = append(output, c)
+			i++
+			continue
+		}
+
+		valid := i+length <= len(input)
+		for j := 1; valid && j < length; j++ {
+			if input[i+j]&0xc0 != 0x80 {
+				valid = false
+				break
+			}
+
+			codePoint = codePoint<<6 | rune(input[i+j]&0x3f)
+		}
+
+		if !valid {
+			output = append(output, c)
+			i++
+			continue
+		}
+
+		encoded := strconv.FormatInt(int64(codePoint), 16)
+		output = append(output, '%', 'u')
+		for padding := len(encoded); padding < 4; padding++ {
+			output = append(output, '0')
+		}
+
+		output = append(output, encoded...)
+		i += length
+	}
+
+	return output
+}
@@ -0,0 +1,518 @@
+package transform
+
+//CMDLine normalizes command line input to catch evasion techniques: the characters \ " ' and ^ are removed,
+// spaces before / and ( are removed, , and ; are replaced by a space, whitespace is compressed and the input is lower cased
+type CMDLine struct{}
+
+//Apply normalizes the input, "C^OMMAND /C DIR" becomes "command/c dir"
+func (t *CMDLine) Apply(input []byte) []byte {
+	output := make([]byte, 0, len(input))
+
+	space := false
+	for _, c := range input {
+		switch c {
+		case '"', '\'', '\\', '^':
+
+		case ' ', ',', ';', '\t', '\r', '\n':
+			if !space {
+				output = append(output, ' ')
+				space = true
+			}
+
+		case '/', '(':
+			if space {
+				output = output[:len(output)-1]
+				space = false
+			}
+
+			output = append(output, c)
+
+		default:
+			output = append(output, toLower(c))
+			space = false
+		}
+	}
+
+	return output
+}
+
+//CompressWhitespace converts whitespace characters (including the non-breaking space 0xa0) to spaces and
+// compresses consecutive spaces into one
+type CompressWhitespace struct{}
+
+//Apply compresses the input, "a \t\n b" becomes "a b"
+func (t *CompressWhitespace) Apply(input []byte) []byte {
+	output := make([]byte, 0, len(input))
+
+	space := false
+	for _, c := range input {
+		if isSpace(c) || c == 0xa0 {
+			if !space {
+				output = append(output, ' ')
+				space = true
+			}
+
+			continue
+		}
+
+		output = append(output, c)
+		space = false
+	}
+
+	return output
+}
+
+//Lowercase converts all ASCII characters to lower case
+type Lowercase struct{}
+
+//Apply converts the input, "SeLeCt" becomes "select"
+func (t *Lowercase) Apply(input []byte) []byte {
+	output := make([]byte, len(input))
+	for i, c := range input {
+		output[i] = toLower(c)
+	}
+
+	return output
+}
+
+//Uppercase converts all ASCII characters to upper case
+type Uppercase struct{}
+
+//Apply converts the input, "SeLeCt" becomes "SELECT"
+func (t *Uppercase) Apply(input []byte) []byte {
+	output := make([]byte, len(input))
+	for i, c := range input {
+		if c >= 'a' && c <= 'z' {
+			c -= 'a' - 'A'
+		}
+
+		output[i] = c
+	}
+
+	return output
+}
+
+//None doesn't change the input, in a pipeline it removes all transformations which come before it
+type None struct{}
+
+//Apply returns the input
+func (t *None) Apply(input []byte) []byte {
+	return input
+}
+
+//NormalizePath removes multiple slashes, directory self-references and directory back-references (except at the start of the input)
+type NormalizePath struct{}
+
+//Apply normalizes the input, "/dir/./sub//../file" becomes "/dir/file"
+func (t *NormalizePath) Apply(input []byte) []byte {
+	return normalizePath(input, false)
+}
+
+//NormalizePathWin normalizes the path like NormalizePath, after converting backslashes to forward slashes
+type NormalizePathWin struct{}
+
+//Apply normalizes the input, "C:\dir\.\sub\..\file" becomes "C:/dir/file"
+func (t *NormalizePathWin) Apply(input []byte) []byte {
+	return normalizePath(input, true)
+}
+
+//normalizePath is a port of the path normalization of ModSecurity, which works on a single buffer with a source
+// and destination index. Back-references which would go above the root of a relative path are kept.
+func normalizePath(input []byte, win bool) []byte {
+	if len(input) == 0 {
+		return []byte{}
+	}
+
+	buf := make([]byte, len(input))
+	copy(buf, input)
+
+	if win {
+		for i, c := range buf {
+			if c == '\\' {
+				buf[i] = '/'
+			}
+		}
+	}
+
+	var (
+		src, dst = 0, 0
+		end      = len(buf) - 1
+		relative = buf[0] != '/'
+		hitRoot  = false
+		done     = false
+
+		//A trailing slash is only kept if the input ends with one
+		trim = buf[end] != '/'
+	)
+
+	for !done && src <= end && dst <= end {
+		skip := false
+
+		switch {
+		//Always normalize at the end of the input
+		case src == end:
+			done = true
+		//Skip normalization if this is not the end of a path segment
+		case buf[src+1] != '/':
+			skip = true
+		}
+
+		if !skip {
+			switch {
+			//An empty path segment, the copy will take care of it
+			case src != end && buf[src] == '/':
+
+			case buf[src] == '.':
+				switch {
+				//Back-reference
+				case dst > 0 && buf[dst-1] == '.':
+					//If a relative path hit the root or this is a back-reference without a previous segment,
+					// no normalization is possible and the back-reference is copied
+					if relative && (hitRoot || dst-2 <= 0) {
+						hitRoot = true
+						break
+					}
+
+					//Remove the back-reference and the previous path segment
+					dst -= 3
+					for dst > 0 && buf[dst] != '/' {
+						dst--
+					}
+
+					//But don't go above the root
+					if dst <= 0 {
+						hitRoot = true
+						dst = 0
+
+						//Keep the root slash of an absolute path if the input ends with a back-reference
+						if !relative && src == end {
+							dst++
+						}
+					}
+
+					if done {
+						continue
+					}
+
+					src++
+
+				//Relative self-reference
+				case dst == 0:
+					if done {
+						continue
+					}
+
+					src++
+
+				//Self-reference
+				case buf[dst-1] == '/':
+					if done {
+						continue
+					}
+
+					dst--
+					src++
+				}
+
+			//A regular path segment
+			case dst > 0:
+				hitRoot = false
+			}
+		}
+
+		//Skip to the last slash when multiple are used
+		if buf[src] == '/' {
+			for src < end && buf[src+1] == '/' {
+				src++
+			}
+
+			//Don't copy the leading slash of a relative path which was reduced to nothing
+			if relative && dst == 0 {
+				src++
+				continue
+			}
+		}
+
+		buf[dst] = buf[src]
+		dst++
+		src++
+	}
+
+	if trim && dst > 0 && buf[dst-1] == '/' {
+		dst--
+	}
+
+	return buf[:dst]
+}
+
+//ParityEven7Bit replaces the 8th bit of each byte with an even parity bit
+type ParityEven7Bit struct{}
+
+//Apply calculates the parity of every byte
+func (t *ParityEven7Bit) Apply(input []byte) []byte {
+	output := make([]byte, len(input))
+	for i, c := range input {
+		if parityOdd(c) {
+			output[i] = c | 0x80
+		} else {
+			output[i] = c & 0x7f
+		}
+	}
+
+	return output
+}
+
+//ParityOdd7Bit replaces the 8th bit of each byte with an odd parity bit
+type ParityOdd7Bit struct{}
+
+//Apply calculates the parity of every byte
+func (t *ParityOdd7Bit) Apply(input []byte) []byte {
+	output := make([]byte, len(input))
+	for i, c := range input {
+		if parityOdd(c) {
+			output[i] = c & 0x7f
+		} else {
+			output[i] = c | 0x80
+		}
+	}
+
+	return output
+}
+
+//parityOdd returns true if an odd number of bits is set, like ModSecurity all 8 bits are counted
+func parityOdd(c byte) bool {
+	c ^= c >> 4
+	c &= 0xf
+
+	return (0x6996>>c)&1 == 1
+}
+
+//ParityZero7Bit sets the 8th bit of each byte to zero
+type ParityZero7Bit struct{}
+
+//Apply clears the 8th bit of every byte
+func (t *ParityZero7Bit) Apply(input []byte) []byte {
+	output := make([]byte, len(input))
+	for i, c := range input {
+		output[i] = c & 0x7f
+	}
+
+	return output
+}
+
+//RemoveComments removes /* */ and <!-- --> comments, the -- and # comments remove the rest of the input
+type RemoveComments struct{}
+
+//Apply removes the comments, "SELECT/* a */1-- x" becomes "SELECT1"
+func (t *RemoveComments) Apply(input []byte) []byte {
+	output := make([]byte, 0, len(input))
+
+	inComment := false
+	i := 0
+
+loop:
+	for i < len(input) {
+		if !inComment {
+			switch {
+			case hasPrefixAt(input, i, "/*"):
+				inComment = true
+				i += 2
+			case hasPrefixAt(input, i, "<!--"):
+				inComment = true
+				i += 4
+			case hasPrefixAt(input, i, "--"), input[i] == '#':
+				break loop
+			default:
+				output = append(output, input[i])
+				i++
+			}
+
+			continue
+		}
+
+		switch {
+		case hasPrefixAt(input, i, "*/"):
+			i += 2
+		case hasPrefixAt(input, i, "-->"):
+			i += 3
+		default:
+			i++
+			continue
+		}
+
+		//The character directly after a comment is always copied
+		inComment = false
+		if i < len(input) {
+			output = append(output, input[i])
+			i++
+		}
+	}
+
+	if inComment {
+		output = append(output, ' ')
+	}
+
+	return output
+}
+
+//RemoveCommentsChar removes the comment characters /* */ -- # <!-- and -->, but not the contents of the comments
+type RemoveCommentsChar struct{}
+
+//Apply removes the comment characters, "SELECT/* a */1-- x" becomes "SELECT a 1 x"
+func (t *RemoveCommentsChar) Apply(input []byte) []byte {
+	output := make([]byte, 0, len(input))
+
+	i := 0
+	for i < len(input) {
+		switch {
+		case hasPrefixAt(input, i, "/*"), hasPrefixAt(input, i, "*/"):
+			i += 2
+		case hasPrefixAt(input, i, "<!--"):
+			i += 4
+		case hasPrefixAt(input, i, "-->"):
+			i += 3
+		case hasPrefixAt(input, i, "--"):
+			i += 2
+		case input[i] == '#':
+			i++
+		default:
+			output = append(output, input[i])
+			i++
+		}
+	}
+
+	return output
+}
+
+//RemoveNulls removes all NUL bytes
+type RemoveNulls struct{}
+
+//Apply removes the NUL bytes, "a\x00b" becomes "ab"
+func (t *RemoveNulls) Apply(input []byte) []byte {
+	output := make([]byte, 0, len(input))
+	for _, c := range input {
+		if c != 0 {
+			output = append(output, c)
+		}
+	}
+
+	return output
+}
+
+//RemoveWhitespace removes all whitespace characters, including the non-breaking space 0xa0
+type RemoveWhitespace struct{}
+
+//Apply removes the whitespace, "a \tb" becomes "ab"
+func (t *RemoveWhitespace) Apply(input []byte) []byte {
+	output := make([]byte, 0, len(input))
+	for _, c := range input {
+		if !isSpace(c) && c != 0xa0 {
+			output = append(output, c)
+		}
+	}
+
+	return output
+}
+
+//ReplaceComments replaces each /* */ comment with a single space, an unterminated comment is also replaced
+type ReplaceComments struct{}
+
+//Apply replaces the comments, "SELECT/* a */1/* b" becomes "SELECT 1 "
+func (t *ReplaceComments) Apply(input []byte) []byte {
+	output := make([]byte, 0, len(input))
+
+	inComment := false
+	i := 0
+	for i < len(input) {
+		switch {
+		case !inComment && hasPrefixAt(input, i, "/*"):
+			inComment = true
+			i += 2
+		case !inComment:
+			output = append(output, input[i])
+			i++
+		case hasPrefixAt(input, i, "*/"):
+			inComment = false
+			output = append(output, ' ')
+			i += 2
+		default:
+			i++
+		}
+	}
+
+	if inComment {
+		output = append(output, ' ')
+	}
+
+	return output
+}
+
+//ReplaceNulls replaces all NUL bytes with spaces
+type ReplaceNulls struct{}
+
+//Apply replaces the NUL bytes, "a\x00b" becomes "a b"
+func (t *ReplaceNulls) Apply(input []byte) []byte {
+	output := make([]byte, len(input))
+	for i, c := range input {
+		if c == 0 {
+			c = ' '
+		}
+
+		output[i] = c
+	}
+
+	return output
+}
+
+//Trim removes whitespace from both sides of the input
+type Trim struct{}
+
+//Apply trims the input, " a b " becomes "a b"
+func (t *Trim) Apply(input []byte) []byte {
+	return trimRight(trimLeft(input))
+}
+
+//TrimLeft removes whitespace from the left side of the input
+type TrimLeft struct{}
+
+//Apply trims the input, " a b " becomes "a b "
+func (t *TrimLeft) Apply(input []byte) []byte {
+	return trimLeft(input)
+}
+
+//TrimRight removes whitespace from the right side of the input
+type TrimRight struct{}
+
+//Apply trims the input, " a b " becomes " a b"
+func (t *TrimRight) Apply(input []byte) []byte {
+	return trimRight(input)
+}
+
+func trimLeft(input []byte) []byte {
+	for len(input) > 0 && isSpace(input[0]) {
+		input = input[1:]
+	}
+
+	return input
+}
+
+func trimRight(input []byte) []byte {
+	for len(input) > 0 && isSpace(input[len(input)-1]) {
+		input = input[:len(input)-1]
+	}
+
+	return input
+}
+
+//hasPrefixAt returns true if the input contains the prefix at index i
+func hasPrefixAt(input []byte, i int, prefix string) bool {
+	return len(input)-i >= len(prefix) && string(input[i:i+len(prefix)]) == prefix
+}
+
+func toLower(c byte) byte {
+	if c >= 'A' && c <= 'Z' {
+		return c + 'a' - 'A'
+	}
+
+	return c
+}
@@ -0,0 +1,155 @@
+//Package transform contains the executable implementations of the ModSecurity transformation functions.
+// The implementations follow the byte level behaviour of ModSecurity v2, including its quirks.
+package transform
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dylandreimerink/go-modsec-parser/ast"
+)
+
+//ErrUnsupported is returned by New for transformations which are not implemented
+var ErrUnsupported = errors.New("Unsupported transform type")
+
+//Transformer is the implementation of a transformation function
+type Transformer interface {
+	//Apply returns the transformed input, the input itself is never modified
+	Apply(input []byte) []byte
+}
+
+//New returns the implementation of the transform type
+func New(transform ast.TransformType) (Transformer, error) {
+	switch transform.(type) {
+	case *ast.TransformBase64Decode:
+		return &Base64Decode{}, nil
+	case *ast.TransformBase64DecodeExt:
+		return &Base64DecodeExt{}, nil
+	case *ast.TransformBase64Encode:
+		return &Base64Encode{}, nil
+	case *ast.TransformCMDLine:
+		return &CMDLine{}, nil
+	case *ast.TransformCompressWhitespace:
+		return &CompressWhitespace{}, nil
+	case *ast.TransformCSSDecode:
+		return &CSSDecode{}, nil
+	case *ast.TransformEscapeSeqDecode:
+		return &EscapeSeqDecode{}, nil
+	case *ast.TransformHexDecode:
+		return &HexDecode{}, nil
+	case *ast.TransformHexEncode:
+		return &HexEncode{}, nil
+	case *ast.TransformHTMLEntityDecode:
+		return &HTMLEntityDecode{}, nil
+	case *ast.TransformJSDecode:
+		return &JSDecode{}, nil
+	case *ast.TransformLength:
+		return &Length{}, nil
+	case *ast.TransformLowercase:
+		return &Lowercase{}, nil
+	case *ast.TransformMD5:
+		return &MD5{}, nil
+	case *ast.TransformNone:
+		return &None{}, nil
+	case *ast.TransformNormalizePath:
+		return &NormalizePath{}, nil
+	case *ast.TransformNormalizePathWin:
+		return &NormalizePathWin{}, nil
+	case *ast.TransformParityEven7Bit:
+		return &ParityEven7Bit{}, nil
+	case *ast.TransformParityOdd7Bit:
+		return &ParityOdd7Bit{}, nil
+	case *ast.TransformParityZero7Bit:
+		return &ParityZero7Bit{}, nil
+	case *ast.TransformRemoveComments:
+		return &RemoveComments{}, nil
+	case *ast.TransformRemoveCommentsChar:
+		return &RemoveCommentsChar{}, nil
+	case *ast.TransformRemoveNulls:
+		return &RemoveNulls{}, nil
+	case *ast.TransformRemoveWhitespace:
+		return &RemoveWhitespace{}, nil
+	case *ast.TransformReplaceComments:
+		return &ReplaceComments{}, nil
+	case *ast.TransformReplaceNulls:
+		return &ReplaceNulls{}, nil
+	case *ast.TransformSHA1:
+		return &SHA1{}, nil
+	case *ast.TransformSQLHexDecode:
+		return &SQLHexDecode{}, nil
+	case *ast.TransformTrim:
+		return &Trim{}, nil
+	case *ast.TransformTrimLeft:
+		return &TrimLeft{}, nil
+	case *ast.TransformTrimRight:
+		return &TrimRight{}, nil
+	case *ast.TransformUppercase:
+		return &Uppercase{}, nil
+	case *ast.TransformUrlDecode:
+		return &UrlDecode{}, nil
+	case *ast.TransformUrlDecodeUni:
+		return &UrlDecodeUni{}, nil
+	case *ast.TransformUrlEncode:
+		return &UrlEncode{}, nil
+	case *ast.TransformUTF8ToUnicode:
+		return &UTF8ToUnicode{}, nil
+	}
+
+	return nil, fmt.Errorf("%w '%s'", ErrUnsupported, transform.Name())
+}
+
+//Pipeline is the list of transformations of a rule, which are applied in order
+type Pipeline []Transformer
+
+//NewPipeline builds the pipeline of the transform actions in the list of actions, other actions are ignored.
+// A t:none action removes all transformations which come before it, like it does in ModSecurity.
+func NewPipeline(actions []ast.Action) (Pipeline, error) {
+	pipeline := Pipeline{}
+
+	for _, action := range actions {
+		transformAction, ok := action.(*ast.ActionTransform)
+		if !ok {
+			continue
+		}
+
+		if _, ok := transformAction.Value.(*ast.TransformNone); ok {
+			pipeline = Pipeline{}
+			continue
+		}
+
+		transformer, err := New(transformAction.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		pipeline = append(pipeline, transformer)
+	}
+
+	return pipeline, nil
+}
+
+//Apply applies all transformations of the pipeline in order
+func (p Pipeline) Apply(input []byte) []byte {
+	for _, transformer := range p {
+		input = transformer.Apply(input)
+	}
+
+	return input
+}
+
+//ApplyAll returns the input followed by the result of every transformation which changed the value,
+// this is the list of values which are inspected when the multiMatch action is used
+func (p Pipeline) ApplyAll(input []byte) [][]byte {
+	values := [][]byte{input}
+
+	for _, transformer := range p {
+		output := transformer.Apply(input)
+		if string(output) != string(input) {
+			values = append(values, output)
+		}
+
+		input = output
+	}
+
+	return values
+}
@@ -0,0 +1,231 @@
+package transform
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/dylandreimerink/go-modsec-parser/ast"
+)
+
+func TestTransformers(t *testing.T) {
+	tests := []struct {
+		name        string
+		transformer Transformer
+		input       string
+		want        string
+	}{
+		{"base64Decode", &Base64Decode{}, "VGVzdENhc2U=", "TestCase"},
+		{"base64Decode unpadded", &Base64Decode{}, "VGVzdENhc2U", "TestCase"},
+		{"base64Decode stops at invalid character", &Base64Decode{}, "VGVz.dENh", "Tes"},
+		{"base64Decode empty", &Base64Decode{}, "", ""},
+
+		{"base64DecodeExt", &Base64DecodeExt{}, "VGVz dENh\nc2U=", "TestCase"},
+		{"base64DecodeExt skips invalid characters", &Base64DecodeExt{}, "VGVz.dENh", "TestCa"},
+
+		{"base64Encode", &Base64Encode{}, "TestCase", "VGVzdENhc2U="},
+		{"base64Encode empty", &Base64Encode{}, "", ""},
+
+		{"cmdLine", &CMDLine{}, "C^OMMAND /C DIR", "command/c dir"},
+		{"cmdLine quotes and backslashes", &CMDLine{}, `c"a't\ /etc/passwd`, "cat/etc/passwd"},
+		{"cmdLine separators", &CMDLine{}, "a,b;c\t\r\nd", "a b c d"},
+		{"cmdLine space before parenthesis", &CMDLine{}, "echo  (1)", "echo(1)"},
+
+		{"compressWhitespace", &CompressWhitespace{}, "a \t\n b", "a b"},
+		{"compressWhitespace non-breaking space", &CompressWhitespace{}, "a\xa0\xa0b", "a b"},
+
+		{"cssDecode", &CSSDecode{}, `\3c script`, "<script"},
+		{"cssDecode without whitespace", &CSSDecode{}, `\3Cscript`, "<script"},
+		{"cssDecode full width", &CSSDecode{}, `\ff1c`, "<"},
+		{"cssDecode lowest byte", &CSSDecode{}, `\00003c`, "<"},
+		{"cssDecode escaped character", &CSSDecode{}, `\z`, "z"},
+		{"cssDecode line continuation", &CSSDecode{}, "a\\\nb", "ab"},
+		{"cssDecode trailing backslash", &CSSDecode{}, `a\`, "a"},
+
+		{"escapeSeqDecode", &EscapeSeqDecode{}, `\x41\102\n`, "AB\n"},
+		{"escapeSeqDecode punctuation", &EscapeSeqDecode{}, `\"\'\\\?`, `"'\?`},
+		{"escapeSeqDecode unknown escape", &EscapeSeqDecode{}, `\q`, "q"},
+		{"escapeSeqDecode invalid hex", &EscapeSeqDecode{}, `\xZZ`, "xZZ"},
+		{"escapeSeqDecode trailing backslash", &EscapeSeqDecode{}, `a\`, `a\`},
+
+		{"hexDecode", &HexDecode{}, "414243", "ABC"},
+		{"hexDecode odd length", &HexDecode{}, "41424", "AB"},
+
+		{"hexEncode", &HexEncode{}, "ABC", "414243"},
+
+		{"htmlEntityDecode", &HTMLEntityDecode{}, "&lt;script&gt;", "<script>"},
+		{"htmlEntityDecode numeric", &HTMLEntityDecode{}, "&#x3c;script&#62", "<script>"},
+		{"htmlEntityDecode quot amp nbsp", &HTMLEntityDecode{}, "&quot;&amp&NBSP;", "\"&\xa0"},
+		{"htmlEntityDecode unknown entity", &HTMLEntityDecode{}, "&foo;", "&foo;"},
+		{"htmlEntityDecode incomplete", &HTMLEntityDecode{}, "&#;&#x;&", "&#;&#x;&"},
+
+		{"jsDecode", &JSDecode{}, `\u0041\x42\103\n`, "ABC\n"},
+		{"jsDecode full width", &JSDecode{}, `\uff1c`, "<"},
+		{"jsDecode octal out of range", &JSDecode{}, `\777`, "?7"},
+		{"jsDecode unknown escape", &JSDecode{}, `\z`, "z"},
+		{"jsDecode invalid unicode", &JSDecode{}, `\u00G1`, "u00G1"},
+
+		{"length", &Length{}, "ABC", "3"},
+		{"length empty", &Length{}, "", "0"},
+
+		{"lowercase", &Lowercase{}, "SeLeCt", "select"},
+
+		{"none", &None{}, "SeLeCt", "SeLeCt"},
+
+		{"normalizePath", &NormalizePath{}, "/dir/./sub//../file", "/dir/file"},
+		{"normalizePath empty", &NormalizePath{}, "", ""},
+		{"normalizePath segment", &NormalizePath{}, "x", "x"},
+		{"normalizePath self-reference", &NormalizePath{}, ".", ""},
+		{"normalizePath self-reference slash", &NormalizePath{}, "./", ""},
+		{"normalizePath self-reference back-reference", &NormalizePath{}, "./..", ".."},
+		{"normalizePath self-reference back-reference slash", &NormalizePath{}, "./../", "../"},
+		{"normalizePath back-reference", &NormalizePath{}, "..", ".."},
+		{"normalizePath back-reference slash", &NormalizePath{}, "../", "../"},
+		{"normalizePath back-reference self-reference", &NormalizePath{}, "../.", ".."},
+		{"normalizePath back-reference self-reference slash", &NormalizePath{}, ".././", "../"},
+		{"normalizePath back-references", &NormalizePath{}, "../..", "../.."},
+		{"normalizePath back-references slash", &NormalizePath{}, "../../", "../../"},
+		{"normalizePath multiple slashes", &NormalizePath{}, "/dir/foo//bar", "/dir/foo/bar"},
+		{"normalizePath relative multiple slashes", &NormalizePath{}, "dir/foo//bar/", "dir/foo/bar/"},
+		{"normalizePath relative back-reference", &NormalizePath{}, "dir/../foo", "foo"},
+		{"normalizePath above relative root", &NormalizePath{}, "dir/../../foo", "../foo"},
+		{"normalizePath mixed", &NormalizePath{}, "dir/./.././../../foo/bar", "../../foo/bar"},
+		{"normalizePath mixed self-reference", &NormalizePath{}, "dir/./.././../../foo/bar/.", "../../foo/bar"},
+		{"normalizePath mixed self-reference slash", &NormalizePath{}, "dir/./.././../../foo/bar/./", "../../foo/bar/"},
+		{"normalizePath mixed back-reference", &NormalizePath{}, "dir/./.././../../foo/bar/..", "../../foo"},
+		{"normalizePath mixed back-reference slash", &NormalizePath{}, "dir/./.././../../foo/bar/../", "../../foo/"},
+		{"normalizePath mixed slash", &NormalizePath{}, "dir/./.././../../foo/bar/", "../../foo/bar/"},
+		{"normalizePath mixed multiple slashes", &NormalizePath{}, "dir//.//..//.//..//..//foo//bar", "../../foo/bar"},
+		{"normalizePath mixed multiple slashes end", &NormalizePath{}, "dir//.//..//.//..//..//foo//bar//", "../../foo/bar/"},
+		{"normalizePath back-references to start", &NormalizePath{}, "dir/subdir/subsubdir/subsubsubdir/../../..", "dir"},
+		{"normalizePath self and back-references", &NormalizePath{}, "dir/./subdir/./subsubdir/./subsubsubdir/../../..", "dir"},
+		{"normalizePath alternating", &NormalizePath{}, "dir/./subdir/../subsubdir/../subsubsubdir/..", "dir"},
+		{"normalizePath absolute alternating", &NormalizePath{}, "/dir/./subdir/../subsubdir/../subsubsubdir/../", "/dir/"},
+		{"normalizePath above absolute root", &NormalizePath{}, "/./.././../../../../../../../etc/./passwd", "/etc/passwd"},
+
+		{"normalizePathWin", &NormalizePathWin{}, `C:\dir\.\sub\..\file`, "C:/dir/file"},
+		{"normalizePathWin mixed", &NormalizePathWin{}, `dir\.\..\.\..\..\foo\bar`, "../../foo/bar"},
+		{"normalizePathWin above absolute root", &NormalizePathWin{}, `\..\..\windows\.\win.ini`, "/windows/win.ini"},
+
+		{"parityEven7bit", &ParityEven7Bit{}, "AC\xc1\x81", "A\xc3\xc1\x01"},
+		{"parityOdd7bit", &ParityOdd7Bit{}, "AC\xc1\x81", "\xc1CA\x81"},
+		{"parityZero7bit", &ParityZero7Bit{}, "A\xc3\xff", "AC\x7f"},
+
+		{"removeComments", &RemoveComments{}, "SELECT/* a */1-- x", "SELECT1"},
+		{"removeComments html", &RemoveComments{}, "a<!-- b -->c", "ac"},
+		{"removeComments hash", &RemoveComments{}, "a#b", "a"},
+		{"removeComments unterminated", &RemoveComments{}, "a/* b", "a "},
+
+		{"removeCommentsChar", &RemoveCommentsChar{}, "SELECT/* a */1-- x", "SELECT a 1 x"},
+		{"removeCommentsChar html", &RemoveCommentsChar{}, "<!--a-->#b", "ab"},
+
+		{"removeNulls", &RemoveNulls{}, "a\x00b\x00", "ab"},
+
+		{"removeWhitespace", &RemoveWhitespace{}, "a \tb\xa0c\r\n", "abc"},
+
+		{"replaceComments", &ReplaceComments{}, "SELECT/* a */1/* b", "SELECT 1 "},
+		{"replaceComments no comment", &ReplaceComments{}, "a*/b", "a*/b"},
+
+		{"replaceNulls", &ReplaceNulls{}, "a\x00b", "a b"},
+
+		{"sqlHexDecode", &SQLHexDecode{}, "0x414243", "ABC"},
+		{"sqlHexDecode in query", &SQLHexDecode{}, "SELECT 0x6162 FROM", "SELECT ab FROM"},
+		{"sqlHexDecode odd trailing character", &SQLHexDecode{}, "0x41z", "Az"},
+		{"sqlHexDecode too short", &SQLHexDecode{}, "0x4", "0x4"},
+
+		{"trim", &Trim{}, " \ta b \n", "a b"},
+		{"trimLeft", &TrimLeft{}, " a b ", "a b "},
+		{"trimRight", &TrimRight{}, " a b ", " a b"},
+
+		{"uppercase", &Uppercase{}, "SeLeCt", "SELECT"},
+
+		{"urlDecode", &UrlDecode{}, "%3Cscript%3E+x", "<script> x"},
+		{"urlDecode invalid", &UrlDecode{}, "%zz%4%", "%zz%4%"},
+		{"urlDecode unicode is not decoded", &UrlDecode{}, "%u003C", "%u003C"},
+
+		{"urlDecodeUni", &UrlDecodeUni{}, "%u003Cscript%3E", "<script>"},
+		{"urlDecodeUni full width", &UrlDecodeUni{}, "%uff1c", "<"},
+		{"urlDecodeUni invalid", &UrlDecodeUni{}, "%u12", "%u12"},
+
+		{"urlEncode", &UrlEncode{}, "a b/c", "a+b%2fc"},
+		{"urlEncode unreserved", &UrlEncode{}, "a*1-_.~", "a*1%2d%5f%2e%7e"},
+
+		{"utf8toUnicode", &UTF8ToUnicode{}, "\xc3\xa9t\xc3\xa9", "%u00e9t%u00e9"},
+		{"utf8toUnicode three bytes", &UTF8ToUnicode{}, "\xe2\x82\xac", "%u20ac"},
+		{"utf8toUnicode four bytes", &UTF8ToUnicode{}, "\xf0\x9f\x98\x80", "%u1f600"},
+		{"utf8toUnicode invalid", &UTF8ToUnicode{}, "\xc3a", "\xc3a"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			input := []byte(test.input)
+			got := test.transformer.Apply(input)
+
+			if string(got) != test.want {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+
+			if string(input) != test.input {
+				t.Errorf("input was modified to %q", input)
+			}
+		})
+	}
+}
+
+func TestHashes(t *testing.T) {
+	tests := []struct {
+		name        string
+		transformer Transformer
+		input       string
+		want        string
+	}{
+		{"md5 empty", &MD5{}, "", "d41d8cd98f00b204e9800998ecf8427e"},
+		{"md5", &MD5{}, "The quick brown fox jumps over the lazy dog", "9e107d9d372bb6826bd81d3542a419d6"},
+		{"sha1 empty", &SHA1{}, "", "da39a3ee5e6b4b0d3255bfef95601890afd80709"},
+		{"sha1", &SHA1{}, "The quick brown fox jumps over the lazy dog", "2fd4e1c67a2d28fced849ee1bb76e7391b93eb12"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := hex.EncodeToString(test.transformer.Apply([]byte(test.input)))
+			if got != test.want {
+				t.Errorf("got %s, want %s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestNewPipeline(t *testing.T) {
+	actions := []ast.Action{
+		&ast.ActionTransform{Value: &ast.TransformUppercase{}},
+		&ast.ActionTransform{Value: &ast.TransformNone{}},
+		&ast.ActionID{Value: 1},
+		&ast.ActionTransform{Value: &ast.TransformUrlDecode{}},
+		&ast.ActionTransform{Value: &ast.TransformLowercase{}},
+		&ast.ActionTransform{Value: &ast.TransformCompressWhitespace{}},
+	}
+
+	pipeline, err := NewPipeline(actions)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(pipeline) != 3 {
+		t.Fatalf("got %d transformations, want 3", len(pipeline))
+	}
+
+	if got := string(pipeline.Apply([]byte("SELECT%20%20*"))); got != "select *" {
+		t.Errorf("got %q, want %q", got, "select *")
+	}
+
+	all := pipeline.ApplyAll([]byte("A%41"))
+	want := []string{"A%41", "AA", "aa"}
+	if len(all) != len(want) {
+		t.Fatalf("got %q, want %q", all, want)
+	}
+
+	for i := range want {
+		if string(all[i]) != want[i] {
+			t.Errorf("got %q, want %q", all, want)
+		}
+	}
+}